@@ -0,0 +1,239 @@
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+)
+
+func dataSourceDatadogDashboards() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list several existing dashboards, optionally with their full JSON definition, for use in other resources or to snapshot dashboards managed outside of Terraform.",
+		ReadContext: dataSourceDatadogDashboardsRead,
+
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"title_filter": {
+					Description: "A string to limit the search. Only dashboards whose title contains this value (case-insensitive) are returned.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"author_filter": {
+					Description: "The handle of the dashboard author to limit the search.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"tags_filter": {
+					Description: "A list of tags to limit the search. Only dashboards having all of these tags are returned.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"dashboard_list_id": {
+					Description: "The ID of a dashboard list to limit the search. Only dashboards belonging to this list are returned.",
+					Type:        schema.TypeInt,
+					Optional:    true,
+				},
+				"shared_filter": {
+					Description: "When `true`, only shared dashboards are returned.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"include_definition": {
+					Description: "When `true`, the full JSON definition of each dashboard is fetched and exposed in the `definition` attribute. This performs one additional API call per dashboard.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+
+				// Computed values
+				"dashboards": {
+					Description: "List of dashboards matching the search.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Description: "ID of the dashboard.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"title": {
+								Description: "Title of the dashboard.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"url": {
+								Description: "URL of the dashboard.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"author_handle": {
+								Description: "Handle of the dashboard author.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"layout_type": {
+								Description: "Layout type of the dashboard.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"definition": {
+								Description: "The JSON formatted definition of the dashboard, in the format expected by the `datadog_dashboard_json` resource. Only set when `include_definition` is `true`.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+func dataSourceDatadogDashboardsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	optionalParams := datadogV1.NewListDashboardsOptionalParameters()
+	if d.Get("shared_filter").(bool) {
+		optionalParams = optionalParams.WithFilterShared(true)
+	}
+
+	dashResponse, httpresp, err := apiInstances.GetDashboardsApiV1().ListDashboards(auth, *optionalParams)
+	if err != nil {
+		return utils.TranslateClientErrorDiag(err, httpresp, "error querying dashboards")
+	}
+
+	var listMembers map[string]struct{}
+	if v, ok := d.GetOk("dashboard_list_id"); ok {
+		items, httpresp, err := apiInstances.GetDashboardListsApiV2().GetDashboardListItems(auth, int64(v.(int)))
+		if err != nil {
+			return utils.TranslateClientErrorDiag(err, httpresp, "error querying dashboard list items")
+		}
+		listMembers = make(map[string]struct{}, len(items.GetDashboards()))
+		for _, item := range items.GetDashboards() {
+			listMembers[item.GetId()] = struct{}{}
+		}
+	}
+
+	titleFilter := strings.ToLower(d.Get("title_filter").(string))
+	authorFilter := d.Get("author_filter").(string)
+	tagsFilter := expandStringList(d.Get("tags_filter").([]interface{}))
+	includeDefinition := d.Get("include_definition").(bool)
+
+	diags := diag.Diagnostics{}
+	tfDashboards := make([]map[string]interface{}, 0)
+	for _, dash := range dashResponse.GetDashboards() {
+		if err := utils.CheckForUnparsed(dash); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("skipping dashboard with id: %v", dash.GetId()),
+				Detail:   fmt.Sprintf("dashboard contains unparsed object: %v", err),
+			})
+			continue
+		}
+		if titleFilter != "" && !strings.Contains(strings.ToLower(dash.GetTitle()), titleFilter) {
+			continue
+		}
+		if authorFilter != "" && dash.GetAuthorHandle() != authorFilter {
+			continue
+		}
+		if listMembers != nil {
+			if _, ok := listMembers[dash.GetId()]; !ok {
+				continue
+			}
+		}
+
+		// Tags are not part of the dashboard summary, so the full definition is needed
+		// as soon as we either filter on them or have to return the definition.
+		definition := ""
+		if len(tagsFilter) > 0 || includeDefinition {
+			dashMap, err := getDashboardDefinitionMap(auth, apiInstances, dash.GetId())
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if !dashboardHasTags(dashMap, tagsFilter) {
+				continue
+			}
+			if includeDefinition {
+				utils.PrepDashboardJSON(dashMap)
+				definition, err = structure.FlattenJsonToString(dashMap)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+		}
+
+		tfDashboards = append(tfDashboards, map[string]interface{}{
+			"id":            dash.GetId(),
+			"title":         dash.GetTitle(),
+			"url":           dash.GetUrl(),
+			"author_handle": dash.GetAuthorHandle(),
+			"layout_type":   string(dash.GetLayoutType()),
+			"definition":    definition,
+		})
+	}
+
+	d.SetId(computeDashboardsDatasourceID(d))
+	if err := d.Set("dashboards", tfDashboards); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func getDashboardDefinitionMap(auth context.Context, apiInstances *utils.ApiInstances, id string) (map[string]interface{}, error) {
+	respByte, httpresp, err := utils.SendRequest(auth, apiInstances.HttpClient, "GET", path+"/"+id, nil)
+	if err != nil {
+		return nil, utils.TranslateClientError(err, httpresp, fmt.Sprintf("error getting dashboard %s", id))
+	}
+	return utils.ConvertResponseByteToMap(respByte)
+}
+
+func dashboardHasTags(dashMap map[string]interface{}, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	dashTags := make(map[string]struct{})
+	if rawTags, ok := dashMap["tags"].([]interface{}); ok {
+		for _, t := range rawTags {
+			if s, ok := t.(string); ok {
+				dashTags[s] = struct{}{}
+			}
+		}
+	}
+	for _, t := range tags {
+		if _, ok := dashTags[t]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func computeDashboardsDatasourceID(d *schema.ResourceData) string {
+	var dsID strings.Builder
+	dsID.WriteString(d.Get("title_filter").(string))
+	dsID.WriteRune('|')
+	dsID.WriteString(d.Get("author_filter").(string))
+	dsID.WriteRune('|')
+	dsID.WriteString(strings.Join(expandStringList(d.Get("tags_filter").([]interface{})), ","))
+	dsID.WriteRune('|')
+	if v, ok := d.GetOk("dashboard_list_id"); ok {
+		dsID.WriteString(fmt.Sprint(v.(int)))
+	}
+	dsID.WriteRune('|')
+	dsID.WriteString(fmt.Sprint(d.Get("shared_filter").(bool)))
+	dsID.WriteRune('|')
+	dsID.WriteString(fmt.Sprint(d.Get("include_definition").(bool)))
+	return dsID.String()
+}
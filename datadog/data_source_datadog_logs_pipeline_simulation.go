@@ -0,0 +1,116 @@
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDatadogLogsPipelineSimulation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to run sample logs through a logs pipeline locally, without calling the Datadog API, to check its processors before applying it. Parsing is an approximation of the Datadog one: the `geo_ip_parser`, `reference_table_lookup_processor`, `span_id_remapper` and `raw_processor` processors are skipped, and the user agent parser only recognizes the most common user agents.",
+		ReadContext: dataSourceDatadogLogsPipelineSimulationRead,
+
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"pipeline": {
+					Description: "Definition of the pipeline, with the same format as the `datadog_logs_custom_pipeline` resource.",
+					Type:        schema.TypeList,
+					Required:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: getPipelineSchema(false),
+					},
+				},
+				"events": {
+					Description: "Sample log events to process, either JSON objects or raw lines used as the `message` of the log.",
+					Type:        schema.TypeList,
+					Required:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+
+				// Computed values
+				"results": {
+					Description: "Events after being processed by the pipeline, in the order of `events`.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"event": {
+								Description: "Processed event, as a JSON object.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"matched": {
+								Description: "Whether the event matched the filter of the pipeline. Events not matching it are returned unchanged.",
+								Type:        schema.TypeBool,
+								Computed:    true,
+							},
+						},
+					},
+				},
+				"warnings": {
+					Description: "Processors of the pipeline which couldn't be simulated.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			}
+		},
+	}
+}
+
+func dataSourceDatadogLogsPipelineSimulationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tfPipeline, ok := d.Get("pipeline.0").(map[string]interface{})
+	if !ok {
+		return diag.Errorf("pipeline must be set")
+	}
+	ddPipeline, err := buildDatadogNestedPipeline(tfPipeline)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	simulator, err := newLogsPipelineSimulator(ddPipeline)
+	if err != nil {
+		return diag.Errorf("error compiling pipeline: %s", err)
+	}
+
+	diags := diag.Diagnostics{}
+	for _, warning := range simulator.warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "pipeline is only partially simulated",
+			Detail:   warning,
+		})
+	}
+	diags = append(diags, logsGrokSampleWarnings(ddPipeline.GetProcessors(), "pipeline.0.")...)
+
+	events := utils.GetStringSlice(d, "events")
+	results := make([]map[string]interface{}, len(events))
+	for i, rawEvent := range events {
+		processed, matched := simulator.process(parseLogsSimulationEvent(rawEvent))
+		encoded, err := json.Marshal(processed)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		results[i] = map[string]interface{}{
+			"event":   string(encoded),
+			"matched": matched,
+		}
+	}
+
+	pipelineJSON, _ := json.Marshal(ddPipeline)
+	d.SetId(utils.ConvertToSha256(string(pipelineJSON) + "|" + strings.Join(events, "\n")))
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("warnings", simulator.warnings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
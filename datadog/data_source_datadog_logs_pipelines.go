@@ -103,7 +103,7 @@ func dataSourceDatadogLogsPipelinesRead(ctx context.Context, d *schema.ResourceD
 	tflogsPipelines := make([]map[string]interface{}, 0)
 	for _, pipeline := range logsPipelines {
 		if !ok || (ok && v == *pipeline.IsReadOnly) {
-			if err := utils.CheckForUnparsed(pipeline); err != nil {
+			if err := checkLogsPipelineForUnparsed(pipeline); err != nil {
 				diags = append(diags, diag.Diagnostic{
 					Severity: diag.Warning,
 					Summary:  fmt.Sprintf("skipping logs pipeline with id: %s", pipeline.GetId()),
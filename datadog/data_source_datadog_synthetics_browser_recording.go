@@ -0,0 +1,330 @@
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Chrome DevTools Recorder steps with no equivalent in browser tests, which are dropped
+var syntheticsRecordingIgnoredSteps = map[string]bool{"close": true, "keyUp": true, "setViewport": true}
+
+// Keys recorded by Chrome which are sent as modifiers of the next key press, with their browser test name
+var syntheticsRecordingModifiers = map[string]string{
+	"Alt":     "Alt",
+	"Control": "Control",
+	"Meta":    "meta",
+	"Shift":   "Shift",
+}
+
+type syntheticsRecording struct {
+	Title string                   `json:"title"`
+	Steps []syntheticsRecordedStep `json:"steps"`
+}
+
+type syntheticsRecordedStep struct {
+	Type           string                    `json:"type"`
+	Target         string                    `json:"target"`
+	Frame          []int                     `json:"frame"`
+	Timeout        int64                     `json:"timeout"`
+	Selectors      []json.RawMessage         `json:"selectors"`
+	Button         string                    `json:"button"`
+	URL            string                    `json:"url"`
+	Value          string                    `json:"value"`
+	Key            string                    `json:"key"`
+	Expression     string                    `json:"expression"`
+	X              int                       `json:"x"`
+	Y              int                       `json:"y"`
+	Visible        *bool                     `json:"visible"`
+	Count          *int                      `json:"count"`
+	Attributes     map[string]interface{}    `json:"attributes"`
+	Properties     map[string]interface{}    `json:"properties"`
+	AssertedEvents []syntheticsRecordedEvent `json:"assertedEvents"`
+}
+
+type syntheticsRecordedEvent struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+func dataSourceDatadogSyntheticsBrowserRecording() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to convert a Chrome DevTools Recorder, or Puppeteer Replay, JSON export into the steps of a browser `datadog_synthetics_test`. The result can be used with `dynamic \"browser_step\"` blocks.",
+		ReadContext: dataSourceDatadogSyntheticsBrowserRecordingRead,
+
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"recording": {
+					Description: "The JSON export of the recording, for example `file(\"checkout.json\")`.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				// Computed values
+				"title": {
+					Description: "The title of the recording.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"start_url": {
+					Description: "The URL of the first navigation of the recording, to be used as the `request_definition` URL of the test.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"browser_step": computedSyntheticsSchema(syntheticsTestBrowserStep()),
+			}
+		},
+	}
+}
+
+func dataSourceDatadogSyntheticsBrowserRecordingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	recordingJSON := d.Get("recording").(string)
+	var recording syntheticsRecording
+	if err := json.Unmarshal([]byte(recordingJSON), &recording); err != nil {
+		return diag.Errorf("error parsing recording: %s", err)
+	}
+
+	startURL, steps, err := buildSyntheticsStepsFromRecording(recording)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	localSteps := make([]map[string]interface{}, len(steps))
+	for i, step := range steps {
+		localSteps[i] = map[string]interface{}{
+			"name":    step.GetName(),
+			"type":    string(step.GetType()),
+			"timeout": step.GetTimeout(),
+			"params":  []interface{}{buildLocalBrowserStepParams(step.GetParams())},
+		}
+	}
+	if err := d.Set("browser_step", localSteps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Make sure the generated blocks are sent back to the API as recorded
+	for i, localStep := range d.Get("browser_step").([]interface{}) {
+		params := buildSyntheticsBrowserStepParams(steps[i].GetType(), localStep.(map[string]interface{})["params"].([]interface{})[0])
+		for key, value := range steps[i].GetParams().(map[string]interface{}) {
+			if !syntheticsJSONEqual(value, params[key]) {
+				return diag.Errorf("error converting step %d (%s): %s can't be represented in a browser_step block", i, steps[i].GetName(), key)
+			}
+		}
+	}
+
+	d.SetId(utils.ConvertToSha256(recordingJSON))
+	d.Set("title", recording.Title)
+	d.Set("start_url", startURL)
+
+	return nil
+}
+
+// buildSyntheticsStepsFromRecording maps the steps of a recording onto browser test steps. The first navigation
+// is returned separately as it is the start URL of the test.
+func buildSyntheticsStepsFromRecording(recording syntheticsRecording) (string, []datadogV1.SyntheticsStep, error) {
+	var startURL string
+	var steps []datadogV1.SyntheticsStep
+	var modifiers []string
+
+	for i, recordedStep := range recording.Steps {
+		if syntheticsRecordingIgnoredSteps[recordedStep.Type] {
+			if recordedStep.Type == "keyUp" {
+				modifiers = removeSyntheticsModifier(modifiers, syntheticsRecordingModifiers[recordedStep.Key])
+			}
+			continue
+		}
+		if len(recordedStep.Frame) > 0 || (recordedStep.Target != "" && recordedStep.Target != "main") {
+			return "", nil, fmt.Errorf("step %d (%s): only steps in the main frame of the main page are supported", i, recordedStep.Type)
+		}
+
+		step := datadogV1.NewSyntheticsStep()
+		params := make(map[string]interface{})
+		switch recordedStep.Type {
+		case "navigate":
+			if startURL == "" && len(steps) == 0 {
+				startURL = recordedStep.URL
+				continue
+			}
+			step.SetType(datadogV1.SYNTHETICSSTEPTYPE_GO_TO_URL)
+			step.SetName(fmt.Sprintf("Navigate to %s", recordedStep.URL))
+			params["value"] = recordedStep.URL
+		case "click", "doubleClick":
+			step.SetType(datadogV1.SYNTHETICSSTEPTYPE_CLICK)
+			switch {
+			case recordedStep.Type == "doubleClick":
+				params["clickType"] = "double"
+			case recordedStep.Button == "" || recordedStep.Button == "primary":
+				params["clickType"] = "primary"
+			case recordedStep.Button == "secondary":
+				params["clickType"] = "contextual"
+			default:
+				return "", nil, fmt.Errorf("step %d (%s): %s button is not supported", i, recordedStep.Type, recordedStep.Button)
+			}
+		case "change":
+			step.SetType(datadogV1.SYNTHETICSSTEPTYPE_TYPE_TEXT)
+			params["value"] = recordedStep.Value
+		case "keyDown":
+			if modifier, ok := syntheticsRecordingModifiers[recordedStep.Key]; ok {
+				modifiers = append(removeSyntheticsModifier(modifiers, modifier), modifier)
+				continue
+			}
+			step.SetType(datadogV1.SYNTHETICSSTEPTYPE_PRESS_KEY)
+			step.SetName(fmt.Sprintf("Press %s", strings.Join(append(modifiers, recordedStep.Key), " + ")))
+			params["value"] = recordedStep.Key
+			if len(modifiers) > 0 {
+				params["modifiers"] = append([]string{}, modifiers...)
+			}
+		case "hover":
+			step.SetType(datadogV1.SYNTHETICSSTEPTYPE_HOVER)
+		case "scroll":
+			step.SetType(datadogV1.SYNTHETICSSTEPTYPE_SCROLL)
+			step.SetName("Scroll")
+			params["x"] = recordedStep.X
+			params["y"] = recordedStep.Y
+		case "waitForElement":
+			if (recordedStep.Visible != nil && !*recordedStep.Visible) || recordedStep.Count != nil || len(recordedStep.Attributes) > 0 || len(recordedStep.Properties) > 0 {
+				return "", nil, fmt.Errorf("step %d (%s): only the presence of an element can be asserted", i, recordedStep.Type)
+			}
+			step.SetType(datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_PRESENT)
+		case "waitForExpression":
+			step.SetType(datadogV1.SYNTHETICSSTEPTYPE_ASSERT_FROM_JAVASCRIPT)
+			step.SetName("Assert JavaScript expression")
+			params["code"] = fmt.Sprintf("return %s", recordedStep.Expression)
+		default:
+			return "", nil, fmt.Errorf("step %d: %s steps are not supported", i, recordedStep.Type)
+		}
+
+		if len(recordedStep.Selectors) > 0 {
+			locator, err := buildSyntheticsUserLocatorFromSelectors(recordedStep.Selectors)
+			if err != nil {
+				return "", nil, fmt.Errorf("step %d (%s): %s", i, recordedStep.Type, err)
+			}
+			params["element"] = map[string]interface{}{"userLocator": map[string]interface{}{
+				"failTestOnCannotLocate": false,
+				"values":                 []interface{}{locator},
+			}}
+			if !step.HasName() {
+				step.SetName(fmt.Sprintf("%s %s", syntheticsRecordedStepVerbs[recordedStep.Type], locator["value"]))
+			}
+		} else if recordedStep.Type != "navigate" && recordedStep.Type != "keyDown" && recordedStep.Type != "scroll" && recordedStep.Type != "waitForExpression" {
+			return "", nil, fmt.Errorf("step %d (%s): selectors are required", i, recordedStep.Type)
+		}
+		if recordedStep.Timeout > 0 {
+			// Recorded timeouts are in milliseconds, step timeouts in seconds
+			step.SetTimeout((recordedStep.Timeout + 999) / 1000)
+		}
+		step.SetParams(params)
+		steps = append(steps, *step)
+
+		for _, event := range recordedStep.AssertedEvents {
+			if event.Type != "navigation" || event.URL == "" {
+				continue
+			}
+			assertion := datadogV1.NewSyntheticsStep()
+			assertion.SetType(datadogV1.SYNTHETICSSTEPTYPE_ASSERT_CURRENT_URL)
+			assertion.SetName(fmt.Sprintf("Assert current URL is %s", event.URL))
+			assertion.SetParams(map[string]interface{}{
+				"check": string(datadogV1.SYNTHETICSCHECKTYPE_EQUALS),
+				"value": event.URL,
+			})
+			steps = append(steps, *assertion)
+		}
+	}
+
+	if startURL == "" {
+		return "", nil, fmt.Errorf("the recording must start with a navigate step")
+	}
+	return startURL, steps, nil
+}
+
+// Step names prefixes of the recorded steps targeting an element
+var syntheticsRecordedStepVerbs = map[string]string{
+	"change":         "Type text on",
+	"click":          "Click on",
+	"doubleClick":    "Double click on",
+	"hover":          "Hover over",
+	"waitForElement": "Assert element is present:",
+}
+
+// buildSyntheticsUserLocatorFromSelectors picks the selector of the recorded element usable by browser tests,
+// CSS selectors are preferred over XPath ones. ARIA and text selectors, as well as selectors going through
+// shadow roots or iframes, have no equivalent.
+func buildSyntheticsUserLocatorFromSelectors(rawSelectors []json.RawMessage) (map[string]interface{}, error) {
+	var xpathLocator map[string]interface{}
+	for _, rawSelector := range rawSelectors {
+		// Selectors are either a string, or a list of selectors to go through shadow roots
+		var selector string
+		if err := json.Unmarshal(rawSelector, &selector); err != nil {
+			var chain []string
+			if err := json.Unmarshal(rawSelector, &chain); err != nil {
+				return nil, fmt.Errorf("invalid selector %s", rawSelector)
+			}
+			if len(chain) != 1 {
+				continue
+			}
+			selector = chain[0]
+		}
+		switch {
+		case strings.HasPrefix(selector, "aria/"), strings.HasPrefix(selector, "text/"), strings.HasPrefix(selector, "pierce/"):
+			continue
+		case strings.HasPrefix(selector, "xpath/"):
+			if xpathLocator == nil {
+				xpathLocator = map[string]interface{}{"type": "xpath", "value": strings.TrimPrefix(selector, "xpath/")}
+			}
+		default:
+			return map[string]interface{}{"type": "css", "value": selector}, nil
+		}
+	}
+	if xpathLocator == nil {
+		return nil, fmt.Errorf("no CSS or XPath selector recorded")
+	}
+	return xpathLocator, nil
+}
+
+func removeSyntheticsModifier(modifiers []string, modifier string) []string {
+	result := make([]string, 0, len(modifiers))
+	for _, m := range modifiers {
+		if m != modifier {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// syntheticsJSONEqual compares two values once encoded in JSON, which ignores the differences of Go types
+func syntheticsJSONEqual(a, b interface{}) bool {
+	var decodedA, decodedB interface{}
+	encodedA, _ := json.Marshal(a)
+	encodedB, _ := json.Marshal(b)
+	json.Unmarshal(encodedA, &decodedA)
+	json.Unmarshal(encodedB, &decodedB)
+	return reflect.DeepEqual(decodedA, decodedB)
+}
+
+// computedSyntheticsSchema returns a copy of a resource attribute schema where every attribute is computed,
+// to expose resource blocks in a data source.
+func computedSyntheticsSchema(s *schema.Schema) *schema.Schema {
+	computed := &schema.Schema{
+		Description: s.Description,
+		Type:        s.Type,
+		Computed:    true,
+	}
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		nested := make(map[string]*schema.Schema, len(elem.Schema))
+		for k, v := range elem.Schema {
+			nested[k] = computedSyntheticsSchema(v)
+		}
+		computed.Elem = &schema.Resource{Schema: nested}
+	case *schema.Schema:
+		computed.Elem = &schema.Schema{Type: elem.Type}
+	}
+	return computed
+}
@@ -0,0 +1,647 @@
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// HTTP methods of OpenAPI path items, in the order operations are listed
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Maximum depth of the generated examples, which stops on recursive schemas
+const openAPIExampleMaxDepth = 8
+
+// Patterns asserting the type of scalar JSON values
+var openAPITypePatterns = map[string]string{
+	"boolean": `^(true|false)$`,
+	"integer": `^-?\d+$`,
+	"number":  `^-?\d+(\.\d+)?([eE][+-]?\d+)?$`,
+	"string":  `.*`,
+}
+
+var openAPIIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func dataSourceDatadogSyntheticsOpenAPIRequests() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to generate the requests and assertions of Synthetics API tests from an OpenAPI 3 document. Every operation of the document gets a request, with an example body, along with assertions on the status code, the content type and the required properties of its successful response.",
+		ReadContext: dataSourceDatadogSyntheticsOpenAPIRequestsRead,
+
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"spec": {
+					Description: "The OpenAPI 3 document, in JSON or YAML, for example `file(\"openapi.yaml\")`.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"server_url": {
+					Description: "Base URL of the requests. Defaults to the first server of the document.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"tags": {
+					Description: "Only generate requests for the operations having one of these tags.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				// Computed values
+				"request": {
+					Description: "Requests generated for the operations of the document, sorted by path and method.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"operation_id": {
+								Description: "ID of the operation, or its method and path when the operation has no ID.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"summary": {
+								Description: "Summary of the operation.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"method": {
+								Description: "The HTTP method.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"url": {
+								Description: "The URL of the request, with example values for the path and required query parameters.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"body": {
+								Description: "Example request body.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"body_type": {
+								Description: "Type of the request body, empty when the operation has no body.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"request_headers": {
+								Description: "Header name and value map, holding the content type and the required header parameters.",
+								Type:        schema.TypeMap,
+								Computed:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+							"assertion": {
+								Description: "Assertions on the successful response of the operation.",
+								Type:        schema.TypeList,
+								Computed:    true,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"type": {
+											Description: "Type of assertion.",
+											Type:        schema.TypeString,
+											Computed:    true,
+										},
+										"operator": {
+											Description: "Assertion operator.",
+											Type:        schema.TypeString,
+											Computed:    true,
+										},
+										"property": {
+											Description: "If assertion type is `header`, this is the header name.",
+											Type:        schema.TypeString,
+											Computed:    true,
+										},
+										"target": {
+											Description: "Expected value.",
+											Type:        schema.TypeString,
+											Computed:    true,
+										},
+										"targetjsonpath": {
+											Description: "Expected structure if `operator` is `validatesJSONPath`.",
+											Type:        schema.TypeList,
+											Computed:    true,
+											Elem: &schema.Resource{
+												Schema: map[string]*schema.Schema{
+													"operator": {
+														Description: "The specific operator to use on the path.",
+														Type:        schema.TypeString,
+														Computed:    true,
+													},
+													"jsonpath": {
+														Description: "The JSON path to assert.",
+														Type:        schema.TypeString,
+														Computed:    true,
+													},
+													"targetvalue": {
+														Description: "Expected matching value.",
+														Type:        schema.TypeString,
+														Computed:    true,
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+func dataSourceDatadogSyntheticsOpenAPIRequestsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	spec := d.Get("spec").(string)
+	var document interface{}
+	if err := yaml.Unmarshal([]byte(spec), &document); err != nil {
+		return diag.Errorf("error parsing OpenAPI document: %s", err)
+	}
+	doc, ok := normalizeOpenAPIValue(document).(map[string]interface{})
+	if !ok {
+		return diag.Errorf("error parsing OpenAPI document: not an object")
+	}
+	if version := fmt.Sprint(doc["openapi"]); !strings.HasPrefix(version, "3.") {
+		return diag.Errorf("only OpenAPI 3 documents are supported")
+	}
+
+	serverURL := d.Get("server_url").(string)
+	if serverURL == "" {
+		serverURL = openAPIServerURL(doc)
+	}
+	if parsed, err := url.Parse(serverURL); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return diag.Errorf("the document has no absolute server URL, server_url must be set")
+	}
+	tags := utils.GetStringSlice(d, "tags")
+
+	requests, err := buildSyntheticsOpenAPIRequests(doc, strings.TrimRight(serverURL, "/"), tags)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("request", requests); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(utils.ConvertToSha256(fmt.Sprintf("%s|%s|%s", spec, serverURL, strings.Join(tags, ","))))
+
+	return nil
+}
+
+func buildSyntheticsOpenAPIRequests(doc map[string]interface{}, serverURL string, tags []string) ([]map[string]interface{}, error) {
+	paths, _ := doc["paths"].(map[string]interface{})
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	requests := make([]map[string]interface{}, 0)
+	for _, path := range pathNames {
+		pathItem, _ := resolveOpenAPIRef(doc, paths[path]).(map[string]interface{})
+		for _, method := range openAPIMethods {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok || !openAPIOperationHasTag(operation, tags) {
+				continue
+			}
+			request, err := buildSyntheticsOpenAPIRequest(doc, serverURL, path, method, pathItem, operation)
+			if err != nil {
+				return nil, fmt.Errorf("error generating request for %s %s: %s", strings.ToUpper(method), path, err)
+			}
+			requests = append(requests, request)
+		}
+	}
+	return requests, nil
+}
+
+func buildSyntheticsOpenAPIRequest(doc map[string]interface{}, serverURL, path, method string, pathItem, operation map[string]interface{}) (map[string]interface{}, error) {
+	operationID, _ := operation["operationId"].(string)
+	if operationID == "" {
+		operationID = fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+	}
+	summary, _ := operation["summary"].(string)
+	headers := make(map[string]interface{})
+
+	// Operation parameters override the ones of the path
+	parameters := make(map[string]map[string]interface{})
+	var parameterKeys []string
+	for _, source := range []interface{}{pathItem["parameters"], operation["parameters"]} {
+		list, _ := source.([]interface{})
+		for _, p := range list {
+			parameter, ok := resolveOpenAPIRef(doc, p).(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%v|%v", parameter["in"], parameter["name"])
+			if _, ok := parameters[key]; !ok {
+				parameterKeys = append(parameterKeys, key)
+			}
+			parameters[key] = parameter
+		}
+	}
+	query := url.Values{}
+	for _, key := range parameterKeys {
+		parameter := parameters[key]
+		name, _ := parameter["name"].(string)
+		required, _ := parameter["required"].(bool)
+		value := openAPIParameterExample(doc, parameter)
+		switch parameter["in"] {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+		case "query":
+			if required {
+				query.Add(name, value)
+			}
+		case "header":
+			if required {
+				headers[name] = value
+			}
+		}
+	}
+	requestURL := serverURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var body, bodyType string
+	if requestBody, ok := resolveOpenAPIRef(doc, operation["requestBody"]).(map[string]interface{}); ok {
+		content, _ := requestBody["content"].(map[string]interface{})
+		if mediaType, media := selectOpenAPIMediaType(content); mediaType != "" {
+			example, err := openAPIMediaExample(doc, media)
+			if err != nil {
+				return nil, err
+			}
+			if s, ok := example.(string); ok && !strings.Contains(mediaType, "json") {
+				body = s
+			} else if example != nil {
+				encoded, err := json.Marshal(example)
+				if err != nil {
+					return nil, err
+				}
+				body = string(encoded)
+			}
+			headers["Content-Type"] = mediaType
+			if _, err := datadogV1.NewSyntheticsTestRequestBodyTypeFromValue(mediaType); err == nil {
+				bodyType = mediaType
+			}
+		}
+	}
+
+	assertions := []map[string]interface{}{}
+	responses, _ := operation["responses"].(map[string]interface{})
+	statusCode, response := selectOpenAPISuccessResponse(responses)
+	assertions = append(assertions, map[string]interface{}{
+		"type":     string(datadogV1.SYNTHETICSASSERTIONTYPE_STATUS_CODE),
+		"operator": string(datadogV1.SYNTHETICSASSERTIONOPERATOR_IS),
+		"target":   statusCode,
+	})
+	if responseMap, ok := resolveOpenAPIRef(doc, response).(map[string]interface{}); ok {
+		content, _ := responseMap["content"].(map[string]interface{})
+		if mediaType, media := selectOpenAPIMediaType(content); mediaType != "" {
+			headers["Accept"] = mediaType
+			assertions = append(assertions, map[string]interface{}{
+				"type":     string(datadogV1.SYNTHETICSASSERTIONTYPE_HEADER),
+				"operator": string(datadogV1.SYNTHETICSASSERTIONOPERATOR_CONTAINS),
+				"property": "content-type",
+				"target":   mediaType,
+			})
+			if strings.Contains(mediaType, "json") {
+				assertions = append(assertions, buildOpenAPISchemaAssertions(doc, media["schema"])...)
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"operation_id":    operationID,
+		"summary":         summary,
+		"method":          strings.ToUpper(method),
+		"url":             requestURL,
+		"body":            body,
+		"body_type":       bodyType,
+		"request_headers": headers,
+		"assertion":       assertions,
+	}, nil
+}
+
+// buildOpenAPISchemaAssertions asserts the presence and the type of the required scalar properties of a response.
+func buildOpenAPISchemaAssertions(doc map[string]interface{}, schemaValue interface{}) []map[string]interface{} {
+	schemaMap := mergeOpenAPIAllOf(doc, schemaValue)
+	required, _ := schemaMap["required"].([]interface{})
+	properties, _ := schemaMap["properties"].(map[string]interface{})
+
+	names := make([]string, 0, len(required))
+	for _, name := range required {
+		if s, ok := name.(string); ok {
+			names = append(names, s)
+		}
+	}
+	sort.Strings(names)
+
+	assertions := []map[string]interface{}{}
+	for _, name := range names {
+		property, ok := resolveOpenAPIRef(doc, properties[name]).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if nullable, _ := property["nullable"].(bool); nullable {
+			continue
+		}
+		propertyType, _ := property["type"].(string)
+		pattern, ok := openAPITypePatterns[propertyType]
+		if !ok {
+			continue
+		}
+		if enum, ok := property["enum"].([]interface{}); ok && len(enum) > 0 {
+			values := make([]string, len(enum))
+			for i, v := range enum {
+				values[i] = regexp.QuoteMeta(fmt.Sprint(v))
+			}
+			pattern = fmt.Sprintf("^(%s)$", strings.Join(values, "|"))
+		}
+		jsonPath := "$." + name
+		if !openAPIIdentifierRegexp.MatchString(name) {
+			jsonPath = fmt.Sprintf("$['%s']", strings.ReplaceAll(name, "'", "\\'"))
+		}
+		assertions = append(assertions, map[string]interface{}{
+			"type":     string(datadogV1.SYNTHETICSASSERTIONTYPE_BODY),
+			"operator": string(datadogV1.SYNTHETICSASSERTIONJSONPATHOPERATOR_VALIDATES_JSON_PATH),
+			"targetjsonpath": []map[string]interface{}{{
+				"operator":    string(datadogV1.SYNTHETICSASSERTIONOPERATOR_MATCHES),
+				"jsonpath":    jsonPath,
+				"targetvalue": pattern,
+			}},
+		})
+	}
+	return assertions
+}
+
+// openAPIServerURL returns the URL of the first server of the document, with the default value of its variables.
+func openAPIServerURL(doc map[string]interface{}) string {
+	servers, _ := doc["servers"].([]interface{})
+	if len(servers) == 0 {
+		return ""
+	}
+	server, _ := servers[0].(map[string]interface{})
+	serverURL, _ := server["url"].(string)
+	variables, _ := server["variables"].(map[string]interface{})
+	for name, v := range variables {
+		if variable, ok := v.(map[string]interface{}); ok {
+			serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", fmt.Sprint(variable["default"]))
+		}
+	}
+	return serverURL
+}
+
+func openAPIOperationHasTag(operation map[string]interface{}, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	operationTags, _ := operation["tags"].([]interface{})
+	for _, operationTag := range operationTags {
+		for _, tag := range tags {
+			if operationTag == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectOpenAPIMediaType prefers JSON content, then the first media type in alphabetical order.
+func selectOpenAPIMediaType(content map[string]interface{}) (string, map[string]interface{}) {
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	if len(mediaTypes) == 0 {
+		return "", nil
+	}
+	sort.Strings(mediaTypes)
+	selected := mediaTypes[0]
+	for _, mediaType := range mediaTypes {
+		if mediaType == "application/json" {
+			selected = mediaType
+			break
+		}
+	}
+	media, _ := content[selected].(map[string]interface{})
+	return selected, media
+}
+
+// selectOpenAPISuccessResponse returns the lowest 2xx status code of the responses, 200 when none is explicit.
+func selectOpenAPISuccessResponse(responses map[string]interface{}) (string, interface{}) {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if code != "2XX" {
+			return code, responses[code]
+		}
+	}
+	if response, ok := responses["2XX"]; ok {
+		return "200", response
+	}
+	return "200", nil
+}
+
+func openAPIParameterExample(doc map[string]interface{}, parameter map[string]interface{}) string {
+	example, _ := openAPIMediaExample(doc, parameter)
+	switch v := example.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, element := range v {
+			values[i] = fmt.Sprint(element)
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// openAPIMediaExample returns the example of a media type or parameter object, generating one from the
+// schema when none is given.
+func openAPIMediaExample(doc map[string]interface{}, media map[string]interface{}) (interface{}, error) {
+	if example, ok := media["example"]; ok {
+		return example, nil
+	}
+	if examples, ok := media["examples"].(map[string]interface{}); ok && len(examples) > 0 {
+		names := make([]string, 0, len(examples))
+		for name := range examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if example, ok := resolveOpenAPIRef(doc, examples[names[0]]).(map[string]interface{}); ok {
+			if value, ok := example["value"]; ok {
+				return value, nil
+			}
+		}
+	}
+	if schemaValue, ok := media["schema"]; ok {
+		return generateOpenAPIExample(doc, schemaValue, 0), nil
+	}
+	return nil, nil
+}
+
+func generateOpenAPIExample(doc map[string]interface{}, schemaValue interface{}, depth int) interface{} {
+	if depth > openAPIExampleMaxDepth {
+		return nil
+	}
+	schemaMap := mergeOpenAPIAllOf(doc, schemaValue)
+	if example, ok := schemaMap["example"]; ok {
+		return example
+	}
+	if def, ok := schemaMap["default"]; ok {
+		return def
+	}
+	if enum, ok := schemaMap["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	for _, k := range []string{"oneOf", "anyOf"} {
+		if alternatives, ok := schemaMap[k].([]interface{}); ok && len(alternatives) > 0 {
+			return generateOpenAPIExample(doc, alternatives[0], depth+1)
+		}
+	}
+
+	schemaType, _ := schemaMap["type"].(string)
+	if schemaType == "" {
+		if _, ok := schemaMap["properties"]; ok {
+			schemaType = "object"
+		}
+	}
+	switch schemaType {
+	case "object":
+		properties, _ := schemaMap["properties"].(map[string]interface{})
+		example := make(map[string]interface{}, len(properties))
+		for name, property := range properties {
+			propertyMap := mergeOpenAPIAllOf(doc, property)
+			if readOnly, _ := propertyMap["readOnly"].(bool); readOnly {
+				continue
+			}
+			example[name] = generateOpenAPIExample(doc, property, depth+1)
+		}
+		return example
+	case "array":
+		return []interface{}{generateOpenAPIExample(doc, schemaMap["items"], depth+1)}
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return true
+	case "string":
+		switch schemaMap["format"] {
+		case "date":
+			return "2024-01-01"
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "email":
+			return "user@example.com"
+		case "uri", "url":
+			return "https://www.example.com"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		}
+		return "string"
+	}
+	return nil
+}
+
+// mergeOpenAPIAllOf resolves a schema, merging the properties and required fields of its allOf schemas.
+func mergeOpenAPIAllOf(doc map[string]interface{}, schemaValue interface{}) map[string]interface{} {
+	return mergeOpenAPIAllOfWithDepth(doc, schemaValue, 0)
+}
+
+func mergeOpenAPIAllOfWithDepth(doc map[string]interface{}, schemaValue interface{}, depth int) map[string]interface{} {
+	schemaMap, _ := resolveOpenAPIRef(doc, schemaValue).(map[string]interface{})
+	allOf, ok := schemaMap["allOf"].([]interface{})
+	if !ok || depth > openAPIExampleMaxDepth {
+		return schemaMap
+	}
+	merged := make(map[string]interface{}, len(schemaMap))
+	properties := make(map[string]interface{})
+	var required []interface{}
+	parts := make([]map[string]interface{}, 0, len(allOf)+1)
+	for _, s := range allOf {
+		parts = append(parts, mergeOpenAPIAllOfWithDepth(doc, s, depth+1))
+	}
+	parts = append(parts, schemaMap)
+	for _, part := range parts {
+		for k, v := range part {
+			switch k {
+			case "allOf":
+			case "properties":
+				if p, ok := v.(map[string]interface{}); ok {
+					for name, property := range p {
+						properties[name] = property
+					}
+				}
+			case "required":
+				if r, ok := v.([]interface{}); ok {
+					required = append(required, r...)
+				}
+			default:
+				merged[k] = v
+			}
+		}
+	}
+	merged["properties"] = properties
+	merged["required"] = required
+	return merged
+}
+
+// resolveOpenAPIRef follows the local `$ref` of an object, references to other documents aren't supported.
+func resolveOpenAPIRef(doc map[string]interface{}, value interface{}) interface{} {
+	for i := 0; i < openAPIExampleMaxDepth; i++ {
+		valueMap, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		ref, ok := valueMap["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return value
+		}
+		var current interface{} = doc
+		for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			currentMap, _ := current.(map[string]interface{})
+			current = currentMap[token]
+		}
+		value = current
+	}
+	return nil
+}
+
+// normalizeOpenAPIValue converts the maps decoded from YAML, which may have non-string keys such as status
+// codes, into JSON-like maps.
+func normalizeOpenAPIValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, element := range v {
+			v[k] = normalizeOpenAPIValue(element)
+		}
+		return v
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, element := range v {
+			result[fmt.Sprint(k)] = normalizeOpenAPIValue(element)
+		}
+		return result
+	case []interface{}:
+		for i, element := range v {
+			v[i] = normalizeOpenAPIValue(element)
+		}
+		return v
+	}
+	return value
+}
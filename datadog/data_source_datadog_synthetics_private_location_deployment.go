@@ -0,0 +1,311 @@
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Path where the worker reads its configuration, also used as key of the Kubernetes secret and the Helm chart
+const syntheticsPrivateLocationConfigPath = "/etc/datadog/synthetics-check-runner.json"
+
+// Keys of the private location configuration which are required by the worker
+var syntheticsPrivateLocationRequiredKeys = []string{"id", "accessKey", "secretAccessKey", "privateKey"}
+
+// Environment variables the worker reads the secret keys of the configuration from, instead of command line flags
+var syntheticsPrivateLocationSecretEnvs = map[string]string{
+	"secretAccessKey": "DATADOG_SECRET_ACCESS_KEY",
+	"privateKey":      "DATADOG_PRIVATE_KEY",
+}
+
+type privateLocationKubernetesSecret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   map[string]string `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	Data       map[string]string `yaml:"data"`
+}
+
+type privateLocationHelmValues struct {
+	Image struct {
+		Repository string `yaml:"repository"`
+		Tag        string `yaml:"tag"`
+	} `yaml:"image"`
+	ConfigFile string `yaml:"configFile"`
+}
+
+type privateLocationComposeConfig struct {
+	Source string `yaml:"source"`
+	Target string `yaml:"target"`
+}
+
+type privateLocationComposeService struct {
+	Image   string                         `yaml:"image"`
+	Restart string                         `yaml:"restart"`
+	Configs []privateLocationComposeConfig `yaml:"configs"`
+}
+
+type privateLocationCompose struct {
+	Services map[string]privateLocationComposeService `yaml:"services"`
+	Configs  map[string]map[string]string             `yaml:"configs"`
+}
+
+type privateLocationECSSecret struct {
+	Name      string `json:"name"`
+	ValueFrom string `json:"valueFrom"`
+}
+
+type privateLocationECSContainer struct {
+	Name      string                     `json:"name"`
+	Image     string                     `json:"image"`
+	Essential bool                       `json:"essential"`
+	Command   []string                   `json:"command"`
+	Secrets   []privateLocationECSSecret `json:"secrets"`
+}
+
+func dataSourceDatadogSyntheticsPrivateLocationDeployment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to render the deployment artifacts of a Synthetics private location worker from the `config` of a `datadog_synthetics_private_location`: a Kubernetes secret, values of the Helm chart, a docker-compose file and an ECS container definition. All outputs but the ECS container definition contain the private key of the location, they are marked as sensitive.",
+		ReadContext: dataSourceDatadogSyntheticsPrivateLocationDeploymentRead,
+
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"config": {
+					Description: "Configuration of the private location, usually the `config` attribute of a `datadog_synthetics_private_location` resource.",
+					Type:        schema.TypeString,
+					Required:    true,
+					Sensitive:   true,
+				},
+				"name": {
+					Description: "Name of the Kubernetes secret, docker-compose service and ECS container.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "synthetics-private-location-worker",
+				},
+				"namespace": {
+					Description: "Kubernetes namespace of the secret.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"image": {
+					Description: "Image of the worker.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "datadog/synthetics-private-location-worker:latest",
+				},
+				"ecs_secrets": {
+					Description: "ARNs of the AWS Secrets Manager secrets or SSM parameters holding the secret keys of the configuration, which the ECS container reads as `secrets`. The ECS container definition is only rendered when this block is set.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"secret_access_key_arn": {
+								Description: "ARN of the secret or parameter holding the `secretAccessKey` of the configuration.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"private_key_arn": {
+								Description: "ARN of the secret or parameter holding the `privateKey` of the configuration.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+						},
+					},
+				},
+				// Computed values
+				"kubernetes_secret": {
+					Description: "Manifest of a Kubernetes secret holding the configuration, which can be passed as `configSecret` to the Helm chart.",
+					Type:        schema.TypeString,
+					Computed:    true,
+					Sensitive:   true,
+				},
+				"helm_values": {
+					Description: "Values of the `datadog/synthetics-private-location` Helm chart.",
+					Type:        schema.TypeString,
+					Computed:    true,
+					Sensitive:   true,
+				},
+				"docker_compose": {
+					Description: "docker-compose file running the worker.",
+					Type:        schema.TypeString,
+					Computed:    true,
+					Sensitive:   true,
+				},
+				"ecs_container_definition": {
+					Description: "JSON container definition of an ECS task running the worker, which reads the secret keys of the configuration from the `ecs_secrets`. Empty when `ecs_secrets` is not set.",
+					Type:        schema.TypeString,
+					Computed:    true,
+					Sensitive:   true,
+				},
+			}
+		},
+	}
+}
+
+func dataSourceDatadogSyntheticsPrivateLocationDeploymentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	image := d.Get("image").(string)
+
+	config, err := parseSyntheticsPrivateLocationConfig(d.Get("config").(string))
+	if err != nil {
+		return diag.Errorf("error parsing private location config: %s", err)
+	}
+	configJSON, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	secret := privateLocationKubernetesSecret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   map[string]string{"name": name},
+		Type:       "Opaque",
+		Data: map[string]string{
+			"synthetics-check-runner.json": base64.StdEncoding.EncodeToString(configJSON),
+		},
+	}
+	if namespace, ok := d.GetOk("namespace"); ok {
+		secret.Metadata["namespace"] = namespace.(string)
+	}
+
+	var helmValues privateLocationHelmValues
+	helmValues.Image.Repository, helmValues.Image.Tag = splitContainerImage(image)
+	helmValues.ConfigFile = string(configJSON)
+
+	compose := privateLocationCompose{
+		Services: map[string]privateLocationComposeService{name: {
+			Image:   image,
+			Restart: "unless-stopped",
+			Configs: []privateLocationComposeConfig{{Source: name + "-config", Target: syntheticsPrivateLocationConfigPath}},
+		}},
+		Configs: map[string]map[string]string{name + "-config": {"content": string(configJSON)}},
+	}
+
+	for attribute, value := range map[string]interface{}{
+		"kubernetes_secret": secret,
+		"helm_values":       helmValues,
+		"docker_compose":    compose,
+	} {
+		rendered, err := marshalDeploymentYAML(value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set(attribute, rendered)
+	}
+
+	// Secret keys are never passed as command line flags, which are visible in the task definition
+	containerDefinition := ""
+	if ecsSecrets, ok := d.Get("ecs_secrets").([]interface{}); ok && len(ecsSecrets) > 0 && ecsSecrets[0] != nil {
+		arns := ecsSecrets[0].(map[string]interface{})
+		container := privateLocationECSContainer{
+			Name:      name,
+			Image:     image,
+			Essential: true,
+			Command:   buildSyntheticsPrivateLocationFlags(config),
+			Secrets: []privateLocationECSSecret{
+				{Name: syntheticsPrivateLocationSecretEnvs["privateKey"], ValueFrom: arns["private_key_arn"].(string)},
+				{Name: syntheticsPrivateLocationSecretEnvs["secretAccessKey"], ValueFrom: arns["secret_access_key_arn"].(string)},
+			},
+		}
+		containerJSON, err := json.MarshalIndent(container, "", "  ")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		containerDefinition = string(containerJSON)
+	}
+	d.Set("ecs_container_definition", containerDefinition)
+
+	d.SetId(utils.ConvertToSha256(name + string(configJSON)))
+	return nil
+}
+
+// parseSyntheticsPrivateLocationConfig decodes the configuration, checking that the worker can use it
+func parseSyntheticsPrivateLocationConfig(rawConfig string) (map[string]interface{}, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(rawConfig), &config); err != nil {
+		return nil, err
+	}
+	for _, key := range syntheticsPrivateLocationRequiredKeys {
+		if value, ok := config[key].(string); !ok || value == "" {
+			return nil, fmt.Errorf("%s is missing", key)
+		}
+	}
+
+	// Keys copied through environments or shell scripts often end up with escaped line breaks
+	privateKey := config["privateKey"].(string)
+	if !strings.Contains(privateKey, "\n") {
+		privateKey = strings.ReplaceAll(privateKey, `\n`, "\n")
+	}
+	if block, _ := pem.Decode([]byte(privateKey)); block == nil {
+		return nil, fmt.Errorf("privateKey is not a PEM encoded key")
+	}
+	config["privateKey"] = privateKey
+	return config, nil
+}
+
+// buildSyntheticsPrivateLocationFlags converts the configuration to the command line flags of the worker, except
+// the secret keys which are read from environment variables
+func buildSyntheticsPrivateLocationFlags(config map[string]interface{}) []string {
+	var flags []string
+	var addFlags func(prefix string, values map[string]interface{})
+	addFlags = func(prefix string, values map[string]interface{}) {
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			flag := prefix + key
+			if _, ok := syntheticsPrivateLocationSecretEnvs[flag]; ok {
+				continue
+			}
+			if flag == "id" {
+				flag = "locationID"
+			}
+			switch value := values[key].(type) {
+			case map[string]interface{}:
+				addFlags(flag+".", value)
+			case string:
+				flags = append(flags, fmt.Sprintf("--%s=%s", flag, value))
+			default:
+				encoded, _ := json.Marshal(value)
+				flags = append(flags, fmt.Sprintf("--%s=%s", flag, encoded))
+			}
+		}
+	}
+	addFlags("", config)
+	return flags
+}
+
+// splitContainerImage splits an image into its repository and tag
+func splitContainerImage(image string) (string, string) {
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, "latest"
+}
+
+func marshalDeploymentYAML(value interface{}) (string, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,222 @@
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDatadogSyntheticsTests() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list several existing synthetics tests for use in other resources, for example to attach downtimes or dashboards to all the tests of a team.",
+		ReadContext: dataSourceDatadogSyntheticsTestsRead,
+
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"name_filter": {
+					Description: "Only list the tests whose name contains this value, ignoring case.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"type_filter": {
+					Description:      "Only list the tests of this type.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validators.ValidateEnumValue(datadogV1.NewSyntheticsTestDetailsTypeFromValue),
+				},
+				"subtype_filter": {
+					Description:      "Only list the API tests of this subtype.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validators.ValidateEnumValue(datadogV1.NewSyntheticsTestDetailsSubTypeFromValue),
+				},
+				"status_filter": {
+					Description:      "Only list the tests with this status.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validators.ValidateEnumValue(datadogV1.NewSyntheticsTestPauseStatusFromValue),
+				},
+				"tags_filter": {
+					Description: "Only list the tests having all these tags.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"locations_filter": {
+					Description: "Only list the tests running from at least one of these locations.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"creator_filter": {
+					Description: "Only list the tests created by the user with this email or handle.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+
+				// Computed values
+				"tests": {
+					Description: "List of synthetics tests.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"public_id": {
+								Description: "Public ID of the test.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"name": {
+								Description: "Name of the test.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"type": {
+								Description: "Type of the test.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"subtype": {
+								Description: "Subtype of the API test.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"status": {
+								Description: "Status of the test.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"monitor_id": {
+								Description: "ID of the monitor associated with the test.",
+								Type:        schema.TypeInt,
+								Computed:    true,
+							},
+							"locations": {
+								Description: "Locations the test runs from.",
+								Type:        schema.TypeList,
+								Computed:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+							"tags": {
+								Description: "Tags of the test.",
+								Type:        schema.TypeList,
+								Computed:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+func dataSourceDatadogSyntheticsTestsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	diags := diag.Diagnostics{}
+	tfTests := make([]map[string]interface{}, 0)
+	items, cancel := apiInstances.GetSyntheticsApiV1().ListTestsWithPagination(auth)
+	defer cancel()
+	for item := range items {
+		if item.Error != nil {
+			return utils.TranslateClientErrorDiag(item.Error, nil, "error listing synthetics tests")
+		}
+		test := item.Item
+		if err := utils.CheckForUnparsed(test); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("skipping synthetics test with id: %s", test.GetPublicId()),
+				Detail:   fmt.Sprintf("synthetics test contains unparsed object: %v", err),
+			})
+			continue
+		}
+		if !syntheticsTestMatchesFilters(d, &test) {
+			continue
+		}
+
+		tfTests = append(tfTests, map[string]interface{}{
+			"public_id":  test.GetPublicId(),
+			"name":       test.GetName(),
+			"type":       test.GetType(),
+			"subtype":    test.GetSubtype(),
+			"status":     test.GetStatus(),
+			"monitor_id": test.GetMonitorId(),
+			"locations":  test.GetLocations(),
+			"tags":       test.GetTags(),
+		})
+	}
+
+	d.SetId(computeSyntheticsTestsDatasourceID(d))
+	if err := d.Set("tests", tfTests); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func syntheticsTestMatchesFilters(d *schema.ResourceData, test *datadogV1.SyntheticsTestDetails) bool {
+	if v, ok := d.GetOk("name_filter"); ok && !strings.Contains(strings.ToLower(test.GetName()), strings.ToLower(v.(string))) {
+		return false
+	}
+	if v, ok := d.GetOk("type_filter"); ok && string(test.GetType()) != v.(string) {
+		return false
+	}
+	if v, ok := d.GetOk("subtype_filter"); ok && string(test.GetSubtype()) != v.(string) {
+		return false
+	}
+	if v, ok := d.GetOk("status_filter"); ok && string(test.GetStatus()) != v.(string) {
+		return false
+	}
+	if v, ok := d.GetOk("creator_filter"); ok {
+		creator := test.GetCreator()
+		if v.(string) != creator.GetEmail() && v.(string) != creator.GetHandle() {
+			return false
+		}
+	}
+
+	tags := make(map[string]bool)
+	for _, tag := range test.GetTags() {
+		tags[tag] = true
+	}
+	for _, tag := range utils.GetStringSlice(d, "tags_filter") {
+		if !tags[tag] {
+			return false
+		}
+	}
+
+	if locationsFilter := utils.GetStringSlice(d, "locations_filter"); len(locationsFilter) > 0 {
+		locations := make(map[string]bool)
+		for _, location := range test.GetLocations() {
+			locations[location] = true
+		}
+		for _, location := range locationsFilter {
+			if locations[location] {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func computeSyntheticsTestsDatasourceID(d *schema.ResourceData) string {
+	var dsID strings.Builder
+	for _, filter := range []string{"name_filter", "type_filter", "subtype_filter", "status_filter", "creator_filter"} {
+		dsID.WriteString(d.Get(filter).(string))
+		dsID.WriteRune('|')
+	}
+	dsID.WriteString(strings.Join(utils.GetStringSlice(d, "tags_filter"), ","))
+	dsID.WriteRune('|')
+	dsID.WriteString(strings.Join(utils.GetStringSlice(d, "locations_filter"), ","))
+	return utils.ConvertToSha256(dsID.String())
+}
@@ -0,0 +1,465 @@
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+// ExportOptions selects the Datadog objects of a given resource type to generate configuration for.
+// When IDs is set, the filters are ignored and only the given objects are exported.
+type ExportOptions struct {
+	ResourceType string
+	IDs          []string
+	NameFilter   string
+	TagsFilter   []string
+}
+
+type exportedObject struct {
+	id   string
+	name string
+}
+
+type exportLister func(ctx context.Context, providerConf *ProviderConfiguration, opts ExportOptions) ([]exportedObject, error)
+
+type exportedResourceType struct {
+	// nameAttribute is the attribute used to build the Terraform resource name
+	nameAttribute string
+	list          exportLister
+}
+
+var exportedResourceTypes = map[string]exportedResourceType{
+	"datadog_dashboard":                {nameAttribute: "title", list: listDashboardsForExport},
+	"datadog_logs_custom_pipeline":     {nameAttribute: "name", list: listLogsCustomPipelinesForExport},
+	"datadog_monitor":                  {nameAttribute: "name", list: listMonitorsForExport},
+	"datadog_security_monitoring_rule": {nameAttribute: "name", list: listSecurityMonitoringRulesForExport},
+	"datadog_service_level_objective":  {nameAttribute: "name", list: listServiceLevelObjectivesForExport},
+	"datadog_synthetics_test":          {nameAttribute: "name", list: listSyntheticsTestsForExport},
+}
+
+// ExportResourceTypes returns the resource types supported by Export.
+func ExportResourceTypes() []string {
+	types := make([]string, 0, len(exportedResourceTypes))
+	for t := range exportedResourceTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ConfigureExportProvider configures the provider from the environment (DD_API_KEY, DD_APP_KEY, DD_HOST, ...)
+// and returns the meta to pass to Export.
+func ConfigureExportProvider(ctx context.Context) (interface{}, error) {
+	p := Provider()
+	diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	if diags.HasError() {
+		return nil, fmt.Errorf("error configuring provider: %s", diags[0].Summary)
+	}
+	return p.Meta(), nil
+}
+
+// Export reads the selected objects through the resource's own read function, so that the state
+// flattening logic is shared with `terraform import`, and writes the matching `resource` and
+// `import` blocks to w.
+func Export(ctx context.Context, meta interface{}, opts ExportOptions, w io.Writer) error {
+	providerConf := meta.(*ProviderConfiguration)
+	exportedType, ok := exportedResourceTypes[opts.ResourceType]
+	if !ok {
+		return fmt.Errorf("resource type %s cannot be exported, valid types are %s", opts.ResourceType, strings.Join(ExportResourceTypes(), ", "))
+	}
+	res := Provider().ResourcesMap[opts.ResourceType]
+
+	objects := make([]exportedObject, 0, len(opts.IDs))
+	for _, id := range opts.IDs {
+		objects = append(objects, exportedObject{id: id})
+	}
+	if len(objects) == 0 {
+		var err error
+		objects, err = exportedType.list(ctx, providerConf, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	imports := hclwrite.NewEmptyFile()
+	usedNames := make(map[string]struct{})
+	for _, obj := range objects {
+		d := res.Data(nil)
+		d.SetId(obj.id)
+		diags := res.ReadContext(ctx, d, meta)
+		for _, diag := range diags {
+			log.Printf("[WARN] %s %s: %s %s", opts.ResourceType, obj.id, diag.Summary, diag.Detail)
+		}
+		if diags.HasError() {
+			return fmt.Errorf("error reading %s %s: %s", opts.ResourceType, obj.id, diags[0].Summary)
+		}
+		if d.Id() == "" {
+			return fmt.Errorf("%s %s was not found", opts.ResourceType, obj.id)
+		}
+
+		name := obj.name
+		if v, ok := d.Get(exportedType.nameAttribute).(string); ok && v != "" {
+			name = v
+		}
+		label := exportResourceLabel(name, obj.id, usedNames)
+
+		block := body.AppendNewBlock("resource", []string{opts.ResourceType, label})
+		values := make(map[string]interface{})
+		for k := range res.SchemaMap() {
+			values[k] = d.Get(k)
+		}
+		writeExportBlockBody(block.Body(), res.SchemaMap(), values)
+		body.AppendNewline()
+
+		importBlock := imports.Body().AppendNewBlock("import", nil)
+		importBlock.Body().SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: opts.ResourceType},
+			hcl.TraverseAttr{Name: label},
+		})
+		importBlock.Body().SetAttributeValue("id", cty.StringVal(d.Id()))
+		imports.Body().AppendNewline()
+	}
+
+	if _, err := w.Write(hclwrite.Format(f.Bytes())); err != nil {
+		return err
+	}
+	_, err := w.Write(hclwrite.Format(imports.Bytes()))
+	return err
+}
+
+var exportLabelInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exportResourceLabel builds a valid and unique Terraform resource name from the object name.
+func exportResourceLabel(name, id string, used map[string]struct{}) string {
+	label := strings.Trim(exportLabelInvalidChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" {
+		label = strings.Trim(exportLabelInvalidChars.ReplaceAllString(strings.ToLower(id), "_"), "_")
+	}
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "r_" + label
+	}
+	unique := label
+	for i := 2; ; i++ {
+		if _, ok := used[unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	used[unique] = struct{}{}
+	return unique
+}
+
+var exportBacktickedNames = regexp.MustCompile("`([a-z0-9_]+)`")
+
+func writeExportBlockBody(body *hclwrite.Body, sch map[string]*schema.Schema, values map[string]interface{}) {
+	keys := make([]string, 0, len(sch))
+	for k := range sch {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attributes := make(map[string]bool)
+	var blocks []string
+	for _, k := range keys {
+		s := sch[k]
+		if !s.Optional && !s.Required {
+			continue
+		}
+		if _, ok := s.Elem.(*schema.Resource); ok {
+			if len(exportListItems(values[k])) > 0 {
+				blocks = append(blocks, k)
+			}
+			continue
+		}
+		if !s.Required && isExportDefaultValue(s, values[k]) {
+			continue
+		}
+		attributes[k] = true
+	}
+
+	// Deprecated attributes are usually still set on read alongside the attribute replacing them,
+	// only keep them when the replacement is not in the configuration.
+	for k := range attributes {
+		s := sch[k]
+		if s.Deprecated == "" {
+			continue
+		}
+		replacements := append([]string{}, s.ConflictsWith...)
+		for _, m := range exportBacktickedNames.FindAllStringSubmatch(s.Deprecated, -1) {
+			replacements = append(replacements, m[1])
+		}
+		for _, r := range replacements {
+			r = r[strings.LastIndex(r, ".")+1:]
+			if r != k && attributes[r] {
+				delete(attributes, k)
+				break
+			}
+		}
+	}
+
+	for _, k := range keys {
+		if attributes[k] {
+			body.SetAttributeValue(k, exportCtyValue(sch[k], values[k]))
+		}
+	}
+	if len(attributes) > 0 && len(blocks) > 0 {
+		body.AppendNewline()
+	}
+	for _, k := range blocks {
+		elem := sch[k].Elem.(*schema.Resource)
+		for _, item := range exportListItems(values[k]) {
+			itemValues, _ := item.(map[string]interface{})
+			if itemValues == nil {
+				itemValues = make(map[string]interface{})
+			}
+			nested := body.AppendNewBlock(k, nil)
+			writeExportBlockBody(nested.Body(), elem.SchemaMap(), itemValues)
+		}
+	}
+}
+
+func exportListItems(v interface{}) []interface{} {
+	switch items := v.(type) {
+	case []interface{}:
+		return items
+	case *schema.Set:
+		return items.List()
+	}
+	return nil
+}
+
+func isExportDefaultValue(s *schema.Schema, v interface{}) bool {
+	if s.Default != nil {
+		return fmt.Sprint(v) == fmt.Sprint(s.Default)
+	}
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case int:
+		return value == 0
+	case float64:
+		return value == 0
+	case bool:
+		return !value
+	case []interface{}:
+		return len(value) == 0
+	case *schema.Set:
+		return value.Len() == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
+
+func exportCtyValue(s *schema.Schema, v interface{}) cty.Value {
+	switch s.Type {
+	case schema.TypeString:
+		return cty.StringVal(v.(string))
+	case schema.TypeInt:
+		return cty.NumberIntVal(int64(v.(int)))
+	case schema.TypeFloat:
+		return cty.NumberFloatVal(v.(float64))
+	case schema.TypeBool:
+		return cty.BoolVal(v.(bool))
+	case schema.TypeList, schema.TypeSet:
+		elemSchema, ok := s.Elem.(*schema.Schema)
+		if !ok {
+			elemSchema = &schema.Schema{Type: schema.TypeString}
+		}
+		items := exportListItems(v)
+		values := make([]cty.Value, 0, len(items))
+		for _, item := range items {
+			values = append(values, exportCtyValue(elemSchema, item))
+		}
+		if s.Type == schema.TypeSet {
+			sort.SliceStable(values, func(i, j int) bool {
+				return values[i].GoString() < values[j].GoString()
+			})
+		}
+		return cty.TupleVal(values)
+	case schema.TypeMap:
+		m := make(map[string]cty.Value)
+		for k, item := range v.(map[string]interface{}) {
+			m[k] = cty.StringVal(fmt.Sprint(item))
+		}
+		return cty.ObjectVal(m)
+	}
+	return cty.StringVal(fmt.Sprint(v))
+}
+
+func exportMatchesFilters(name string, tags []string, opts ExportOptions) bool {
+	if opts.NameFilter != "" && !strings.Contains(strings.ToLower(name), strings.ToLower(opts.NameFilter)) {
+		return false
+	}
+	tagSet := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		tagSet[t] = struct{}{}
+	}
+	for _, t := range opts.TagsFilter {
+		if _, ok := tagSet[t]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func listMonitorsForExport(ctx context.Context, providerConf *ProviderConfiguration, opts ExportOptions) ([]exportedObject, error) {
+	optionalParams := datadogV1.NewListMonitorsOptionalParameters()
+	if opts.NameFilter != "" {
+		optionalParams = optionalParams.WithName(opts.NameFilter)
+	}
+	if len(opts.TagsFilter) > 0 {
+		optionalParams = optionalParams.WithMonitorTags(strings.Join(opts.TagsFilter, ","))
+	}
+	monitors, httpresp, err := providerConf.DatadogApiInstances.GetMonitorsApiV1().ListMonitors(providerConf.Auth, *optionalParams)
+	if err != nil {
+		return nil, utils.TranslateClientError(err, httpresp, "error listing monitors")
+	}
+	objects := make([]exportedObject, 0, len(monitors))
+	for _, m := range monitors {
+		// Synthetics monitors are managed through their test
+		if m.GetType() == datadogV1.MONITORTYPE_SYNTHETICS_ALERT {
+			continue
+		}
+		objects = append(objects, exportedObject{id: fmt.Sprint(m.GetId()), name: m.GetName()})
+	}
+	return objects, nil
+}
+
+func listDashboardsForExport(ctx context.Context, providerConf *ProviderConfiguration, opts ExportOptions) ([]exportedObject, error) {
+	if len(opts.TagsFilter) > 0 {
+		return nil, fmt.Errorf("tags filter is not supported for datadog_dashboard, use the `datadog_dashboards` data source instead")
+	}
+	resp, httpresp, err := providerConf.DatadogApiInstances.GetDashboardsApiV1().ListDashboards(providerConf.Auth)
+	if err != nil {
+		return nil, utils.TranslateClientError(err, httpresp, "error listing dashboards")
+	}
+	var objects []exportedObject
+	for _, dash := range resp.GetDashboards() {
+		if exportMatchesFilters(dash.GetTitle(), nil, opts) {
+			objects = append(objects, exportedObject{id: dash.GetId(), name: dash.GetTitle()})
+		}
+	}
+	return objects, nil
+}
+
+func listServiceLevelObjectivesForExport(ctx context.Context, providerConf *ProviderConfiguration, opts ExportOptions) ([]exportedObject, error) {
+	optionalParams := datadogV1.NewListSLOsOptionalParameters()
+	if len(opts.TagsFilter) > 0 {
+		optionalParams = optionalParams.WithTagsQuery(strings.Join(opts.TagsFilter, ","))
+	}
+	var objects []exportedObject
+	items, cancel := providerConf.DatadogApiInstances.GetServiceLevelObjectivesApiV1().ListSLOsWithPagination(providerConf.Auth, *optionalParams)
+	defer cancel()
+	for slo := range items {
+		if slo.Error != nil {
+			return nil, utils.TranslateClientError(slo.Error, nil, "error listing service level objectives")
+		}
+		if exportMatchesFilters(slo.Item.GetName(), nil, opts) {
+			objects = append(objects, exportedObject{id: slo.Item.GetId(), name: slo.Item.GetName()})
+		}
+	}
+	return objects, nil
+}
+
+func listSyntheticsTestsForExport(ctx context.Context, providerConf *ProviderConfiguration, opts ExportOptions) ([]exportedObject, error) {
+	var objects []exportedObject
+	items, cancel := providerConf.DatadogApiInstances.GetSyntheticsApiV1().ListTestsWithPagination(providerConf.Auth)
+	defer cancel()
+	for test := range items {
+		if test.Error != nil {
+			return nil, utils.TranslateClientError(test.Error, nil, "error listing synthetics tests")
+		}
+		if exportMatchesFilters(test.Item.GetName(), test.Item.GetTags(), opts) {
+			objects = append(objects, exportedObject{id: test.Item.GetPublicId(), name: test.Item.GetName()})
+		}
+	}
+	return objects, nil
+}
+
+func listLogsCustomPipelinesForExport(ctx context.Context, providerConf *ProviderConfiguration, opts ExportOptions) ([]exportedObject, error) {
+	if len(opts.TagsFilter) > 0 {
+		return nil, fmt.Errorf("tags filter is not supported for datadog_logs_custom_pipeline")
+	}
+	pipelines, httpresp, err := providerConf.DatadogApiInstances.GetLogsPipelinesApiV1().ListLogsPipelines(providerConf.Auth)
+	if err != nil {
+		return nil, utils.TranslateClientError(err, httpresp, "error listing logs pipelines")
+	}
+	var objects []exportedObject
+	for _, p := range pipelines {
+		// Integration pipelines are read-only and managed by `datadog_logs_integration_pipeline`
+		if p.GetIsReadOnly() {
+			continue
+		}
+		if exportMatchesFilters(p.GetName(), nil, opts) {
+			objects = append(objects, exportedObject{id: p.GetId(), name: p.GetName()})
+		}
+	}
+	return objects, nil
+}
+
+func listSecurityMonitoringRulesForExport(ctx context.Context, providerConf *ProviderConfiguration, opts ExportOptions) ([]exportedObject, error) {
+	const pageSize = int64(100)
+	var objects []exportedObject
+	page := int64(0)
+	for {
+		response, httpresp, err := providerConf.DatadogApiInstances.GetSecurityMonitoringApiV2().ListSecurityMonitoringRules(providerConf.Auth,
+			datadogV2.ListSecurityMonitoringRulesOptionalParameters{
+				PageNumber: datadog.PtrInt64(page),
+				PageSize:   datadog.PtrInt64(pageSize),
+			})
+		if err != nil {
+			return nil, utils.TranslateClientError(err, httpresp, "error listing rules")
+		}
+
+		rules := response.GetData()
+		for _, ruleR := range rules {
+			var id, name string
+			var isDefault bool
+			var tags []string
+			if rule := ruleR.SecurityMonitoringStandardRuleResponse; rule != nil {
+				id, name, isDefault, tags = rule.GetId(), rule.GetName(), rule.GetIsDefault(), rule.GetTags()
+			} else if rule := ruleR.SecurityMonitoringSignalRuleResponse; rule != nil {
+				id, name, isDefault, tags = rule.GetId(), rule.GetName(), rule.GetIsDefault(), rule.GetTags()
+			} else {
+				continue
+			}
+			// Default rules are managed by `datadog_security_monitoring_default_rule`
+			if isDefault || !exportMatchesFilters(name, tags, opts) {
+				continue
+			}
+			objects = append(objects, exportedObject{id: id, name: name})
+		}
+
+		// Stop on a short page, or once all the rules reported by the API were listed
+		if int64(len(rules)) < pageSize {
+			break
+		}
+		meta := response.GetMeta()
+		pagination := meta.GetPage()
+		if totalCount, ok := pagination.GetTotalCountOk(); ok && (page+1)*pageSize >= *totalCount {
+			break
+		}
+		page++
+	}
+	return objects, nil
+}
@@ -0,0 +1,169 @@
+package datadog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+// ConvertDashboardJSON converts a dashboard JSON definition, as stored by `datadog_dashboard_json`,
+// into an equivalent `datadog_dashboard` resource named after the dashboard title.
+// It returns one warning per widget or attribute that the typed resource cannot express: unsupported
+// widgets are removed from the generated configuration, other attributes are reported by JSON path.
+func ConvertDashboardJSON(dashboardJSON string) ([]byte, []string, error) {
+	var attrMap map[string]interface{}
+	if err := json.Unmarshal([]byte(dashboardJSON), &attrMap); err != nil {
+		return nil, nil, fmt.Errorf("error parsing dashboard JSON: %s", err)
+	}
+	utils.PrepDashboardJSON(attrMap)
+
+	var warnings []string
+	if widgets, ok := attrMap["widgets"].([]interface{}); ok {
+		attrMap["widgets"] = filterConvertibleWidgets(widgets, "widgets", &warnings)
+	}
+
+	// Round-trip through JSON so that the original definition can be compared with the
+	// one rebuilt from the generated configuration, using the same encoding.
+	filteredJSON, err := json.Marshal(attrMap)
+	if err != nil {
+		return nil, nil, err
+	}
+	var dashboard datadogV1.Dashboard
+	if err := json.Unmarshal(filteredJSON, &dashboard); err != nil {
+		return nil, nil, fmt.Errorf("error parsing dashboard JSON: %s", err)
+	}
+	if err := utils.CheckForUnparsed(dashboard); err != nil {
+		return nil, nil, err
+	}
+
+	res := resourceDatadogDashboard()
+	d := res.Data(nil)
+	if diags := updateDashboardState(d, &dashboard); diags.HasError() {
+		return nil, nil, fmt.Errorf("error converting dashboard: %s", diags[0].Summary)
+	}
+
+	rebuilt, err := buildDatadogDashboard(d)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error converting dashboard: %s", err)
+	}
+	rebuiltJSON, err := json.Marshal(rebuilt)
+	if err != nil {
+		return nil, nil, err
+	}
+	var original, converted map[string]interface{}
+	if err := json.Unmarshal(filteredJSON, &original); err != nil {
+		return nil, nil, fmt.Errorf("error parsing dashboard JSON: %s", err)
+	}
+	if err := json.Unmarshal(rebuiltJSON, &converted); err != nil {
+		return nil, nil, fmt.Errorf("error parsing converted dashboard: %s", err)
+	}
+	utils.PrepDashboardJSON(original)
+	utils.PrepDashboardJSON(converted)
+	for _, p := range lostJSONPaths(original, converted, "") {
+		warnings = append(warnings, fmt.Sprintf("%s: value cannot be expressed with datadog_dashboard and was not converted", p))
+	}
+
+	f := hclwrite.NewEmptyFile()
+	label := exportResourceLabel(dashboard.GetTitle(), "dashboard", make(map[string]struct{}))
+	block := f.Body().AppendNewBlock("resource", []string{"datadog_dashboard", label})
+	values := make(map[string]interface{})
+	for k := range res.SchemaMap() {
+		values[k] = d.Get(k)
+	}
+	writeExportBlockBody(block.Body(), res.SchemaMap(), values)
+
+	return hclwrite.Format(f.Bytes()), warnings, nil
+}
+
+// filterConvertibleWidgets removes the widgets that can't be represented by `datadog_dashboard`,
+// including the ones nested in group widgets, and records a warning for each of them.
+func filterConvertibleWidgets(widgets []interface{}, path string, warnings *[]string) []interface{} {
+	filtered := make([]interface{}, 0, len(widgets))
+	for i, w := range widgets {
+		widgetPath := fmt.Sprintf("%s[%d]", path, i)
+		widgetMap, _ := w.(map[string]interface{})
+		definition, _ := widgetMap["definition"].(map[string]interface{})
+		if nested, ok := definition["widgets"].([]interface{}); ok && definition["type"] == "group" {
+			definition["widgets"] = filterConvertibleWidgets(nested, widgetPath+".definition.widgets", warnings)
+		}
+
+		widgetType, _ := definition["type"].(string)
+		if reason := unconvertibleWidgetReason(widgetMap); reason != "" {
+			*warnings = append(*warnings, fmt.Sprintf("%s: %s widget cannot be expressed with datadog_dashboard and was removed: %s", widgetPath, widgetType, reason))
+			continue
+		}
+		filtered = append(filtered, w)
+	}
+	return filtered
+}
+
+func unconvertibleWidgetReason(widgetMap map[string]interface{}) string {
+	widgetJSON, err := json.Marshal(widgetMap)
+	if err != nil {
+		return err.Error()
+	}
+	var widget datadogV1.Widget
+	if err := json.Unmarshal(widgetJSON, &widget); err != nil {
+		return err.Error()
+	}
+	if err := utils.CheckForUnparsed(widget); err != nil {
+		return err.Error()
+	}
+	if _, err := buildTerraformWidget(&widget); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// lostJSONPaths returns the paths of the values of original that are missing or different in converted.
+// Values only present in converted are ignored, as they are defaults added by the resource.
+func lostJSONPaths(original, converted interface{}, path string) []string {
+	switch o := original.(type) {
+	case map[string]interface{}:
+		c, ok := converted.(map[string]interface{})
+		if !ok {
+			return []string{path}
+		}
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var lost []string
+		for _, k := range keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			if o[k] == nil {
+				continue
+			}
+			if _, ok := c[k]; !ok {
+				lost = append(lost, childPath)
+				continue
+			}
+			lost = append(lost, lostJSONPaths(o[k], c[k], childPath)...)
+		}
+		return lost
+	case []interface{}:
+		c, ok := converted.([]interface{})
+		if !ok || len(c) != len(o) {
+			return []string{path}
+		}
+		var lost []string
+		for i := range o {
+			lost = append(lost, lostJSONPaths(o[i], c[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return lost
+	}
+	if !reflect.DeepEqual(original, converted) {
+		return []string{path}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package datadog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertDashboardJSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		json     string
+		config   []string
+		warnings []string
+		err      string
+	}{
+		{
+			name: "valid dashboard",
+			json: `{
+				"title": "Payments",
+				"layout_type": "ordered",
+				"widgets": [{"definition": {"type": "note", "content": "Owned by payments"}}]
+			}`,
+			config: []string{`resource "datadog_dashboard" "payments"`, `note_definition`, `"Owned by payments"`},
+		},
+		{
+			name: "malformed JSON",
+			json: `{"title": "Payments",`,
+			err:  "error parsing dashboard JSON",
+		},
+		{
+			name: "not an object",
+			json: `["Payments"]`,
+			err:  "error parsing dashboard JSON",
+		},
+		{
+			name: "unknown widget type",
+			json: `{
+				"title": "Payments",
+				"layout_type": "ordered",
+				"widgets": [
+					{"definition": {"type": "note", "content": "Owned by payments"}},
+					{"definition": {"type": "unknown_widget", "content": "Unknown"}}
+				]
+			}`,
+			config:   []string{`"Owned by payments"`},
+			warnings: []string{"widgets[1]: unknown_widget widget cannot be expressed with datadog_dashboard and was removed"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, warnings, err := ConvertDashboardJSON(c.json)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("expected error containing %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, expected := range c.config {
+				if !strings.Contains(string(config), expected) {
+					t.Errorf("expected configuration to contain %q, got:\n%s", expected, config)
+				}
+			}
+			if strings.Contains(string(config), "unknown_widget") || strings.Contains(string(config), "Unknown") {
+				t.Errorf("expected unknown widgets to be removed, got:\n%s", config)
+			}
+			if len(warnings) != len(c.warnings) {
+				t.Fatalf("expected warnings %v, got %v", c.warnings, warnings)
+			}
+			for i, expected := range c.warnings {
+				if !strings.HasPrefix(warnings[i], expected) {
+					t.Errorf("expected warning %q, got %q", expected, warnings[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+func TestExportResourceLabel(t *testing.T) {
+	used := make(map[string]struct{})
+	cases := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{name: "Payments API latency", id: "123", expected: "payments_api_latency"},
+		{name: "payments-api latency!", id: "456", expected: "payments_api_latency_2"},
+		{name: "Payments API latency", id: "789", expected: "payments_api_latency_3"},
+		{name: "", id: "abc-def-ghi", expected: "abc_def_ghi"},
+		{name: "5xx errors", id: "1", expected: "r_5xx_errors"},
+		{name: "", id: "12345", expected: "r_12345"},
+		{name: "???", id: "---", expected: "r_"},
+		{name: "!!!", id: "", expected: "r__2"},
+	}
+	for _, tc := range cases {
+		if label := exportResourceLabel(tc.name, tc.id, used); label != tc.expected {
+			t.Errorf("expected label %q for %q (%s), got %q", tc.expected, tc.name, tc.id, label)
+		}
+	}
+}
+
+func TestIsExportDefaultValue(t *testing.T) {
+	cases := []struct {
+		name     string
+		schema   *schema.Schema
+		value    interface{}
+		expected bool
+	}{
+		{name: "empty string", schema: &schema.Schema{Type: schema.TypeString}, value: "", expected: true},
+		{name: "string", schema: &schema.Schema{Type: schema.TypeString}, value: "a", expected: false},
+		{name: "string default", schema: &schema.Schema{Type: schema.TypeString, Default: "a"}, value: "a", expected: true},
+		{name: "empty string with a default", schema: &schema.Schema{Type: schema.TypeString, Default: "a"}, value: "", expected: false},
+		{name: "zero int", schema: &schema.Schema{Type: schema.TypeInt}, value: 0, expected: true},
+		{name: "int default", schema: &schema.Schema{Type: schema.TypeInt, Default: 10}, value: 10, expected: true},
+		{name: "zero int with a default", schema: &schema.Schema{Type: schema.TypeInt, Default: 10}, value: 0, expected: false},
+		{name: "zero float", schema: &schema.Schema{Type: schema.TypeFloat}, value: 0.0, expected: true},
+		{name: "false", schema: &schema.Schema{Type: schema.TypeBool}, value: false, expected: true},
+		{name: "true with a default", schema: &schema.Schema{Type: schema.TypeBool, Default: true}, value: true, expected: true},
+		{name: "false with a default", schema: &schema.Schema{Type: schema.TypeBool, Default: true}, value: false, expected: false},
+		{name: "empty list", schema: &schema.Schema{Type: schema.TypeList}, value: []interface{}{}, expected: true},
+		{name: "list", schema: &schema.Schema{Type: schema.TypeList}, value: []interface{}{"a"}, expected: false},
+		{name: "empty set", schema: &schema.Schema{Type: schema.TypeSet}, value: schema.NewSet(schema.HashString, nil), expected: true},
+		{name: "empty map", schema: &schema.Schema{Type: schema.TypeMap}, value: map[string]interface{}{}, expected: true},
+		{name: "nil", schema: &schema.Schema{Type: schema.TypeString}, value: nil, expected: true},
+	}
+	for _, tc := range cases {
+		if isDefault := isExportDefaultValue(tc.schema, tc.value); isDefault != tc.expected {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.expected, isDefault)
+		}
+	}
+}
+
+func TestWriteExportBlockBody(t *testing.T) {
+	sch := map[string]*schema.Schema{
+		"name":        {Type: schema.TypeString, Required: true},
+		"description": {Type: schema.TypeString, Optional: true},
+		"priority":    {Type: schema.TypeInt, Optional: true, Default: 3},
+		"enabled":     {Type: schema.TypeBool, Optional: true, Default: true},
+		"tags":        {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"api_key":     {Type: schema.TypeString, Optional: true, Sensitive: true},
+		"created_at":  {Type: schema.TypeString, Computed: true},
+		"state":       {Type: schema.TypeString, Optional: true, Computed: true},
+		"old_query":   {Type: schema.TypeString, Optional: true, Deprecated: "Use `query` instead."},
+		"query":       {Type: schema.TypeString, Optional: true},
+		"option": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"window": {Type: schema.TypeString, Required: true},
+					"renotify": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"interval": {Type: schema.TypeInt, Optional: true},
+							},
+						},
+					},
+				},
+			},
+		},
+		"empty_block": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Resource{Schema: map[string]*schema.Schema{"value": {Type: schema.TypeString, Optional: true}}},
+		},
+	}
+	values := map[string]interface{}{
+		"name":        "Payments",
+		"description": "",
+		"priority":    3,
+		"enabled":     false,
+		"tags":        schema.NewSet(schema.HashString, []interface{}{"team:payments", "env:prod"}),
+		"api_key":     "secret",
+		"created_at":  "2024-01-01",
+		"state":       "active",
+		"old_query":   "service:payments",
+		"query":       "service:payments",
+		"option": []interface{}{
+			map[string]interface{}{
+				"window":   "5m",
+				"renotify": []interface{}{map[string]interface{}{"interval": 10}},
+			},
+			map[string]interface{}{"window": "1h", "renotify": []interface{}{}},
+		},
+		"empty_block": []interface{}{},
+	}
+
+	f := hclwrite.NewEmptyFile()
+	writeExportBlockBody(f.Body(), sch, values)
+	config := string(hclwrite.Format(f.Bytes()))
+
+	expected := `api_key = "secret"
+enabled = false
+name    = "Payments"
+query   = "service:payments"
+state   = "active"
+tags    = ["env:prod", "team:payments"]
+
+option {
+  window = "5m"
+
+  renotify {
+    interval = 10
+  }
+}
+option {
+  window = "1h"
+}
+`
+	if config != expected {
+		t.Errorf("unexpected configuration:\n%s\nexpected:\n%s", config, expected)
+	}
+}
+
+// TestExportRoundTrip checks that the configuration generated from a resource's state doesn't produce a diff
+func TestExportRoundTrip(t *testing.T) {
+	res := resourceDatadogLogsCustomPipeline()
+	d := schema.TestResourceDataRaw(t, res.SchemaMap(), map[string]interface{}{
+		"name":       "Payments",
+		"is_enabled": true,
+		"filter":     []interface{}{map[string]interface{}{"query": "service:payments"}},
+		"processor": []interface{}{
+			map[string]interface{}{
+				"date_remapper": []interface{}{map[string]interface{}{
+					"name":       "Date remapper",
+					"is_enabled": true,
+					"sources":    []interface{}{"timestamp", "date"},
+				}},
+			},
+			map[string]interface{}{
+				"category_processor": []interface{}{map[string]interface{}{
+					"name":   "Status category",
+					"target": "status_category",
+					"category": []interface{}{
+						map[string]interface{}{"name": "error", "filter": []interface{}{map[string]interface{}{"query": "@http.status_code:[500 TO 599]"}}},
+						map[string]interface{}{"name": "ok", "filter": []interface{}{map[string]interface{}{"query": "@http.status_code:[200 TO 299]"}}},
+					},
+				}},
+			},
+			map[string]interface{}{
+				"pipeline": []interface{}{map[string]interface{}{
+					"name":       "Nested",
+					"is_enabled": true,
+					"filter":     []interface{}{map[string]interface{}{"query": "source:nginx"}},
+					"processor": []interface{}{map[string]interface{}{
+						"url_parser": []interface{}{map[string]interface{}{
+							"name":                     "URL parser",
+							"sources":                  []interface{}{"http.url"},
+							"target":                   "http.url_details",
+							"normalize_ending_slashes": true,
+						}},
+					}},
+				}},
+			},
+		},
+	})
+	d.SetId("abc-123")
+	state := d.State()
+
+	values := make(map[string]interface{})
+	for k := range res.SchemaMap() {
+		values[k] = d.Get(k)
+	}
+	f := hclwrite.NewEmptyFile()
+	writeExportBlockBody(f.Body(), res.SchemaMap(), values)
+
+	file, diags := hclsyntax.ParseConfig(f.Bytes(), "export.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("error parsing the generated configuration: %s", diags)
+	}
+	config := terraform.NewResourceConfigRaw(exportTestRawConfig(t, file.Body.(*hclsyntax.Body)))
+	diff, err := res.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		var changes []string
+		for k, attr := range diff.Attributes {
+			changes = append(changes, k+": "+attr.Old+" => "+attr.New)
+		}
+		t.Errorf("expected no diff, got:\n%s\nfor the configuration:\n%s", strings.Join(changes, "\n"), hclwrite.Format(f.Bytes()))
+	}
+}
+
+// exportTestRawConfig converts a parsed configuration to the raw format used by terraform.NewResourceConfigRaw
+func exportTestRawConfig(t *testing.T, body *hclsyntax.Body) map[string]interface{} {
+	raw := make(map[string]interface{})
+	for name, attr := range body.Attributes {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			t.Fatalf("error evaluating %s: %s", name, diags)
+		}
+		encoded, err := ctyjson.Marshal(value, value.Type())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		raw[name] = decoded
+	}
+	for _, block := range body.Blocks {
+		blocks, _ := raw[block.Type].([]interface{})
+		raw[block.Type] = append(blocks, exportTestRawConfig(t, block.Body))
+	}
+	return raw
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +29,8 @@ import (
 )
 
 var (
-	_ provider.Provider = &FrameworkProvider{}
+	_ provider.Provider              = &FrameworkProvider{}
+	_ provider.ProviderWithFunctions = &FrameworkProvider{}
 )
 
 var Resources = []func() resource.Resource{
@@ -64,6 +66,15 @@ var Datasources = []func() datasource.DataSource{
 	NewSensitiveDataScannerGroupOrderDatasource,
 }
 
+var Functions = []func() function.Function{
+	NewDashboardJSONCanonicalizeFunction,
+	NewMonitorQueryFunction,
+	NewNormalizeTagFunction,
+	NewParseMonitorIDFromURLFunction,
+	NewTagListToMapFunction,
+	NewTagMapToListFunction,
+}
+
 // FrameworkProvider struct
 type FrameworkProvider struct {
 	CommunityClient     *datadogCommunity.Client
@@ -113,6 +124,10 @@ func (p *FrameworkProvider) DataSources(_ context.Context) []func() datasource.D
 	return wrappedDatasources
 }
 
+func (p *FrameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return Functions
+}
+
 func (p *FrameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, response *provider.MetadataResponse) {
 	response.TypeName = "datadog_"
 }
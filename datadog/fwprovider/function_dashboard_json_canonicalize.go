@@ -0,0 +1,58 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var _ function.Function = &dashboardJSONCanonicalizeFunction{}
+
+func NewDashboardJSONCanonicalizeFunction() function.Function {
+	return &dashboardJSONCanonicalizeFunction{}
+}
+
+type dashboardJSONCanonicalizeFunction struct{}
+
+func (f *dashboardJSONCanonicalizeFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "dashboard_json_canonicalize"
+}
+
+func (f *dashboardJSONCanonicalizeFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Canonicalize a dashboard JSON definition.",
+		Description: "Returns the dashboard JSON definition in the canonical form stored by the `datadog_dashboard_json` resource: computed attributes such as `id`, `url` or widget IDs are removed, defaults are set and keys are sorted. This allows comparing an exported dashboard with the configured one.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "dashboard",
+				Description: "The JSON formatted definition of the dashboard.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *dashboardJSONCanonicalizeFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var dashboard string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &dashboard))
+	if response.Error != nil {
+		return
+	}
+
+	var attrMap map[string]interface{}
+	if err := json.Unmarshal([]byte(dashboard), &attrMap); err != nil {
+		response.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid dashboard JSON: %s", err))
+		return
+	}
+	canonical, err := json.Marshal(utils.PrepDashboardJSON(attrMap))
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, string(canonical)))
+}
@@ -0,0 +1,144 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &monitorQueryFunction{}
+
+var (
+	monitorQueryAggregations   = []string{"avg", "max", "min", "sum"}
+	monitorQueryComparators    = []string{">", ">=", "<", "<="}
+	monitorQueryMetricRegex    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.]*$`)
+	monitorQueryTimeframeRegex = regexp.MustCompile(`^last_\d+(m|h|d|w|mo)$`)
+	// Characters with a meaning in the query syntax, which must be escaped in tags
+	monitorQueryTagEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, `{`, `\{`, `}`, `\}`, `(`, `\(`, `)`, `\)`, ` `, `\ `)
+)
+
+func NewMonitorQueryFunction() function.Function {
+	return &monitorQueryFunction{}
+}
+
+type monitorQueryFunction struct{}
+
+func (f *monitorQueryFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "monitor_query"
+}
+
+func (f *monitorQueryFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Build a metric monitor query.",
+		Description: "Returns a metric monitor query, such as `avg(last_5m):avg:system.cpu.user{env:prod,!host:a\\ b} by {host} > 90`, for use in the `query` attribute of `datadog_monitor`. Characters with a meaning in the query syntax are escaped in the filter and group by tags.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "time_aggregation",
+				Description: "The aggregation applied over the evaluation window. Valid values are `avg`, `max`, `min` and `sum`.",
+			},
+			function.StringParameter{
+				Name:        "timeframe",
+				Description: "The evaluation window, such as `last_5m`, `last_1h` or `last_1d`.",
+			},
+			function.StringParameter{
+				Name:        "space_aggregation",
+				Description: "The aggregation applied across the reporting sources. Valid values are `avg`, `max`, `min` and `sum`.",
+			},
+			function.StringParameter{
+				Name:        "metric",
+				Description: "The name of the metric.",
+			},
+			function.ListParameter{
+				Name:        "filter",
+				Description: "The tags to filter the metric on. Prefix a tag with `!` to exclude it. An empty list queries every source.",
+				ElementType: types.StringType,
+			},
+			function.ListParameter{
+				Name:        "group_by",
+				Description: "The tag keys to group the query by, for multi alert monitors.",
+				ElementType: types.StringType,
+			},
+			function.StringParameter{
+				Name:        "comparator",
+				Description: "The comparator applied to the threshold. Valid values are `>`, `>=`, `<` and `<=`.",
+			},
+			function.NumberParameter{
+				Name:        "threshold",
+				Description: "The critical threshold of the monitor.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *monitorQueryFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var timeAggregation, timeframe, spaceAggregation, metric, comparator string
+	var filter, groupBy []string
+	var threshold float64
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &timeAggregation, &timeframe, &spaceAggregation, &metric, &filter, &groupBy, &comparator, &threshold))
+	if response.Error != nil {
+		return
+	}
+
+	query, err := buildMonitorQuery(timeAggregation, timeframe, spaceAggregation, metric, filter, groupBy, comparator, threshold)
+	if err != nil {
+		response.Error = err
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, query))
+}
+
+func buildMonitorQuery(timeAggregation, timeframe, spaceAggregation, metric string, filter, groupBy []string, comparator string, threshold float64) (string, *function.FuncError) {
+	if !slices.Contains(monitorQueryAggregations, timeAggregation) {
+		return "", function.NewArgumentFuncError(0, fmt.Sprintf("time_aggregation must be one of %s, got %q", strings.Join(monitorQueryAggregations, ", "), timeAggregation))
+	}
+	if !monitorQueryTimeframeRegex.MatchString(timeframe) {
+		return "", function.NewArgumentFuncError(1, fmt.Sprintf("timeframe must be an evaluation window such as last_5m, got %q", timeframe))
+	}
+	if !slices.Contains(monitorQueryAggregations, spaceAggregation) {
+		return "", function.NewArgumentFuncError(2, fmt.Sprintf("space_aggregation must be one of %s, got %q", strings.Join(monitorQueryAggregations, ", "), spaceAggregation))
+	}
+	if !monitorQueryMetricRegex.MatchString(metric) {
+		return "", function.NewArgumentFuncError(3, fmt.Sprintf("%q is not a valid metric name", metric))
+	}
+	if !slices.Contains(monitorQueryComparators, comparator) {
+		return "", function.NewArgumentFuncError(6, fmt.Sprintf("comparator must be one of %s, got %q", strings.Join(monitorQueryComparators, ", "), comparator))
+	}
+
+	scope := make([]string, 0, len(filter))
+	for _, tag := range filter {
+		if tag == "" || tag == "!" {
+			return "", function.NewArgumentFuncError(4, "filter tags must not be empty")
+		}
+		if strings.HasPrefix(tag, "!") {
+			scope = append(scope, "!"+monitorQueryTagEscaper.Replace(tag[1:]))
+		} else {
+			scope = append(scope, monitorQueryTagEscaper.Replace(tag))
+		}
+	}
+	if len(scope) == 0 {
+		scope = append(scope, "*")
+	}
+
+	var query strings.Builder
+	fmt.Fprintf(&query, "%s(%s):%s:%s{%s}", timeAggregation, timeframe, spaceAggregation, metric, strings.Join(scope, ","))
+	if len(groupBy) > 0 {
+		keys := make([]string, 0, len(groupBy))
+		for _, key := range groupBy {
+			if key == "" {
+				return "", function.NewArgumentFuncError(5, "group by tag keys must not be empty")
+			}
+			keys = append(keys, monitorQueryTagEscaper.Replace(key))
+		}
+		fmt.Fprintf(&query, " by {%s}", strings.Join(keys, ","))
+	}
+	fmt.Fprintf(&query, " %s %s", comparator, strconv.FormatFloat(threshold, 'f', -1, 64))
+	return query.String(), nil
+}
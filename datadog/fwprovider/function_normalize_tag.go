@@ -0,0 +1,45 @@
+package fwprovider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var _ function.Function = &normalizeTagFunction{}
+
+func NewNormalizeTagFunction() function.Function {
+	return &normalizeTagFunction{}
+}
+
+type normalizeTagFunction struct{}
+
+func (f *normalizeTagFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "normalize_tag"
+}
+
+func (f *normalizeTagFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Normalize a tag the same way Datadog does.",
+		Description: "Returns the given tag normalized according to the Datadog tagging conventions: lowercased, with unsupported characters replaced by underscores and truncated to 200 characters. This is the normalization applied by the provider and the Datadog backend.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "tag",
+				Description: "The tag to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeTagFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var tag string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &tag))
+	if response.Error != nil {
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, utils.NormalizeTag(tag)))
+}
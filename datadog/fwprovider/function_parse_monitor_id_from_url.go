@@ -0,0 +1,71 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &parseMonitorIDFromURLFunction{}
+
+// Matches both `/monitors/<id>` and the legacy `/monitors#<id>` URL formats.
+var monitorURLIDRegex = regexp.MustCompile(`^/monitors(?:/|#)(\d+)(?:/.*)?$`)
+
+func NewParseMonitorIDFromURLFunction() function.Function {
+	return &parseMonitorIDFromURLFunction{}
+}
+
+type parseMonitorIDFromURLFunction struct{}
+
+func (f *parseMonitorIDFromURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "parse_monitor_id_from_url"
+}
+
+func (f *parseMonitorIDFromURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Extract the monitor ID from a monitor URL.",
+		Description: "Returns the ID of the monitor referenced by a Datadog monitor URL, such as `https://app.datadoghq.com/monitors/12345?view=spans`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "url",
+				Description: "The URL of the monitor.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *parseMonitorIDFromURLFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var monitorURL string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &monitorURL))
+	if response.Error != nil {
+		return
+	}
+
+	id, err := parseMonitorIDFromURL(monitorURL)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, id))
+}
+
+func parseMonitorIDFromURL(monitorURL string) (string, error) {
+	u, err := url.Parse(monitorURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid monitor URL %q: %s", monitorURL, err)
+	}
+	path := u.Path
+	if u.Fragment != "" {
+		path += "#" + u.Fragment
+	}
+	matches := monitorURLIDRegex.FindStringSubmatch(path)
+	if matches == nil {
+		return "", fmt.Errorf("%q is not a monitor URL, expected a URL such as https://app.datadoghq.com/monitors/12345", monitorURL)
+	}
+	return matches[1], nil
+}
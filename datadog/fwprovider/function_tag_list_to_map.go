@@ -0,0 +1,57 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &tagListToMapFunction{}
+
+func NewTagListToMapFunction() function.Function {
+	return &tagListToMapFunction{}
+}
+
+type tagListToMapFunction struct{}
+
+func (f *tagListToMapFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "tag_list_to_map"
+}
+
+func (f *tagListToMapFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Convert a list of tags into a map of tags.",
+		Description: "Returns the map corresponding to the given list of `key:value` tags. Tags are split on their first `:`, tags without value are mapped to an empty string. Fails if a key appears more than once.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "tags",
+				Description: "The list of tags to convert.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.MapReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *tagListToMapFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var tags []string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &tags))
+	if response.Error != nil {
+		return
+	}
+
+	tagMap := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		k, v, _ := strings.Cut(tag, ":")
+		if _, ok := tagMap[k]; ok {
+			response.Error = function.NewArgumentFuncError(0, fmt.Sprintf("tag key %q is present more than once", k))
+			return
+		}
+		tagMap[k] = v
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, tagMap))
+}
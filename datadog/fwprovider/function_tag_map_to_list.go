@@ -0,0 +1,56 @@
+package fwprovider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &tagMapToListFunction{}
+
+func NewTagMapToListFunction() function.Function {
+	return &tagMapToListFunction{}
+}
+
+type tagMapToListFunction struct{}
+
+func (f *tagMapToListFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "tag_map_to_list"
+}
+
+func (f *tagMapToListFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Convert a map of tags into a list of tags.",
+		Description: "Returns the list of `key:value` tags corresponding to the given map, sorted by key. Entries with an empty value are converted to a `key` tag.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:        "tags",
+				Description: "The map of tags to convert.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *tagMapToListFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var tagMap map[string]string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &tagMap))
+	if response.Error != nil {
+		return
+	}
+
+	tags := make([]string, 0, len(tagMap))
+	for k, v := range tagMap {
+		if v == "" {
+			tags = append(tags, k)
+		} else {
+			tags = append(tags, k+":"+v)
+		}
+	}
+	sort.Strings(tags)
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, tags))
+}
@@ -5,10 +5,13 @@ import (
 	"fmt"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	frameworkPath "github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
@@ -26,6 +29,7 @@ func NewSensitiveDataScannerGroupOrder() resource.Resource {
 type sensitiveDataScannerGroupOrderModel struct {
 	ID       types.String `tfsdk:"id"`
 	GroupIDs types.List   `tfsdk:"group_ids"`
+	Mode     types.String `tfsdk:"mode"`
 }
 
 type sensitiveDataScannerGroupOrder struct {
@@ -52,6 +56,13 @@ func (r *sensitiveDataScannerGroupOrder) Schema(_ context.Context, _ resource.Sc
 				ElementType: types.StringType,
 				Required:    true,
 			},
+			"mode": schema.StringAttribute{
+				Description: "How the `group_ids` list is enforced. With `full`, it must list all the groups. With `relative`, only the relative order of the listed groups is enforced and the other groups keep their position. Valid values are `full`, `relative`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(utils.OrderModeFull),
+				Validators:  []validator.String{stringvalidator.OneOf(utils.OrderModes...)},
+			},
 			// Resource ID
 			"id": utils.ResourceIDAttribute(),
 		},
@@ -83,19 +94,15 @@ func (r *sensitiveDataScannerGroupOrder) Read(ctx context.Context, request resou
 		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, fmt.Sprintf("error reading SDS groups. http response: %v", httpResponse)))
 		return
 	}
-	var groups []datadogV2.SensitiveDataScannerGroupItem
-	var groupID string
-	if respData, ok := resp.GetDataOk(); ok {
-		if respRelationships, ok := respData.GetRelationshipsOk(); ok {
-			if respGroups, ok := respRelationships.GetGroupsOk(); ok {
-				groups = respGroups.GetData()
-			}
-		}
-		groupID = respData.GetId()
-	}
-	tfList := make([]string, len(groups))
-	for i, ddGroup := range groups {
-		tfList[i] = ddGroup.GetId()
+	tfList := getSensitiveDataScannerGroupIDs(resp)
+	data := resp.GetData()
+	groupID := data.GetId()
+	if state.Mode.ValueString() == utils.OrderModeRelative {
+		var listed []string
+		response.Diagnostics.Append(state.GroupIDs.ElementsAs(ctx, &listed, false)...)
+		tfList = utils.FilterRelativeOrder(tfList, listed)
+	} else {
+		state.Mode = types.StringValue(utils.OrderModeFull)
 	}
 
 	state.GroupIDs, _ = types.ListValueFrom(ctx, types.StringType, tfList)
@@ -136,6 +143,27 @@ func (r *sensitiveDataScannerGroupOrder) updateOrder(state *sensitiveDataScanner
 		diag.Append(utils.FrameworkErrorDiag(err, fmt.Sprintf("error getting Sensitive Data Scanner groups list: %v", httpResponse)))
 	}
 
+	if state.Mode.ValueString() == utils.OrderModeRelative {
+		if diag.HasError() {
+			return
+		}
+		current := getSensitiveDataScannerGroupIDs(ddSDSGroupsList)
+		desired := make([]string, len(ddList))
+		for i, ddGroup := range ddList {
+			desired[i] = ddGroup.GetId()
+		}
+		merged, err := utils.MergeRelativeOrder(current, desired)
+		if err != nil {
+			diag.AddError("cannot map groups to existing ones", err.Error())
+			return
+		}
+		ddList = make([]datadogV2.SensitiveDataScannerGroupItem, len(merged))
+		for i, id := range merged {
+			ddList[i] = *datadogV2.NewSensitiveDataScannerGroupItemWithDefaults()
+			ddList[i].SetId(id)
+		}
+	}
+
 	SDSGroupOrderRequest := datadogV2.NewSensitiveDataScannerConfigRequestWithDefaults()
 	SDSGroupOrderRequestConfig := datadogV2.NewSensitiveDataScannerReorderConfigWithDefaults()
 	SDSGroupOrderRequestRelationships := datadogV2.NewSensitiveDataScannerConfigurationRelationshipsWithDefaults()
@@ -155,3 +183,19 @@ func (r *sensitiveDataScannerGroupOrder) updateOrder(state *sensitiveDataScanner
 	}
 	state.ID = types.StringValue(ddSDSGroupsList.Data.GetId())
 }
+
+func getSensitiveDataScannerGroupIDs(resp datadogV2.SensitiveDataScannerGetConfigResponse) []string {
+	var groups []datadogV2.SensitiveDataScannerGroupItem
+	if respData, ok := resp.GetDataOk(); ok {
+		if respRelationships, ok := respData.GetRelationshipsOk(); ok {
+			if respGroups, ok := respRelationships.GetGroupsOk(); ok {
+				groups = respGroups.GetData()
+			}
+		}
+	}
+	groupIDs := make([]string, len(groups))
+	for i, ddGroup := range groups {
+		groupIDs[i] = ddGroup.GetId()
+	}
+	return groupIDs
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"sort"
+)
+
+// DashboardJSONComputedFields lists the dashboard JSON attributes set by the API and ignored when comparing definitions
+var DashboardJSONComputedFields = []string{"id", "author_handle", "author_name", "created_at", "modified_at", "url"}
+
+// PrepDashboardJSON removes the computed fields from a dashboard JSON definition and normalizes
+// the fields the API may reorder or default, so that definitions can be compared without diffs.
+func PrepDashboardJSON(attrMap map[string]interface{}) map[string]interface{} {
+	// This is an edge case where refresh might be called with an empty definition.
+	if attrMap == nil {
+		return attrMap
+	}
+
+	// Remove computed fields when comparing diffs
+	for _, f := range DashboardJSONComputedFields {
+		delete(attrMap, f)
+	}
+	// Remove every widget id too
+	if widgets, ok := attrMap["widgets"].([]interface{}); ok {
+		deleteWidgetID(widgets)
+	}
+	// 'restricted_roles' takes precedence over 'is_read_only'
+	if _, ok := attrMap["restricted_roles"].([]interface{}); ok {
+		delete(attrMap, "is_read_only")
+	} else {
+		// `is_read_only` defaults to false.
+		// We set it manually to avoid continous diff when not set.
+		if _, ok := attrMap["is_read_only"]; !ok {
+			attrMap["is_read_only"] = false
+		}
+	}
+	// handle `notify_list` order
+	if notifyList, ok := attrMap["notify_list"].([]interface{}); ok {
+		sort.SliceStable(notifyList, func(i, j int) bool {
+			return notifyList[i].(string) < notifyList[j].(string)
+		})
+	}
+
+	return attrMap
+}
+
+func deleteWidgetID(widgets []interface{}) {
+	for _, w := range widgets {
+		if widget, ok := w.(map[string]interface{}); ok {
+			if def, ok := widget["definition"].(map[string]interface{}); ok {
+				if def["type"] == "group" {
+					if group, ok := def["widgets"].([]interface{}); ok {
+						deleteWidgetID(group)
+					}
+				}
+				delete(widget, "id")
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package utils
+
+import "fmt"
+
+// Modes of the order resources
+const (
+	// OrderModeFull enforces the complete order of the items
+	OrderModeFull = "full"
+	// OrderModeRelative only enforces the relative order of the listed items
+	OrderModeRelative = "relative"
+)
+
+// OrderModes lists the valid values of the `mode` attribute of order resources
+var OrderModes = []string{OrderModeFull, OrderModeRelative}
+
+// MergeRelativeOrder returns the current order where the listed items are reordered as in the desired order. Listed
+// items take the positions they currently occupy, so items which aren't listed keep their position.
+func MergeRelativeOrder(current []string, desired []string) ([]string, error) {
+	listed := make(map[string]bool, len(desired))
+	for _, item := range desired {
+		if listed[item] {
+			return nil, fmt.Errorf("%q is listed more than once", item)
+		}
+		listed[item] = true
+	}
+
+	merged := make([]string, len(current))
+	found := 0
+	for i, item := range current {
+		if listed[item] {
+			merged[i] = desired[found]
+			found++
+		} else {
+			merged[i] = item
+		}
+	}
+	if found != len(desired) {
+		existing := make(map[string]bool, len(current))
+		for _, item := range current {
+			existing[item] = true
+		}
+		for _, item := range desired {
+			if !existing[item] {
+				return nil, fmt.Errorf("%q doesn't exist", item)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// FilterRelativeOrder returns the listed items in their current order, ignoring the items which aren't listed
+func FilterRelativeOrder(current []string, listed []string) []string {
+	isListed := make(map[string]bool, len(listed))
+	for _, item := range listed {
+		isListed[item] = true
+	}
+	filtered := make([]string, 0, len(listed))
+	for _, item := range current {
+		if isListed[item] {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeRelativeOrder(t *testing.T) {
+	cases := []struct {
+		current  []string
+		desired  []string
+		expected []string
+		err      string
+	}{
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}, []string{"a", "b", "c"}, ""},
+		{[]string{"a", "x", "b", "y", "c"}, []string{"c", "a"}, []string{"c", "x", "b", "y", "a"}, ""},
+		{[]string{"x", "a", "b", "y"}, []string{"b", "a"}, []string{"x", "b", "a", "y"}, ""},
+		{[]string{"x", "y"}, []string{}, []string{"x", "y"}, ""},
+		{[]string{"a", "b"}, []string{"b", "z"}, nil, `"z" doesn't exist`},
+		{[]string{"a", "b"}, []string{"a", "a"}, nil, `"a" is listed more than once`},
+	}
+	for _, c := range cases {
+		merged, err := MergeRelativeOrder(c.current, c.desired)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("expected error %q merging %v into %v, got %v", c.err, c.desired, c.current, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error merging %v into %v: %s", c.desired, c.current, err)
+		} else if !reflect.DeepEqual(merged, c.expected) {
+			t.Errorf("expected %v merging %v into %v, got %v", c.expected, c.desired, c.current, merged)
+		}
+	}
+}
+
+func TestFilterRelativeOrder(t *testing.T) {
+	filtered := FilterRelativeOrder([]string{"x", "b", "y", "a", "z"}, []string{"a", "b", "c"})
+	if expected := []string{"b", "a"}; !reflect.DeepEqual(filtered, expected) {
+		t.Errorf("expected %v, got %v", expected, filtered)
+	}
+}
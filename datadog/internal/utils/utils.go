@@ -228,6 +228,15 @@ func AccountIDAndServiceIDFromID(id string) (string, string, error) {
 	return result[0], result[1], nil
 }
 
+// RestrictionQueryAndRoleFromID returns the restriction query ID and role ID from a restriction query role ID
+func RestrictionQueryAndRoleFromID(id string) (string, string, error) {
+	result := strings.SplitN(id, ":", 2)
+	if len(result) != 2 {
+		return "", "", fmt.Errorf("error extracting restriction query ID and role ID from id: %s", id)
+	}
+	return result[0], result[1], nil
+}
+
 // ConvertResponseByteToMap converts JSON []byte to map[string]interface{}
 func ConvertResponseByteToMap(b []byte) (map[string]interface{}, error) {
 	convertedMap := make(map[string]interface{})
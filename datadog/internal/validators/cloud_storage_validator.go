@@ -0,0 +1,83 @@
+package validators
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+var (
+	s3BucketNameRegex            = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`)
+	gcsBucketNameRegex           = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*[a-z0-9]$`)
+	azureContainerNameRegex      = regexp.MustCompile(`^[a-z0-9](-?[a-z0-9])*$`)
+	azureStorageAccountNameRegex = regexp.MustCompile(`^[a-z0-9]{3,24}$`)
+)
+
+// ValidateS3BucketName ensures a string follows the AWS S3 bucket naming rules
+func ValidateS3BucketName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	switch {
+	case len(value) < 3 || len(value) > 63:
+		errors = append(errors, fmt.Errorf("%q must be between 3 and 63 characters long, got %q", k, value))
+	case !s3BucketNameRegex.MatchString(value):
+		errors = append(errors, fmt.Errorf("%q can only contain lowercase letters, numbers, dots and hyphens, and must begin and end with a letter or number, got %q", k, value))
+	case strings.Contains(value, ".."):
+		errors = append(errors, fmt.Errorf("%q must not contain two adjacent periods, got %q", k, value))
+	case net.ParseIP(value) != nil:
+		errors = append(errors, fmt.Errorf("%q must not be formatted as an IP address, got %q", k, value))
+	case strings.HasPrefix(value, "xn--") || strings.HasPrefix(value, "sthree-"):
+		errors = append(errors, fmt.Errorf("%q must not start with the reserved prefixes `xn--` and `sthree-`, got %q", k, value))
+	case strings.HasSuffix(value, "-s3alias") || strings.HasSuffix(value, "--ol-s3"):
+		errors = append(errors, fmt.Errorf("%q must not end with the reserved suffixes `-s3alias` and `--ol-s3`, got %q", k, value))
+	}
+	return
+}
+
+// ValidateGCSBucketName ensures a string follows the Google Cloud Storage bucket naming rules
+func ValidateGCSBucketName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	maxLength := 63
+	if strings.Contains(value, ".") {
+		maxLength = 222
+	}
+	switch {
+	case len(value) < 3 || len(value) > maxLength:
+		errors = append(errors, fmt.Errorf("%q must be between 3 and %d characters long, got %q", k, maxLength, value))
+	case !gcsBucketNameRegex.MatchString(value):
+		errors = append(errors, fmt.Errorf("%q can only contain lowercase letters, numbers, dots, hyphens and underscores, and must begin and end with a letter or number, got %q", k, value))
+	case net.ParseIP(value) != nil:
+		errors = append(errors, fmt.Errorf("%q must not be formatted as an IP address, got %q", k, value))
+	case strings.HasPrefix(value, "goog") || strings.Contains(value, "google"):
+		errors = append(errors, fmt.Errorf("%q must not start with `goog` or contain `google`, got %q", k, value))
+	default:
+		for _, component := range strings.Split(value, ".") {
+			if len(component) == 0 || len(component) > 63 {
+				errors = append(errors, fmt.Errorf("%q dot-separated components must be between 1 and 63 characters long, got %q", k, value))
+				break
+			}
+		}
+	}
+	return
+}
+
+// ValidateAzureContainerName ensures a string follows the Azure Blob Storage container naming rules
+func ValidateAzureContainerName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	switch {
+	case len(value) < 3 || len(value) > 63:
+		errors = append(errors, fmt.Errorf("%q must be between 3 and 63 characters long, got %q", k, value))
+	case !azureContainerNameRegex.MatchString(value):
+		errors = append(errors, fmt.Errorf("%q can only contain lowercase letters, numbers and single hyphens, and must begin and end with a letter or number, got %q", k, value))
+	}
+	return
+}
+
+// ValidateAzureStorageAccountName ensures a string follows the Azure storage account naming rules
+func ValidateAzureStorageAccountName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !azureStorageAccountNameRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be between 3 and 24 characters long and can only contain lowercase letters and numbers, got %q", k, value))
+	}
+	return
+}
@@ -0,0 +1,89 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateS3BucketName(t *testing.T) {
+	cases := map[string]bool{
+		"my-bucket":             true,
+		"logs.example.com":      true,
+		"a1b":                   true,
+		"ab":                    false,
+		strings.Repeat("a", 64): false,
+		"My-Bucket":             false,
+		"my_bucket":             false,
+		"-my-bucket":            false,
+		"my-bucket.":            false,
+		"my..bucket":            false,
+		"192.168.5.4":           false,
+		"xn--bucket":            false,
+		"sthree-bucket":         false,
+		"my-bucket-s3alias":     false,
+		"my-bucket--ol-s3":      false,
+	}
+	for name, valid := range cases {
+		if _, errors := ValidateS3BucketName(name, "bucket"); (len(errors) == 0) != valid {
+			t.Errorf("expected %q to be valid: %t, got %v", name, valid, errors)
+		}
+	}
+}
+
+func TestValidateGCSBucketName(t *testing.T) {
+	cases := map[string]bool{
+		"my-bucket":        true,
+		"my_bucket":        true,
+		"logs.example.com": true,
+		strings.Repeat("a", 63) + "." + strings.Repeat("b", 63): true,
+		strings.Repeat("a", 64):                                 false,
+		strings.Repeat("a", 64) + ".com":                        false,
+		"ab":                                                    false,
+		"My-Bucket":                                             false,
+		"_my-bucket":                                            false,
+		"my..bucket":                                            false,
+		"192.168.5.4":                                           false,
+		"goog-bucket":                                           false,
+		"my-google-bucket":                                      false,
+	}
+	for name, valid := range cases {
+		if _, errors := ValidateGCSBucketName(name, "bucket"); (len(errors) == 0) != valid {
+			t.Errorf("expected %q to be valid: %t, got %v", name, valid, errors)
+		}
+	}
+}
+
+func TestValidateAzureContainerName(t *testing.T) {
+	cases := map[string]bool{
+		"my-container":          true,
+		"container1":            true,
+		"ab":                    false,
+		strings.Repeat("a", 64): false,
+		"My-Container":          false,
+		"my--container":         false,
+		"-container":            false,
+		"container-":            false,
+		"my.container":          false,
+	}
+	for name, valid := range cases {
+		if _, errors := ValidateAzureContainerName(name, "container"); (len(errors) == 0) != valid {
+			t.Errorf("expected %q to be valid: %t, got %v", name, valid, errors)
+		}
+	}
+}
+
+func TestValidateAzureStorageAccountName(t *testing.T) {
+	cases := map[string]bool{
+		"storageaccount":        true,
+		"logs2023":              true,
+		"ab":                    false,
+		strings.Repeat("a", 25): false,
+		"storage-account":       false,
+		"StorageAccount":        false,
+	}
+	for name, valid := range cases {
+		if _, errors := ValidateAzureStorageAccountName(name, "storage_account"); (len(errors) == 0) != valid {
+			t.Errorf("expected %q to be valid: %t, got %v", name, valid, errors)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LogsQueryNode is a node of a parsed log search query. Boolean expressions have an operator and children, while
+// search terms have a value and the facet they apply to, which is empty for full-text search terms.
+type LogsQueryNode struct {
+	Operator string
+	Children []*LogsQueryNode
+	Facet    string
+	Value    string
+}
+
+const (
+	LogsQueryOperatorAnd = "AND"
+	LogsQueryOperatorOr  = "OR"
+	LogsQueryOperatorNot = "NOT"
+)
+
+// ParseLogsQuery parses a query following the log search syntax: full-text search terms, `facet:value` and
+// `@attribute:value` filters with wildcards, quoted phrases, value groups, ranges and comparisons, combined with the
+// AND, OR and NOT operators and parentheses. An empty query matches all logs and returns a nil node.
+func ParseLogsQuery(query string) (*LogsQueryNode, error) {
+	p := &logsQueryParser{query: query}
+	node, err := p.parseOr("")
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.query) {
+		return nil, fmt.Errorf("unexpected `%c` at position %d", p.query[p.pos], p.pos)
+	}
+	return node, nil
+}
+
+// ValidateLogsQuery ensures a string is a valid log search query
+func ValidateLogsQuery(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := ParseLogsQuery(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid log search query: %s", k, err))
+	}
+	return
+}
+
+// ValidateLogsRestrictionQuery ensures a string is a restriction query made of `key:value` filters combined with
+// boolean operators, as restriction queries don't support full-text search
+func ValidateLogsRestrictionQuery(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if strings.TrimSpace(value) == "" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", k))
+		return
+	}
+	node, err := ParseLogsQuery(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid log search query: %s", k, err))
+		return
+	}
+	node.walk(func(n *LogsQueryNode) {
+		if n.Operator == "" && n.Facet == "" {
+			errors = append(errors, fmt.Errorf("%q only supports `key:value` filters, got full-text search term %q", k, n.Value))
+		}
+	})
+	return
+}
+
+func (n *LogsQueryNode) walk(f func(*LogsQueryNode)) {
+	f(n)
+	for _, child := range n.Children {
+		child.walk(f)
+	}
+}
+
+type logsQueryParser struct {
+	query string
+	pos   int
+}
+
+func (p *logsQueryParser) skipSpaces() {
+	for p.pos < len(p.query) && isLogsQuerySpace(p.query[p.pos]) {
+		p.pos++
+	}
+}
+
+// peekOperator returns whether the query continues with the given operator, as a separate word
+func (p *logsQueryParser) peekOperator(operator string) bool {
+	if !strings.HasPrefix(p.query[p.pos:], operator) {
+		return false
+	}
+	end := p.pos + len(operator)
+	return end == len(p.query) || isLogsQuerySpace(p.query[end]) || p.query[end] == '('
+}
+
+// parseOr parses operands separated by OR. The facet is set within the value group of a `facet:(...)` filter.
+func (p *logsQueryParser) parseOr(facet string) (*LogsQueryNode, error) {
+	node, err := p.parseAnd(facet)
+	if err != nil || node == nil {
+		return node, err
+	}
+	or := &LogsQueryNode{Operator: LogsQueryOperatorOr, Children: []*LogsQueryNode{node}}
+	for {
+		p.skipSpaces()
+		if !p.peekOperator(LogsQueryOperatorOr) {
+			break
+		}
+		position := p.pos
+		p.pos += len(LogsQueryOperatorOr)
+		operand, err := p.parseAnd(facet)
+		if err != nil {
+			return nil, err
+		}
+		if operand == nil {
+			return nil, fmt.Errorf("missing operand after `OR` at position %d", position)
+		}
+		or.Children = append(or.Children, operand)
+	}
+	if len(or.Children) == 1 {
+		return node, nil
+	}
+	return or, nil
+}
+
+// parseAnd parses operands separated by AND or by spaces
+func (p *logsQueryParser) parseAnd(facet string) (*LogsQueryNode, error) {
+	and := &LogsQueryNode{Operator: LogsQueryOperatorAnd}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.query) || p.query[p.pos] == ')' || p.peekOperator(LogsQueryOperatorOr) {
+			if len(and.Children) == 0 && p.peekOperator(LogsQueryOperatorOr) {
+				return nil, fmt.Errorf("missing operand before `OR` at position %d", p.pos)
+			}
+			break
+		}
+		if p.peekOperator(LogsQueryOperatorAnd) {
+			position := p.pos
+			if len(and.Children) == 0 {
+				return nil, fmt.Errorf("missing operand before `AND` at position %d", position)
+			}
+			p.pos += len(LogsQueryOperatorAnd)
+			operand, err := p.parseUnary(facet)
+			if err != nil {
+				return nil, err
+			}
+			if operand == nil {
+				return nil, fmt.Errorf("missing operand after `AND` at position %d", position)
+			}
+			and.Children = append(and.Children, operand)
+			continue
+		}
+		operand, err := p.parseUnary(facet)
+		if err != nil {
+			return nil, err
+		}
+		and.Children = append(and.Children, operand)
+	}
+	switch len(and.Children) {
+	case 0:
+		return nil, nil
+	case 1:
+		return and.Children[0], nil
+	}
+	return and, nil
+}
+
+// parseUnary parses an operand optionally negated with NOT or `-`
+func (p *logsQueryParser) parseUnary(facet string) (*LogsQueryNode, error) {
+	p.skipSpaces()
+	position := p.pos
+	negated := false
+	if p.peekOperator(LogsQueryOperatorNot) {
+		p.pos += len(LogsQueryOperatorNot)
+		negated = true
+	} else if p.pos+1 < len(p.query) && p.query[p.pos] == '-' && !isLogsQuerySpace(p.query[p.pos+1]) {
+		p.pos++
+		negated = true
+	}
+	if negated {
+		operand, err := p.parseUnary(facet)
+		if err != nil {
+			return nil, err
+		}
+		if operand == nil {
+			return nil, fmt.Errorf("missing operand after negation at position %d", position)
+		}
+		return &LogsQueryNode{Operator: LogsQueryOperatorNot, Children: []*LogsQueryNode{operand}}, nil
+	}
+	return p.parsePrimary(facet)
+}
+
+// parsePrimary parses a parenthesized expression or a search term
+func (p *logsQueryParser) parsePrimary(facet string) (*LogsQueryNode, error) {
+	if p.pos >= len(p.query) || p.query[p.pos] == ')' || p.peekOperator(LogsQueryOperatorAnd) || p.peekOperator(LogsQueryOperatorOr) {
+		return nil, nil
+	}
+	if p.query[p.pos] != '(' {
+		return p.parseTerm(facet)
+	}
+	position := p.pos
+	p.pos++
+	node, err := p.parseGroup(facet, position)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, fmt.Errorf("empty parentheses at position %d", position)
+	}
+	return node, nil
+}
+
+// parseGroup parses the content of parentheses opened at the given position, up to the closing parenthesis
+func (p *logsQueryParser) parseGroup(facet string, position int) (*LogsQueryNode, error) {
+	node, err := p.parseOr(facet)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos >= len(p.query) {
+		return nil, fmt.Errorf("unclosed `(` at position %d", position)
+	}
+	p.pos++
+	return node, nil
+}
+
+// parseTerm parses a full-text search term, or a filter on a facet when the term has a `facet:` prefix
+func (p *logsQueryParser) parseTerm(facet string) (*LogsQueryNode, error) {
+	start := p.pos
+	if facet == "" {
+		name, hasFacet, err := p.readWord(true)
+		if err != nil {
+			return nil, err
+		}
+		if !hasFacet {
+			return &LogsQueryNode{Value: name}, nil
+		}
+		if name == "" || name == "@" {
+			return nil, fmt.Errorf("missing facet name before `:` at position %d", start)
+		}
+		return p.parseFacetValue(name)
+	}
+	value, _, err := p.readWord(false)
+	if err != nil {
+		return nil, err
+	}
+	return &LogsQueryNode{Facet: facet, Value: value}, nil
+}
+
+// parseFacetValue parses the value of a filter on the given facet, right after the `:` separator
+func (p *logsQueryParser) parseFacetValue(facet string) (*LogsQueryNode, error) {
+	position := p.pos
+	// Spaces are allowed between the separator and the value, as in `@severity: "-"`
+	p.skipSpaces()
+	if p.pos >= len(p.query) || p.query[p.pos] == ')' {
+		return nil, fmt.Errorf("missing value for facet %q at position %d", facet, position)
+	}
+	switch p.query[p.pos] {
+	case '(':
+		p.pos++
+		node, err := p.parseGroup(facet, position)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, fmt.Errorf("empty value group for facet %q at position %d", facet, position)
+		}
+		return node, nil
+	case '[', '{':
+		end := strings.IndexAny(p.query[p.pos:], "]}")
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed range for facet %q at position %d", facet, position)
+		}
+		value := p.query[p.pos : p.pos+end+1]
+		p.pos += end + 1
+		bounds := strings.Fields(value[1 : len(value)-1])
+		if len(bounds) != 3 || bounds[1] != "TO" {
+			return nil, fmt.Errorf("invalid range %s for facet %q at position %d, expected `[min TO max]`", value, facet, position)
+		}
+		return &LogsQueryNode{Facet: facet, Value: value}, nil
+	case '<', '>':
+		comparison := p.query[p.pos : p.pos+1]
+		p.pos++
+		if p.pos < len(p.query) && p.query[p.pos] == '=' {
+			comparison += "="
+			p.pos++
+		}
+		value, _, err := p.readWord(false)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			return nil, fmt.Errorf("missing value after `%s` for facet %q at position %d", comparison, facet, position)
+		}
+		return &LogsQueryNode{Facet: facet, Value: comparison + value}, nil
+	}
+	value, _, err := p.readWord(false)
+	if err != nil {
+		return nil, err
+	}
+	return &LogsQueryNode{Facet: facet, Value: value}, nil
+}
+
+// readWord reads a word up to the next space or parenthesis, keeping escaped characters, quoted phrases and the
+// arguments of functions like `CIDR(...)`. When stopAtFacet is true, the word stops after a `facet:` prefix.
+func (p *logsQueryParser) readWord(stopAtFacet bool) (string, bool, error) {
+	start := p.pos
+	for p.pos < len(p.query) {
+		switch c := p.query[p.pos]; {
+		case c == '\\':
+			p.pos += 2
+		case c == '"':
+			end := p.pos + 1
+			for ; end < len(p.query) && p.query[end] != '"'; end++ {
+				if p.query[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(p.query) {
+				return "", false, fmt.Errorf("unterminated quoted phrase at position %d", p.pos)
+			}
+			p.pos = end + 1
+		case c == ':' && stopAtFacet:
+			word := p.query[start:p.pos]
+			p.pos++
+			return word, true, nil
+		case c == '(' && p.pos > start:
+			position := p.pos
+			depth := 0
+			for ; p.pos < len(p.query); p.pos++ {
+				if p.query[p.pos] == '(' {
+					depth++
+				} else if p.query[p.pos] == ')' {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			if p.pos >= len(p.query) {
+				return "", false, fmt.Errorf("unclosed `(` at position %d", position)
+			}
+			p.pos++
+		case c == '(' || c == ')' || isLogsQuerySpace(c):
+			return p.query[start:p.pos], false, nil
+		default:
+			p.pos++
+		}
+	}
+	if p.pos > len(p.query) {
+		p.pos = len(p.query)
+	}
+	return p.query[start:p.pos], false, nil
+}
+
+func isLogsQuerySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
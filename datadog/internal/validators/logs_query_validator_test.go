@@ -0,0 +1,92 @@
+package validators
+
+import (
+	"testing"
+)
+
+func TestValidateLogsRestrictionQuery(t *testing.T) {
+	cases := map[string]bool{
+		"service:payments":                           true,
+		"service:payments env:prod":                  true,
+		"service:(payments OR billing) -env:staging": true,
+		"@http.url:\"/api/v1/pay ments\"":            true,
+		"team:payments AND NOT source:nginx":         true,
+		"host:web\\ 01":                              true,
+		"":                                           false,
+		"   ":                                        false,
+		"payments":                                   false,
+		"service:payments error":                     false,
+		"service:(payments":                          false,
+		"service:payments)":                          false,
+		"service:\"payments":                         false,
+	}
+	for query, valid := range cases {
+		if _, errors := ValidateLogsRestrictionQuery(query, "restriction_query"); (len(errors) == 0) != valid {
+			t.Errorf("expected %q to be valid: %t, got %v", query, valid, errors)
+		}
+	}
+}
+
+func TestParseLogsQuery(t *testing.T) {
+	cases := map[string]string{
+		"":                                       "",
+		"*":                                      "",
+		"service:payments":                       "",
+		"source:nginx status:error":              "",
+		"@http.status_code:[400 TO 499]":         "",
+		"@duration:{100 TO *}":                   "",
+		"@duration:>=100 AND @duration:<1000":    "",
+		"service:(payments OR billing) -env:dev": "",
+		"NOT (env:staging OR env:dev)":           "",
+		"\"connection refused\" host:web-*":      "",
+		"@http.url:\"/api/v1/pay ments\"":        "",
+		"@http.url:https\\://example.com":        "",
+		"host:web\\ 01 OR host:web?02":           "",
+		"CIDR(@network.client.ip,13.0.0.0/8)":    "",
+		"service:payments)":                      "unexpected `)` at position 16",
+		"service:(payments":                      "unclosed `(` at position 8",
+		"(service:payments":                      "unclosed `(` at position 0",
+		"service:payments ()":                    "empty parentheses at position 17",
+		"service:":                               "missing value for facet \"service\" at position 8",
+		"service: ":                              "missing value for facet \"service\" at position 8",
+		"@severity: \"-\"":                       "",
+		":payments":                              "missing facet name before `:` at position 0",
+		"@:payments":                             "missing facet name before `:` at position 0",
+		"service:\"payments":                     "unterminated quoted phrase at position 8",
+		"@http.status_code:[400 499]":            "invalid range [400 499] for facet \"@http.status_code\" at position 18, expected `[min TO max]`",
+		"@http.status_code:[400 TO 499":          "unclosed range for facet \"@http.status_code\" at position 18",
+		"@duration:>":                            "missing value after `>` for facet \"@duration\" at position 10",
+		"service:payments AND":                   "missing operand after `AND` at position 17",
+		"AND service:payments":                   "missing operand before `AND` at position 0",
+		"service:payments OR":                    "missing operand after `OR` at position 17",
+		"OR service:payments":                    "missing operand before `OR` at position 0",
+		"service:payments AND OR env:prod":       "missing operand after `AND` at position 17",
+		"service:payments NOT":                   "missing operand after negation at position 17",
+		"service:()":                             "empty value group for facet \"service\" at position 8",
+	}
+	for query, expected := range cases {
+		_, err := ParseLogsQuery(query)
+		if (err == nil && expected != "") || (err != nil && err.Error() != expected) {
+			t.Errorf("expected error %q when parsing %q, got %v", expected, query, err)
+		}
+	}
+}
+
+func TestParseLogsQueryTree(t *testing.T) {
+	node, err := ParseLogsQuery("service:(payments OR billing) -env:dev error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Operator != LogsQueryOperatorAnd || len(node.Children) != 3 {
+		t.Fatalf("expected an AND of 3 operands, got %+v", node)
+	}
+	if group := node.Children[0]; group.Operator != LogsQueryOperatorOr || group.Children[1].Facet != "service" || group.Children[1].Value != "billing" {
+		t.Errorf("expected the values of the group to apply to the facet, got %+v", group)
+	}
+	if not := node.Children[1]; not.Operator != LogsQueryOperatorNot || not.Children[0].Facet != "env" || not.Children[0].Value != "dev" {
+		t.Errorf("expected a negated filter, got %+v", not)
+	}
+	if text := node.Children[2]; text.Facet != "" || text.Value != "error" {
+		t.Errorf("expected a full-text search term, got %+v", text)
+	}
+}
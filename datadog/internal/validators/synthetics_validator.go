@@ -0,0 +1,96 @@
+package validators
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/antchfx/xpath"
+	"github.com/ohler55/ojg/jp"
+)
+
+// ValidateJSONPath ensures a JSON path expression, as used by synthetics assertions and parsers, compiles
+func ValidateJSONPath(path string) error {
+	if _, err := jp.ParseString(path); err != nil {
+		return fmt.Errorf("invalid JSON path %q: %s", path, err)
+	}
+	return nil
+}
+
+// ValidateXPath ensures an XPath expression, as used by synthetics assertions and parsers, compiles
+func ValidateXPath(path string) error {
+	if _, err := xpath.Compile(path); err != nil {
+		return fmt.Errorf("invalid XPath %q: %s", path, err)
+	}
+	return nil
+}
+
+// SyntheticsTemplateRegexp matches the `{{ VARIABLE }}` templates of synthetics tests, which are only replaced when
+// the test runs. Values holding templates can't be validated at plan time.
+var SyntheticsTemplateRegexp = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+
+// ValidateRegex ensures a regular expression evaluated by Datadog compiles. Such expressions use the
+// JavaScript syntax, so lookarounds and backreferences, which Go doesn't support, are accepted: they are
+// replaced by groups and literals the rest of the expression is checked with.
+func ValidateRegex(pattern string) error {
+	expr := pattern
+	for {
+		_, err := syntax.Parse(expr, syntax.Perl)
+		var syntaxErr *syntax.Error
+		if !errors.As(err, &syntaxErr) {
+			break
+		}
+		// Lookarounds, such as `(?=`, become groups, and backreferences, such as `\1`, become literals. Recent Go
+		// versions report lookbehinds as invalid named captures, along with the rest of the expression.
+		invalid, replacement := syntaxErr.Expr, "x"
+		switch {
+		case syntaxErr.Code == syntax.ErrInvalidNamedCapture && (strings.HasPrefix(invalid, "(?<=") || strings.HasPrefix(invalid, "(?<!")):
+			invalid, replacement = invalid[:4], "("
+		case syntaxErr.Code == syntax.ErrInvalidPerlOp:
+			replacement = "("
+		case syntaxErr.Code != syntax.ErrInvalidEscape:
+			return fmt.Errorf("invalid regular expression %q: %s", pattern, err)
+		}
+		next := strings.Replace(expr, invalid, replacement, 1)
+		if next == expr {
+			break
+		}
+		expr = next
+	}
+	return nil
+}
+
+// ParseClientCertificate parses the first certificate of a PEM encoded chain used by synthetics tests,
+// ensuring that it matches the PEM encoded private key
+func ParseClientCertificate(certPEM, keyPEM string) (*x509.Certificate, error) {
+	pair, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate: %s", err)
+	}
+	return x509.ParseCertificate(pair.Certificate[0])
+}
+
+// ValidateTOTPSecret ensures the secret of a synthetics TOTP global variable is base32 encoded. Authenticator
+// setup pages often display it in lower case, in groups separated by spaces and without padding, which are
+// all accepted.
+func ValidateTOTPSecret(secret string) error {
+	normalized := NormalizeTOTPSecret(secret)
+	if normalized == "" {
+		return fmt.Errorf("TOTP secret must not be empty")
+	}
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalized); err != nil {
+		return fmt.Errorf("TOTP secret must be base32 encoded: %s", err)
+	}
+	return nil
+}
+
+// NormalizeTOTPSecret returns the secret of a synthetics TOTP global variable in upper case, without spaces nor padding
+func NormalizeTOTPSecret(secret string) string {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	return strings.TrimRight(normalized, "=")
+}
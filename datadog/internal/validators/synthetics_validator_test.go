@@ -0,0 +1,141 @@
+package validators
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestValidateJSONPath(t *testing.T) {
+	cases := map[string]bool{
+		"$.items[0].id":               true,
+		"$..name":                     true,
+		"$.items[?(@.price < 10)].id": true,
+		"$['key with spaces']":        true,
+		"$.items[":                    false,
+		"$.items[?(@.price <":         false,
+	}
+	for path, valid := range cases {
+		if err := ValidateJSONPath(path); (err == nil) != valid {
+			t.Errorf("expected %q to be valid: %t, got %v", path, valid, err)
+		}
+	}
+}
+
+func TestValidateXPath(t *testing.T) {
+	cases := map[string]bool{
+		"/user/name":              true,
+		"//item[@id='1']/text()":  true,
+		"count(//item) > 2":       true,
+		"//item[":                 false,
+		"/user/name[position()=]": false,
+	}
+	for path, valid := range cases {
+		if err := ValidateXPath(path); (err == nil) != valid {
+			t.Errorf("expected %q to be valid: %t, got %v", path, valid, err)
+		}
+	}
+}
+
+func TestValidateRegex(t *testing.T) {
+	cases := map[string]bool{
+		`^session-(\d+)$`: true,
+		`foo(?=bar)`:      true,
+		`(a)\1`:           true,
+		`(?<=a)b(?!c)`:    true,
+		`foo(`:            false,
+		`[a-`:             false,
+		`a**`:             false,
+		`(?=a)[b-`:        false,
+		`(a)\1(`:          false,
+		`(?<!a)b**`:       false,
+	}
+	for pattern, valid := range cases {
+		if err := ValidateRegex(pattern); (err == nil) != valid {
+			t.Errorf("expected %q to be valid: %t, got %v", pattern, valid, err)
+		}
+	}
+}
+
+func TestParseClientCertificate(t *testing.T) {
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	certPEM, keyPEM := generateTestCertificate(t, "client.example.com", notAfter)
+	_, otherKeyPEM := generateTestCertificate(t, "other.example.com", notAfter)
+
+	cert, err := ParseClientCertificate(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("expected certificate to be valid, got %v", err)
+	}
+	if cert.Subject.CommonName != "client.example.com" || !cert.NotAfter.Equal(notAfter) {
+		t.Errorf("unexpected certificate %s expiring on %s", cert.Subject, cert.NotAfter)
+	}
+
+	if _, err := ParseClientCertificate(certPEM, otherKeyPEM); err == nil {
+		t.Errorf("expected certificate not to match the key of another certificate")
+	}
+	if _, err := ParseClientCertificate("not a certificate", keyPEM); err == nil {
+		t.Errorf("expected certificate without PEM data to be invalid")
+	}
+}
+
+func generateTestCertificate(t *testing.T, commonName string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestValidateTOTPSecret(t *testing.T) {
+	cases := map[string]bool{
+		"JBSWY3DPEHPK3PXP":         true,
+		"jbsw y3dp ehpk 3pxp":      true,
+		"GEZDGNBVGY3TQOJQ":         true,
+		"GEZDGNBVGY3TQOJQGEZA====": true,
+		"GEZDGNBVGY3TQOJQGEZA":     true,
+		"":                         false,
+		"   ":                      false,
+		"JBSWY3DPEHPK3PX1":         false,
+		"not-a-secret!":            false,
+	}
+	for secret, valid := range cases {
+		if err := ValidateTOTPSecret(secret); (err == nil) != valid {
+			t.Errorf("expected %q to be valid: %t, got %v", secret, valid, err)
+		}
+	}
+}
+
+func TestNormalizeTOTPSecret(t *testing.T) {
+	cases := map[string]string{
+		"JBSWY3DPEHPK3PXP":         "JBSWY3DPEHPK3PXP",
+		"jbsw y3dp ehpk 3pxp":      "JBSWY3DPEHPK3PXP",
+		"GEZDGNBVGY3TQOJQGEZA====": "GEZDGNBVGY3TQOJQGEZA",
+		" gezd\tgnbv ":             "GEZDGNBV",
+	}
+	for secret, expected := range cases {
+		if normalized := NormalizeTOTPSecret(secret); normalized != expected {
+			t.Errorf("expected %q to be normalized to %q, got %q", secret, expected, normalized)
+		}
+	}
+}
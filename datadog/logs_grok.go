@@ -0,0 +1,726 @@
+package datadog
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Regular expressions of the grok matchers which don't take arguments
+var grokMatcherPatterns = map[string]string{
+	"notSpace":           `\S+`,
+	"boolean":            `(?i:true|false)`,
+	"numberStr":          `[+-]?(?:\d+(?:\.\d*)?|\.\d+)`,
+	"number":             `[+-]?(?:\d+(?:\.\d*)?|\.\d+)`,
+	"numberExtStr":       `[+-]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?`,
+	"numberExt":          `[+-]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?`,
+	"integerStr":         `[+-]?\d+`,
+	"integer":            `[+-]?\d+`,
+	"integerExtStr":      `[+-]?\d+(?:[eE][+]?\d+)?`,
+	"integerExt":         `[+-]?\d+(?:[eE][+]?\d+)?`,
+	"word":               `\w+`,
+	"doubleQuotedString": `"(?:[^"\\]|\\.)*"`,
+	"singleQuotedString": `'(?:[^'\\]|\\.)*'`,
+	"quotedString":       `"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`,
+	"uuid":               `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+	"mac":                `(?:[0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}`,
+	"ipv4":               `(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)`,
+	"ipv6":               `[0-9A-Fa-f]{0,4}(?::[0-9A-Fa-f]{0,4}){2,7}`,
+	"ip":                 `(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)|[0-9A-Fa-f]{0,4}(?::[0-9A-Fa-f]{0,4}){2,7}`,
+	"hostname":           `[0-9A-Za-z](?:[0-9A-Za-z-]{0,62})(?:\.[0-9A-Za-z][0-9A-Za-z-]{0,62})*\.?`,
+	"ipOrHost":           `(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)|[0-9A-Fa-f]{0,4}(?::[0-9A-Fa-f]{0,4}){2,7}|[0-9A-Za-z](?:[0-9A-Za-z-]{0,62})(?:\.[0-9A-Za-z][0-9A-Za-z-]{0,62})*\.?`,
+	"port":               `\d{1,5}`,
+	"data":               `(?s:.*?)`,
+}
+
+// Filters which can be applied to a grok match, with their allowed number of arguments
+var grokFilterArguments = map[string][2]int{
+	"number":             {0, 0},
+	"integer":            {0, 0},
+	"boolean":            {0, 1},
+	"nullIf":             {1, 1},
+	"json":               {0, 0},
+	"rubyhash":           {0, 0},
+	"useragent":          {0, 1},
+	"querystring":        {0, 0},
+	"decodeuricomponent": {0, 0},
+	"lowercase":          {0, 0},
+	"uppercase":          {0, 0},
+	"keyvalue":           {0, 4},
+	"scale":              {1, 1},
+	"array":              {0, 3},
+	"url":                {0, 0},
+	"xml":                {0, 0},
+	"csv":                {1, 3},
+}
+
+var grokRuleNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// logsGrokParser holds the compiled match rules of a grok parser processor
+type logsGrokParser struct {
+	rules []grokRule
+}
+
+type grokRule struct {
+	name     string
+	regexp   *regexp.Regexp
+	captures []grokCapture
+}
+
+// grokCapture extracts the text of a capturing group to an attribute
+type grokCapture struct {
+	group     string
+	attribute string
+	matcher   string
+	date      *grokDateFormat
+	filter    string
+	args      []string
+}
+
+type grokDateFormat struct {
+	layout   string
+	location *time.Location
+}
+
+type grokCompiler struct {
+	supportRules map[string]string
+	captures     []grokCapture
+	expanding    map[string]bool
+}
+
+// compileGrokParser compiles the match rules of a grok parser, one `name pattern` per line, which may
+// reference the support rules and the match rules defined before them. Patterns are regular expressions with %{matcher:attribute:filter} tokens.
+func compileGrokParser(matchRules string, supportRules string) (*logsGrokParser, error) {
+	compiler := &grokCompiler{supportRules: make(map[string]string), expanding: make(map[string]bool)}
+	parsedSupportRules, err := parseGrokRules(supportRules)
+	if err != nil {
+		return nil, fmt.Errorf("support rules: %s", err)
+	}
+	for _, rule := range parsedSupportRules {
+		if _, ok := compiler.supportRules[rule[0]]; ok {
+			return nil, fmt.Errorf("support rules: rule %q is defined twice", rule[0])
+		}
+		compiler.supportRules[rule[0]] = rule[1]
+	}
+	parsedMatchRules, err := parseGrokRules(matchRules)
+	if err != nil {
+		return nil, fmt.Errorf("match rules: %s", err)
+	}
+	if len(parsedMatchRules) == 0 {
+		return nil, fmt.Errorf("match rules: at least one rule is required")
+	}
+
+	parser := &logsGrokParser{}
+	names := make(map[string]bool)
+	for _, rule := range parsedMatchRules {
+		if names[rule[0]] {
+			return nil, fmt.Errorf("match rules: rule %q is defined twice", rule[0])
+		}
+		names[rule[0]] = true
+		compiler.captures = nil
+		pattern, err := compiler.expand(rule[1])
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %s", rule[0], err)
+		}
+		// Atomic groups, often used in Datadog rules, aren't supported by RE2 and are treated as plain groups
+		compiled, err := regexp.Compile(`^(?:` + strings.ReplaceAll(pattern, `(?>`, `(?:`) + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: invalid regular expression: %s", rule[0], err)
+		}
+		parser.rules = append(parser.rules, grokRule{name: rule[0], regexp: compiled, captures: compiler.captures})
+		if _, ok := compiler.supportRules[rule[0]]; !ok {
+			compiler.supportRules[rule[0]] = rule[1]
+		}
+	}
+	return parser, nil
+}
+
+// parseGrokRules splits rules into their name and pattern, ignoring empty lines and `#` comments
+func parseGrokRules(rules string) ([][2]string, error) {
+	var parsed [][2]string
+	for i, line := range strings.Split(rules, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) != 2 || strings.TrimSpace(fields[1]) == "" || !grokRuleNameRegexp.MatchString(fields[0]) {
+			return nil, fmt.Errorf("line %d must be formatted as `name pattern`: %q", i+1, line)
+		}
+		parsed = append(parsed, [2]string{fields[0], strings.TrimSpace(fields[1])})
+	}
+	return parsed, nil
+}
+
+// expand converts the grok tokens of a pattern to capturing groups, inlining the support rules
+func (c *grokCompiler) expand(pattern string) (string, error) {
+	var expanded strings.Builder
+	for {
+		start := strings.Index(pattern, "%{")
+		if start < 0 {
+			expanded.WriteString(pattern)
+			return expanded.String(), nil
+		}
+		end := grokTokenEnd(pattern, start+2)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated token %q", pattern[start:])
+		}
+		expanded.WriteString(pattern[:start])
+		token, err := c.expandToken(pattern[start+2 : end])
+		if err != nil {
+			return "", err
+		}
+		expanded.WriteString(token)
+		pattern = pattern[end+1:]
+	}
+}
+
+// grokTokenEnd returns the index of the brace closing a token, skipping quoted arguments
+func grokTokenEnd(pattern string, from int) int {
+	quote := byte(0)
+	for i := from; i < len(pattern); i++ {
+		switch ch := pattern[i]; {
+		case quote != 0 && ch == '\\':
+			i++
+		case quote != 0 && ch == quote:
+			quote = 0
+		case quote != 0:
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *grokCompiler) expandToken(token string) (string, error) {
+	parts := splitGrokToken(token, ':')
+	if len(parts) > 3 {
+		return "", fmt.Errorf("token %%{%s} must be formatted as %%{matcher:attribute:filter}", token)
+	}
+	matcher, matcherArgs, err := parseGrokCall(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("token %%{%s}: %s", token, err)
+	}
+	capture := grokCapture{group: fmt.Sprintf("g%d", len(c.captures)), matcher: matcher}
+	if len(parts) > 1 {
+		capture.attribute = parts[1]
+	}
+	if len(parts) > 2 {
+		capture.filter, capture.args, err = parseGrokCall(parts[2])
+		if err != nil {
+			return "", fmt.Errorf("token %%{%s}: %s", token, err)
+		}
+		arguments, ok := grokFilterArguments[capture.filter]
+		if !ok {
+			return "", fmt.Errorf("token %%{%s}: unknown filter %q", token, capture.filter)
+		}
+		if len(capture.args) < arguments[0] || len(capture.args) > arguments[1] {
+			return "", fmt.Errorf("token %%{%s}: filter %q takes between %d and %d arguments, got %d", token, capture.filter, arguments[0], arguments[1], len(capture.args))
+		}
+	}
+
+	var pattern string
+	switch {
+	case matcher == "date":
+		if len(matcherArgs) < 1 || len(matcherArgs) > 2 {
+			return "", fmt.Errorf("token %%{%s}: matcher date takes a format and an optional timezone", token)
+		}
+		var date grokDateFormat
+		pattern, date.layout, err = convertGrokDateFormat(matcherArgs[0])
+		if err != nil {
+			return "", fmt.Errorf("token %%{%s}: %s", token, err)
+		}
+		date.location = time.UTC
+		if len(matcherArgs) == 2 {
+			if date.location, err = loadGrokTimezone(matcherArgs[1]); err != nil {
+				return "", fmt.Errorf("token %%{%s}: %s", token, err)
+			}
+		}
+		capture.date = &date
+	case matcher == "regex":
+		if len(matcherArgs) != 1 {
+			return "", fmt.Errorf("token %%{%s}: matcher regex takes a pattern", token)
+		}
+		pattern = matcherArgs[0]
+		if _, err := regexp.Compile(pattern); err != nil {
+			return "", fmt.Errorf("token %%{%s}: invalid regular expression: %s", token, err)
+		}
+	case grokMatcherPatterns[matcher] != "":
+		if matcherArgs != nil {
+			return "", fmt.Errorf("token %%{%s}: matcher %s doesn't take arguments", token, matcher)
+		}
+		pattern = grokMatcherPatterns[matcher]
+	default:
+		supportRule, ok := c.supportRules[matcher]
+		if !ok {
+			return "", fmt.Errorf("token %%{%s}: unknown matcher or support rule %q", token, matcher)
+		}
+		if c.expanding[matcher] {
+			return "", fmt.Errorf("support rule %q references itself", matcher)
+		}
+		c.expanding[matcher] = true
+		pattern, err = c.expand(supportRule)
+		delete(c.expanding, matcher)
+		if err != nil {
+			return "", fmt.Errorf("support rule %q: %s", matcher, err)
+		}
+	}
+
+	if capture.attribute == "" && capture.filter == "" {
+		return "(?:" + pattern + ")", nil
+	}
+	c.captures = append(c.captures, capture)
+	return "(?P<" + capture.group + ">" + pattern + ")", nil
+}
+
+// splitGrokToken splits a token on a separator which isn't quoted or within parentheses
+func splitGrokToken(token string, separator byte) []string {
+	var parts []string
+	depth, quote, start := 0, byte(0), 0
+	for i := 0; i < len(token); i++ {
+		switch ch := token[i]; {
+		case quote != 0 && ch == '\\':
+			i++
+		case quote != 0 && ch == quote:
+			quote = 0
+		case quote != 0:
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == separator && depth == 0:
+			parts = append(parts, strings.TrimSpace(token[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(token[start:]))
+}
+
+// parseGrokCall parses `name` or `name(args)`, returning nil arguments when there are no parentheses
+func parseGrokCall(call string) (string, []string, error) {
+	open := strings.Index(call, "(")
+	if open < 0 {
+		if call == "" {
+			return "", nil, fmt.Errorf("missing matcher")
+		}
+		return call, nil, nil
+	}
+	if !strings.HasSuffix(call, ")") {
+		return "", nil, fmt.Errorf("missing closing parenthesis in %q", call)
+	}
+	args := make([]string, 0)
+	if inner := strings.TrimSpace(call[open+1 : len(call)-1]); inner != "" {
+		for _, arg := range splitGrokToken(inner, ',') {
+			if len(arg) >= 2 && (arg[0] == '"' || arg[0] == '\'') && arg[len(arg)-1] == arg[0] {
+				args = append(args, unquoteGrokArgument(arg[1:len(arg)-1], arg[0]))
+			} else {
+				args = append(args, arg)
+			}
+		}
+	}
+	return call[:open], args, nil
+}
+
+// unquoteGrokArgument unescapes backslashes and quotes, other escape sequences being kept for regular expressions
+func unquoteGrokArgument(arg string, quote byte) string {
+	var unquoted strings.Builder
+	for i := 0; i < len(arg); i++ {
+		if arg[i] == '\\' && i+1 < len(arg) && (arg[i+1] == '\\' || arg[i+1] == quote) {
+			i++
+		}
+		unquoted.WriteByte(arg[i])
+	}
+	return unquoted.String()
+}
+
+func loadGrokTimezone(timezone string) (*time.Location, error) {
+	if offset, err := time.Parse("-07:00", timezone); err == nil {
+		return offset.Location(), nil
+	}
+	if offset, err := time.Parse("-0700", timezone); err == nil {
+		return offset.Location(), nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", timezone)
+	}
+	return location, nil
+}
+
+// convertGrokDateFormat converts a Java date format to a regular expression matching it and a Go layout parsing it
+func convertGrokDateFormat(format string) (string, string, error) {
+	var pattern, layout strings.Builder
+	for i := 0; i < len(format); {
+		ch := format[i]
+		if ch == '\'' {
+			end := strings.IndexByte(format[i+1:], '\'')
+			if end < 0 {
+				return "", "", fmt.Errorf("unterminated quote in date format %q", format)
+			}
+			literal := format[i+1 : i+1+end]
+			if literal == "" {
+				literal = "'"
+			}
+			pattern.WriteString(regexp.QuoteMeta(literal))
+			layout.WriteString(literal)
+			i += end + 2
+			continue
+		}
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z') {
+			pattern.WriteString(regexp.QuoteMeta(string(ch)))
+			layout.WriteByte(ch)
+			i++
+			continue
+		}
+		count := 1
+		for i+count < len(format) && format[i+count] == ch {
+			count++
+		}
+		i += count
+		var p, l string
+		switch {
+		case ch == 'y' || ch == 'u':
+			if count == 2 {
+				p, l = `\d{2}`, "06"
+			} else {
+				p, l = `\d{4}`, "2006"
+			}
+		case ch == 'M' && count >= 4:
+			p, l = `[A-Za-z]+`, "January"
+		case ch == 'M' && count == 3:
+			p, l = `[A-Za-z]{3}`, "Jan"
+		case ch == 'M':
+			p, l = grokDateNumber(count), map[bool]string{true: "01", false: "1"}[count == 2]
+		case ch == 'd':
+			p, l = grokDateNumber(count), map[bool]string{true: "02", false: "2"}[count == 2]
+		case ch == 'H' || ch == 'k':
+			p, l = grokDateNumber(count), "15"
+		case ch == 'h' || ch == 'K':
+			p, l = grokDateNumber(count), map[bool]string{true: "03", false: "3"}[count == 2]
+		case ch == 'm':
+			p, l = grokDateNumber(count), map[bool]string{true: "04", false: "4"}[count == 2]
+		case ch == 's':
+			p, l = grokDateNumber(count), map[bool]string{true: "05", false: "5"}[count == 2]
+		case ch == 'S':
+			p, l = fmt.Sprintf(`\d{%d}`, count), strings.Repeat("0", count)
+		case ch == 'a':
+			p, l = `(?:AM|PM|am|pm)`, "PM"
+		case ch == 'E' && count >= 4:
+			p, l = `[A-Za-z]+`, "Monday"
+		case ch == 'E':
+			p, l = `[A-Za-z]{3}`, "Mon"
+		case ch == 'Z' && count == 1:
+			p, l = `(?:Z|[+-]\d{4})`, "Z0700"
+		case ch == 'Z' || ch == 'X' || ch == 'x':
+			p, l = `(?:Z|[+-]\d{2}:?\d{2})`, "Z07:00"
+		case ch == 'z':
+			p, l = `[A-Za-z]+`, "MST"
+		default:
+			return "", "", fmt.Errorf("unsupported pattern letter %q in date format %q", strings.Repeat(string(ch), count), format)
+		}
+		pattern.WriteString(p)
+		layout.WriteString(l)
+	}
+	return pattern.String(), layout.String(), nil
+}
+
+func grokDateNumber(count int) string {
+	if count == 1 {
+		return `\d{1,2}`
+	}
+	return fmt.Sprintf(`\d{%d}`, count)
+}
+
+// parse extracts the attributes of a value with the first matching rule, returning the name of that rule
+func (g *logsGrokParser) parse(value string) (map[string]interface{}, string, bool) {
+	for _, rule := range g.rules {
+		match := rule.regexp.FindStringSubmatchIndex(value)
+		if match == nil {
+			continue
+		}
+		attributes := make(map[string]interface{})
+		for _, capture := range rule.captures {
+			index := rule.regexp.SubexpIndex(capture.group)
+			if match[2*index] < 0 {
+				continue
+			}
+			extracted, ok := capture.convert(value[match[2*index]:match[2*index+1]])
+			if !ok {
+				continue
+			}
+			if capture.attribute == "" {
+				// Filters like json extract their attributes at the root
+				if extractedMap, isMap := extracted.(map[string]interface{}); isMap {
+					for k, v := range extractedMap {
+						setLogsAttribute(attributes, k, v)
+					}
+				}
+				continue
+			}
+			setLogsAttribute(attributes, capture.attribute, extracted)
+		}
+		return attributes, rule.name, true
+	}
+	return nil, "", false
+}
+
+// ruleNames returns the names of the match rules
+func (g *logsGrokParser) ruleNames() []string {
+	names := make([]string, len(g.rules))
+	for i, rule := range g.rules {
+		names[i] = rule.name
+	}
+	return names
+}
+
+func (c *grokCapture) convert(text string) (interface{}, bool) {
+	var value interface{} = text
+	switch c.matcher {
+	case "number", "numberExt":
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			value = number
+		}
+	case "integer", "integerExt":
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			value = float64(int64(number))
+		}
+	case "boolean":
+		value = strings.EqualFold(text, "true")
+	case "date":
+		parsed, err := time.ParseInLocation(c.date.layout, text, c.date.location)
+		if err != nil {
+			return nil, false
+		}
+		if parsed.Year() == 0 {
+			parsed = parsed.AddDate(time.Now().Year(), 0, 0)
+		}
+		value = float64(parsed.UnixMilli())
+	}
+	if c.filter == "" {
+		return value, true
+	}
+	return applyGrokFilter(c.filter, c.args, value)
+}
+
+func applyGrokFilter(filter string, args []string, value interface{}) (interface{}, bool) {
+	text := logsValueToString(value)
+	switch filter {
+	case "number":
+		number, err := strconv.ParseFloat(text, 64)
+		return number, err == nil
+	case "integer":
+		number, err := strconv.ParseFloat(text, 64)
+		return float64(int64(number)), err == nil
+	case "boolean":
+		if len(args) == 1 {
+			return strings.EqualFold(text, args[0]), true
+		}
+		if strings.EqualFold(text, "true") || strings.EqualFold(text, "false") {
+			return strings.EqualFold(text, "true"), true
+		}
+		return nil, false
+	case "nullIf":
+		if text == args[0] {
+			return nil, false
+		}
+		return value, true
+	case "json":
+		var decoded interface{}
+		err := json.Unmarshal([]byte(text), &decoded)
+		return decoded, err == nil
+	case "rubyhash":
+		var decoded interface{}
+		err := json.Unmarshal([]byte(strings.ReplaceAll(text, "=>", ":")), &decoded)
+		return decoded, err == nil
+	case "useragent":
+		return parseLogsUserAgent(text), true
+	case "querystring":
+		return logsQueryString(text), true
+	case "decodeuricomponent":
+		decoded, err := url.QueryUnescape(text)
+		return decoded, err == nil
+	case "lowercase":
+		return strings.ToLower(text), true
+	case "uppercase":
+		return strings.ToUpper(text), true
+	case "scale":
+		factor, err := strconv.ParseFloat(args[0], 64)
+		number, numberErr := strconv.ParseFloat(text, 64)
+		return number * factor, err == nil && numberErr == nil
+	case "url":
+		details, ok := parseLogsURL(text, false)
+		return details, ok
+	case "keyvalue":
+		return parseGrokKeyValues(text, args), true
+	case "xml":
+		return parseGrokXML(text)
+	case "csv":
+		return parseGrokCSV(text, args)
+	case "array":
+		return parseGrokArray(text, args), true
+	}
+	return value, true
+}
+
+// parseGrokKeyValues implements keyvalue([separator[, characterAllowList[, quotingString[, delimiter]]]])
+func parseGrokKeyValues(text string, args []string) map[string]interface{} {
+	separator, allowList := "=", `\w.\-_@`
+	if len(args) > 0 && args[0] != "" {
+		separator = args[0]
+	}
+	if len(args) > 1 && args[1] != "" {
+		allowList += regexp.QuoteMeta(args[1])
+	}
+	pattern := regexp.MustCompile(`([` + allowList + `]+)` + regexp.QuoteMeta(separator) + `("(?:[^"\\]|\\.)*"|'[^']*'|[` + allowList + `]*)`)
+	values := make(map[string]interface{})
+	for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+		value := match[2]
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+			value = value[1 : len(value)-1]
+		}
+		if value != "" {
+			values[match[1]] = value
+		}
+	}
+	return values
+}
+
+// parseGrokXML implements xml, converting elements to objects of their attributes and children. The text of
+// elements without attributes or children is used as their value, and stored in `value` otherwise.
+func parseGrokXML(text string) (interface{}, bool) {
+	decoder := xml.NewDecoder(strings.NewReader(text))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, false
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			element, err := parseGrokXMLElement(decoder, start)
+			if err != nil {
+				return nil, false
+			}
+			return map[string]interface{}{start.Name.Local: element}, true
+		}
+	}
+}
+
+func parseGrokXMLElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	element := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		element[attr.Name.Local] = attr.Value
+	}
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := parseGrokXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			// Repeated elements are grouped in an array
+			switch existing := element[t.Name.Local].(type) {
+			case nil:
+				element[t.Name.Local] = child
+			case []interface{}:
+				element[t.Name.Local] = append(existing, child)
+			default:
+				element[t.Name.Local] = []interface{}{existing, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			if len(element) == 0 {
+				return value, nil
+			}
+			if value != "" {
+				element["value"] = value
+			}
+			return element, nil
+		}
+	}
+}
+
+// parseGrokCSV implements csv(headers[, separator[, quotingcharacter]]), where headers are separated by commas.
+// Numeric values are converted to numbers. Values without a header are dropped, as well as headers without a value.
+func parseGrokCSV(text string, args []string) (map[string]interface{}, bool) {
+	separator, quote := ",", `"`
+	if len(args) > 1 && args[1] != "" {
+		separator = args[1]
+	}
+	if len(args) > 2 && args[2] != "" {
+		quote = args[2]
+	}
+	var values []string
+	var value strings.Builder
+	quoted := false
+	for i := 0; i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], quote) && quoted && strings.HasPrefix(text[i+len(quote):], quote):
+			// A doubled quoting character is a literal one
+			value.WriteString(quote)
+			i += 2*len(quote) - 1
+		case strings.HasPrefix(text[i:], quote):
+			quoted = !quoted
+			i += len(quote) - 1
+		case !quoted && strings.HasPrefix(text[i:], separator):
+			values = append(values, value.String())
+			value.Reset()
+			i += len(separator) - 1
+		default:
+			value.WriteByte(text[i])
+		}
+	}
+	if quoted {
+		return nil, false
+	}
+	values = append(values, value.String())
+
+	parsed := make(map[string]interface{})
+	for i, header := range strings.Split(args[0], ",") {
+		if i >= len(values) || values[i] == "" {
+			continue
+		}
+		if number, err := strconv.ParseFloat(values[i], 64); err == nil {
+			parsed[strings.TrimSpace(header)] = number
+		} else {
+			parsed[strings.TrimSpace(header)] = values[i]
+		}
+	}
+	return parsed, true
+}
+
+// parseGrokArray implements array([[openCloseStr, ] separator])
+func parseGrokArray(text string, args []string) []interface{} {
+	brackets, separator := "[]", ","
+	switch {
+	case len(args) == 1:
+		separator = args[0]
+	case len(args) >= 2:
+		brackets, separator = args[0], args[1]
+	}
+	if len(brackets) == 2 {
+		text = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(text), brackets[:1]), brackets[1:])
+	}
+	values := make([]interface{}, 0)
+	if strings.TrimSpace(text) == "" {
+		return values
+	}
+	for _, item := range strings.Split(text, separator) {
+		values = append(values, strings.TrimSpace(item))
+	}
+	return values
+}
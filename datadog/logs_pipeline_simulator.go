@@ -0,0 +1,961 @@
+package datadog
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+)
+
+// Layouts tried by the date remapper on string dates, after ISO8601
+var logsDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000Z0700",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.000",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05.000",
+	"2006-01-02 15:04:05",
+	"02/Jan/2006:15:04:05 -0700",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	time.StampMilli,
+	time.Stamp,
+}
+
+// logsPipelineSimulator runs log events through the processors of a pipeline locally
+type logsPipelineSimulator struct {
+	filter     logsSearchQuery
+	enabled    bool
+	processors []func(event map[string]interface{})
+	warnings   []string
+}
+
+// newLogsPipelineSimulator compiles the filters, grok rules and expressions of a pipeline. Processors
+// depending on data which is only available in Datadog, like reference tables, are skipped with a warning.
+func newLogsPipelineSimulator(pipeline *datadogV1.LogsPipelineProcessor) (*logsPipelineSimulator, error) {
+	filter := pipeline.GetFilter()
+	query, err := compileLogsSearchQuery(filter.GetQuery())
+	if err != nil {
+		return nil, fmt.Errorf("filter: %s", err)
+	}
+	simulator := &logsPipelineSimulator{filter: query, enabled: pipeline.GetIsEnabled()}
+	for i, processor := range pipeline.GetProcessors() {
+		process, warning, err := compileLogsProcessor(processor)
+		if err != nil {
+			return nil, fmt.Errorf("processor.%d: %s", i, err)
+		}
+		if warning != "" {
+			simulator.warnings = append(simulator.warnings, fmt.Sprintf("processor.%d: %s", i, warning))
+		}
+		if process != nil {
+			simulator.processors = append(simulator.processors, process)
+		}
+	}
+	return simulator, nil
+}
+
+// process returns a transformed copy of an event, and whether the pipeline filter matched it
+func (s *logsPipelineSimulator) process(event map[string]interface{}) (map[string]interface{}, bool) {
+	processed := copyLogsValue(event).(map[string]interface{})
+	if !s.enabled || !s.filter.matches(processed) {
+		return processed, false
+	}
+	for _, process := range s.processors {
+		process(processed)
+	}
+	return processed, true
+}
+
+// parseLogsSimulationEvent reads a sample event: a JSON object, or a raw line used as message
+func parseLogsSimulationEvent(raw string) map[string]interface{} {
+	var event map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &event); err == nil && event != nil {
+		return event
+	}
+	return map[string]interface{}{"message": raw}
+}
+
+func compileLogsProcessor(processor datadogV1.LogsProcessor) (func(event map[string]interface{}), string, error) {
+	switch {
+	case processor.LogsGrokParser != nil:
+		p := processor.LogsGrokParser
+		if !p.GetIsEnabled() {
+			return nil, "", nil
+		}
+		grok, err := compileGrokParser(p.Grok.GetMatchRules(), p.Grok.GetSupportRules())
+		if err != nil {
+			return nil, "", err
+		}
+		return func(event map[string]interface{}) {
+			source, ok := getLogsAttribute(event, p.GetSource()).(string)
+			if !ok {
+				return
+			}
+			if attributes, _, matched := grok.parse(source); matched {
+				for key, value := range attributes {
+					setLogsAttribute(event, key, value)
+				}
+			}
+		}, "", nil
+	case processor.LogsDateRemapper != nil:
+		p := processor.LogsDateRemapper
+		return logsSourceRemapper(p.GetIsEnabled(), p.GetSources(), func(event map[string]interface{}, value interface{}) {
+			if date, ok := parseLogsDate(value); ok {
+				event["date"] = date.UTC().Format("2006-01-02T15:04:05.000Z")
+			}
+		}), "", nil
+	case processor.LogsStatusRemapper != nil:
+		p := processor.LogsStatusRemapper
+		return logsSourceRemapper(p.GetIsEnabled(), p.GetSources(), func(event map[string]interface{}, value interface{}) {
+			event["status"] = normalizeLogsStatus(value)
+		}), "", nil
+	case processor.LogsServiceRemapper != nil:
+		p := processor.LogsServiceRemapper
+		return logsSourceRemapper(p.GetIsEnabled(), p.GetSources(), func(event map[string]interface{}, value interface{}) {
+			event["service"] = logsValueToString(value)
+		}), "", nil
+	case processor.LogsMessageRemapper != nil:
+		p := processor.LogsMessageRemapper
+		return logsSourceRemapper(p.GetIsEnabled(), p.GetSources(), func(event map[string]interface{}, value interface{}) {
+			event["message"] = logsValueToString(value)
+		}), "", nil
+	case processor.LogsTraceRemapper != nil:
+		p := processor.LogsTraceRemapper
+		return logsSourceRemapper(p.GetIsEnabled(), p.GetSources(), func(event map[string]interface{}, value interface{}) {
+			event["trace_id"] = logsValueToString(value)
+		}), "", nil
+	case processor.LogsAttributeRemapper != nil:
+		p := processor.LogsAttributeRemapper
+		if !p.GetIsEnabled() {
+			return nil, "", nil
+		}
+		return func(event map[string]interface{}) { remapLogsAttribute(p, event) }, "", nil
+	case processor.LogsCategoryProcessor != nil:
+		p := processor.LogsCategoryProcessor
+		if !p.GetIsEnabled() {
+			return nil, "", nil
+		}
+		queries := make([]logsSearchQuery, len(p.GetCategories()))
+		for i, category := range p.GetCategories() {
+			filter := category.GetFilter()
+			query, err := compileLogsSearchQuery(filter.GetQuery())
+			if err != nil {
+				return nil, "", fmt.Errorf("category %q: %s", category.GetName(), err)
+			}
+			queries[i] = query
+		}
+		return func(event map[string]interface{}) {
+			for i, query := range queries {
+				if query.matches(event) {
+					setLogsAttribute(event, p.GetTarget(), p.Categories[i].GetName())
+					return
+				}
+			}
+		}, "", nil
+	case processor.LogsArithmeticProcessor != nil:
+		p := processor.LogsArithmeticProcessor
+		if !p.GetIsEnabled() {
+			return nil, "", nil
+		}
+		expression, err := parseLogsArithmeticExpression(p.GetExpression())
+		if err != nil {
+			return nil, "", err
+		}
+		return func(event map[string]interface{}) {
+			if result, ok := expression.evaluate(event, p.GetIsReplaceMissing()); ok && !math.IsNaN(result) && !math.IsInf(result, 0) {
+				setLogsAttribute(event, p.GetTarget(), result)
+			}
+		}, "", nil
+	case processor.LogsStringBuilderProcessor != nil:
+		p := processor.LogsStringBuilderProcessor
+		if !p.GetIsEnabled() {
+			return nil, "", nil
+		}
+		return func(event map[string]interface{}) {
+			if result, ok := buildLogsString(p.GetTemplate(), event, p.GetIsReplaceMissing()); ok {
+				setLogsAttribute(event, p.GetTarget(), result)
+			}
+		}, "", nil
+	case processor.LogsURLParser != nil:
+		p := processor.LogsURLParser
+		return logsSourceRemapper(p.GetIsEnabled(), p.GetSources(), func(event map[string]interface{}, value interface{}) {
+			if details, ok := parseLogsURL(logsValueToString(value), p.GetNormalizeEndingSlashes()); ok {
+				setLogsAttribute(event, p.GetTarget(), details)
+			}
+		}), "", nil
+	case processor.LogsUserAgentParser != nil:
+		p := processor.LogsUserAgentParser
+		return logsSourceRemapper(p.GetIsEnabled(), p.GetSources(), func(event map[string]interface{}, value interface{}) {
+			userAgent := logsValueToString(value)
+			if p.GetIsEncoded() {
+				if decoded, err := url.QueryUnescape(userAgent); err == nil {
+					userAgent = decoded
+				}
+			}
+			setLogsAttribute(event, p.GetTarget(), parseLogsUserAgent(userAgent))
+		}), "", nil
+	case processor.LogsLookupProcessor != nil:
+		p := processor.LogsLookupProcessor
+		if !p.GetIsEnabled() {
+			return nil, "", nil
+		}
+		table := make(map[string]string)
+		for _, entry := range p.GetLookupTable() {
+			fields, err := csv.NewReader(strings.NewReader(entry)).Read()
+			if err != nil || len(fields) != 2 {
+				return nil, "", fmt.Errorf("lookup table entry %q must be formatted as `key,value`", entry)
+			}
+			table[fields[0]] = fields[1]
+		}
+		return func(event map[string]interface{}) {
+			source := getLogsAttribute(event, p.GetSource())
+			if source == nil {
+				return
+			}
+			if value, ok := table[logsValueToString(source)]; ok {
+				setLogsAttribute(event, p.GetTarget(), value)
+			} else if p.DefaultLookup != nil {
+				setLogsAttribute(event, p.GetTarget(), p.GetDefaultLookup())
+			}
+		}, "", nil
+	case processor.LogsPipelineProcessor != nil:
+		nested, err := newLogsPipelineSimulator(processor.LogsPipelineProcessor)
+		if err != nil {
+			return nil, "", err
+		}
+		var warning string
+		if len(nested.warnings) > 0 {
+			warning = strings.Join(nested.warnings, ", ")
+		}
+		return func(event map[string]interface{}) {
+			if processed, matched := nested.process(event); matched {
+				for key := range event {
+					delete(event, key)
+				}
+				for key, value := range processed {
+					event[key] = value
+				}
+			}
+		}, warning, nil
+	case processor.LogsGeoIPParser != nil:
+		return nil, "geo_ip_parser isn't simulated, it needs Datadog's GeoIP database", nil
+	case processor.ReferenceTableLogsLookupProcessor != nil:
+		return nil, "reference_table_lookup_processor isn't simulated, it needs the content of the reference table", nil
+	case processor.UnparsedObject != nil:
+		raw, _ := processor.UnparsedObject.(map[string]interface{})
+		return compileLogsRawProcessor(raw)
+	}
+	return nil, "", fmt.Errorf("unknown processor")
+}
+
+// compileLogsRawProcessor compiles the processors which aren't modeled by the API client
+func compileLogsRawProcessor(raw map[string]interface{}) (func(event map[string]interface{}), string, error) {
+	processorType, _ := raw["type"].(string)
+	if enabled, _ := raw["is_enabled"].(bool); !enabled {
+		return nil, "", nil
+	}
+	source, _ := raw["source"].(string)
+	target, _ := raw["target"].(string)
+	switch processorType {
+	case ddDecoderProcessor:
+		encoding, _ := raw["binary_to_text_encoding"].(string)
+		representation, _ := raw["input_representation"].(string)
+		return func(event map[string]interface{}) {
+			encoded, ok := getLogsAttribute(event, source).(string)
+			if !ok {
+				return
+			}
+			var decoded []byte
+			var err error
+			if encoding == "base16" {
+				decoded, err = hex.DecodeString(encoded)
+			} else {
+				decoded, err = base64.StdEncoding.DecodeString(encoded)
+			}
+			if err != nil {
+				return
+			}
+			if representation == "integer" {
+				var value uint64
+				for _, b := range decoded {
+					value = value<<8 | uint64(b)
+				}
+				setLogsAttribute(event, target, float64(value))
+				return
+			}
+			setLogsAttribute(event, target, string(decoded))
+		}, "", nil
+	case ddArrayProcessor:
+		operation, _ := raw["operation"].(map[string]interface{})
+		operationType, _ := operation["type"].(string)
+		source, _ = operation["source"].(string)
+		target, _ = operation["target"].(string)
+		switch operationType {
+		case "length":
+			return func(event map[string]interface{}) {
+				if values, ok := getLogsAttribute(event, source).([]interface{}); ok {
+					setLogsAttribute(event, target, float64(len(values)))
+				}
+			}, "", nil
+		case "append":
+			preserveSource, ok := operation["preserve_source"].(bool)
+			preserveSource = preserveSource || !ok
+			return func(event map[string]interface{}) {
+				value := getLogsAttribute(event, source)
+				if value == nil {
+					return
+				}
+				var values []interface{}
+				switch existing := getLogsAttribute(event, target).(type) {
+				case []interface{}:
+					values = append(values, existing...)
+				case nil:
+				default:
+					values = append(values, existing)
+				}
+				setLogsAttribute(event, target, append(values, copyLogsValue(value)))
+				if !preserveSource {
+					deleteLogsAttribute(event, source)
+				}
+			}, "", nil
+		case "select":
+			filter, _ := operation["filter"].(string)
+			query, err := compileLogsSearchQuery(filter)
+			if err != nil {
+				return nil, "", fmt.Errorf("filter: %s", err)
+			}
+			valueToExtract, _ := operation["value_to_extract"].(string)
+			return func(event map[string]interface{}) {
+				values, _ := getLogsAttribute(event, source).([]interface{})
+				for _, value := range values {
+					if element, ok := value.(map[string]interface{}); ok && query.matches(logsArrayElementEvent(element)) {
+						if extracted := getLogsAttribute(element, valueToExtract); extracted != nil {
+							setLogsAttribute(event, target, copyLogsValue(extracted))
+						}
+						return
+					}
+				}
+			}, "", nil
+		}
+		return nil, "", fmt.Errorf("unknown array operation %q", operationType)
+	}
+	return nil, fmt.Sprintf("processor type %q isn't simulated", processorType), nil
+}
+
+// logsArrayElementEvent returns an event which fields of the element can be searched for without the `@` prefix, as
+// in the filter of the array processor
+func logsArrayElementEvent(element map[string]interface{}) map[string]interface{} {
+	event := make(map[string]interface{}, len(element)+1)
+	var tags []interface{}
+	for key, value := range element {
+		event[key] = value
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+		default:
+			tags = append(tags, key+":"+logsValueToString(value))
+		}
+	}
+	event["tags"] = tags
+	return event
+}
+
+// logsSourceRemapper applies a remapping to the first source attribute set on the event
+func logsSourceRemapper(enabled bool, sources []string, remap func(event map[string]interface{}, value interface{})) func(event map[string]interface{}) {
+	if !enabled {
+		return nil
+	}
+	return func(event map[string]interface{}) {
+		for _, source := range sources {
+			if value := getLogsAttribute(event, source); value != nil {
+				remap(event, value)
+				return
+			}
+		}
+	}
+}
+
+func remapLogsAttribute(p *datadogV1.LogsAttributeRemapper, event map[string]interface{}) {
+	fromTags := p.GetSourceType() == "tag"
+	toTags := p.GetTargetType() == "tag"
+	var value interface{}
+	var source string
+	for _, candidate := range p.GetSources() {
+		if fromTags {
+			if tagValue, ok := getLogsTag(event, candidate); ok {
+				value, source = tagValue, candidate
+				break
+			}
+		} else if candidateValue := getLogsAttribute(event, candidate); candidateValue != nil {
+			value, source = candidateValue, candidate
+			break
+		}
+	}
+	if source == "" {
+		return
+	}
+
+	if toTags {
+		if _, exists := getLogsTag(event, p.GetTarget()); exists && !p.GetOverrideOnConflict() {
+			return
+		}
+		removeLogsTag(event, p.GetTarget())
+		addLogsTag(event, p.GetTarget()+":"+logsValueToString(value))
+	} else {
+		if getLogsAttribute(event, p.GetTarget()) != nil && !p.GetOverrideOnConflict() {
+			return
+		}
+		setLogsAttribute(event, p.GetTarget(), castLogsValue(value, string(p.GetTargetFormat())))
+	}
+
+	if !p.GetPreserveSource() && !(fromTags == toTags && source == p.GetTarget()) {
+		if fromTags {
+			removeLogsTag(event, source)
+		} else {
+			deleteLogsAttribute(event, source)
+		}
+	}
+}
+
+func castLogsValue(value interface{}, format string) interface{} {
+	switch format {
+	case "string":
+		return logsValueToString(value)
+	case "integer":
+		if number, ok := logsValueToNumber(value); ok {
+			return float64(int64(number))
+		}
+	case "double":
+		if number, ok := logsValueToNumber(value); ok {
+			return number
+		}
+	}
+	return value
+}
+
+// normalizeLogsStatus maps a value to a log status, following the rules of the status remapper
+func normalizeLogsStatus(value interface{}) string {
+	syslogSeverities := []string{"emerg", "alert", "critical", "error", "warn", "notice", "info", "debug"}
+	if number, ok := value.(float64); ok {
+		if number >= 0 && number < 8 && number == math.Trunc(number) {
+			return syslogSeverities[int(number)]
+		}
+		return "info"
+	}
+	status := strings.ToLower(logsValueToString(value))
+	if number, err := strconv.Atoi(status); err == nil && number >= 0 && number < 8 {
+		return syslogSeverities[number]
+	}
+	switch {
+	case strings.HasPrefix(status, "emerg") || strings.HasPrefix(status, "f"):
+		return "emerg"
+	case strings.HasPrefix(status, "a"):
+		return "alert"
+	case strings.HasPrefix(status, "c"):
+		return "critical"
+	case strings.HasPrefix(status, "err"):
+		return "error"
+	case strings.HasPrefix(status, "w"):
+		return "warn"
+	case strings.HasPrefix(status, "n"):
+		return "notice"
+	case strings.HasPrefix(status, "i"):
+		return "info"
+	case strings.HasPrefix(status, "d") || strings.HasPrefix(status, "trace") || strings.HasPrefix(status, "verbose"):
+		return "debug"
+	case strings.HasPrefix(status, "o") || strings.HasPrefix(status, "s"):
+		return "ok"
+	}
+	return "info"
+}
+
+// parseLogsDate reads epoch timestamps, in seconds or milliseconds, and the common string date formats
+func parseLogsDate(value interface{}) (time.Time, bool) {
+	if number, ok := logsValueToNumber(value); ok {
+		if math.Abs(number) < 1e11 {
+			number *= 1000
+		}
+		return time.UnixMilli(int64(number)), true
+	}
+	text := strings.TrimSpace(logsValueToString(value))
+	for _, layout := range logsDateLayouts {
+		if date, err := time.Parse(layout, text); err == nil {
+			if date.Year() == 0 {
+				date = date.AddDate(time.Now().Year(), 0, 0)
+			}
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+var logsStringBuilderRegexp = regexp.MustCompile(`%\{([^}]+)\}`)
+
+// buildLogsString replaces the %{attribute} references of a template, joining arrays with commas
+func buildLogsString(template string, event map[string]interface{}, replaceMissing bool) (string, bool) {
+	complete := true
+	result := logsStringBuilderRegexp.ReplaceAllStringFunc(template, func(reference string) string {
+		value := getLogsAttribute(event, strings.TrimSpace(reference[2:len(reference)-1]))
+		switch v := value.(type) {
+		case nil, map[string]interface{}:
+			complete = false
+			return ""
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, logsValueToString(item))
+			}
+			return strings.Join(items, ",")
+		}
+		return logsValueToString(value)
+	})
+	return result, complete || replaceMissing
+}
+
+// parseLogsURL returns the details extracted by the url parser
+func parseLogsURL(rawURL string, normalizeEndingSlashes bool) (map[string]interface{}, bool) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, false
+	}
+	path := parsed.Path
+	if normalizeEndingSlashes && len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	details := map[string]interface{}{
+		"scheme": parsed.Scheme,
+		"host":   parsed.Hostname(),
+		"path":   path,
+	}
+	if port, err := strconv.Atoi(parsed.Port()); err == nil {
+		details["port"] = float64(port)
+	} else if parsed.Scheme == "http" {
+		details["port"] = float64(80)
+	} else if parsed.Scheme == "https" {
+		details["port"] = float64(443)
+	}
+	if parsed.RawQuery != "" {
+		details["queryString"] = logsQueryString(parsed.RawQuery)
+	}
+	return details, true
+}
+
+func logsQueryString(query string) map[string]interface{} {
+	values, _ := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	parameters := make(map[string]interface{})
+	for key, value := range values {
+		if len(value) == 1 {
+			parameters[key] = value[0]
+		} else {
+			items := make([]interface{}, len(value))
+			for i, item := range value {
+				items[i] = item
+			}
+			parameters[key] = items
+		}
+	}
+	return parameters
+}
+
+var (
+	logsUserAgentBrowsers = []struct {
+		family string
+		re     *regexp.Regexp
+	}{
+		{"Googlebot", regexp.MustCompile(`Googlebot/(\d+)(?:\.(\d+))?`)},
+		{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Opera", regexp.MustCompile(`OPR/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Chrome Mobile iOS", regexp.MustCompile(`CriOS/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Chrome Mobile", regexp.MustCompile(`Chrome/(\d+)(?:\.(\d+))?(?:\.(\d+))?.*Mobile`)},
+		{"Chrome", regexp.MustCompile(`Chrome/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Firefox", regexp.MustCompile(`Firefox/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Mobile Safari", regexp.MustCompile(`Version/(\d+)(?:\.(\d+))?(?:\.(\d+))?.*Mobile.*Safari`)},
+		{"Safari", regexp.MustCompile(`Version/(\d+)(?:\.(\d+))?(?:\.(\d+))?.*Safari`)},
+		{"curl", regexp.MustCompile(`^curl/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	}
+	logsUserAgentSystems = []struct {
+		family string
+		re     *regexp.Regexp
+	}{
+		{"iOS", regexp.MustCompile(`(?:iPhone|CPU) OS (\d+)(?:_(\d+))?(?:_(\d+))?`)},
+		{"Android", regexp.MustCompile(`Android (\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Windows", regexp.MustCompile(`Windows NT (\d+)(?:\.(\d+))?`)},
+		{"Mac OS X", regexp.MustCompile(`Mac OS X (\d+)(?:[_.](\d+))?(?:[_.](\d+))?`)},
+		{"Chrome OS", regexp.MustCompile(`CrOS \S+ (\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Linux", regexp.MustCompile(`Linux()`)},
+	}
+)
+
+// parseLogsUserAgent extracts the browser, OS and device of the most common user agents
+func parseLogsUserAgent(userAgent string) map[string]interface{} {
+	version := func(family string, match []string) map[string]interface{} {
+		details := map[string]interface{}{"family": family}
+		for i, key := range []string{"major", "minor", "patch"} {
+			if i+1 < len(match) && match[i+1] != "" {
+				details[key] = match[i+1]
+			}
+		}
+		return details
+	}
+
+	browser := map[string]interface{}{"family": "Other"}
+	for _, candidate := range logsUserAgentBrowsers {
+		if match := candidate.re.FindStringSubmatch(userAgent); match != nil {
+			browser = version(candidate.family, match)
+			break
+		}
+	}
+	os := map[string]interface{}{"family": "Other"}
+	for _, candidate := range logsUserAgentSystems {
+		if match := candidate.re.FindStringSubmatch(userAgent); match != nil {
+			os = version(candidate.family, match)
+			break
+		}
+	}
+	if os["family"] == "Windows" {
+		// Windows NT 10.0 is Windows 10, older versions keep their NT version
+		if os["major"] == "10" {
+			delete(os, "minor")
+		}
+	}
+
+	device := map[string]interface{}{"family": "Other", "category": "Desktop"}
+	switch {
+	case browser["family"] == "Googlebot" || strings.Contains(strings.ToLower(userAgent), "bot"):
+		device = map[string]interface{}{"family": "Spider", "category": "Bot"}
+	case strings.Contains(userAgent, "iPad"):
+		device = map[string]interface{}{"family": "iPad", "category": "Tablet"}
+	case strings.Contains(userAgent, "iPhone"):
+		device = map[string]interface{}{"family": "iPhone", "category": "Mobile"}
+	case os["family"] == "Android" && strings.Contains(userAgent, "Mobile"):
+		device = map[string]interface{}{"family": "Generic Smartphone", "category": "Mobile"}
+	case os["family"] == "Android":
+		device = map[string]interface{}{"family": "Generic Tablet", "category": "Tablet"}
+	case browser["family"] == "curl":
+		device = map[string]interface{}{"family": "Other", "category": "Other"}
+	}
+	return map[string]interface{}{"browser": browser, "os": os, "device": device}
+}
+
+// logsArithmeticExpression is a node of an arithmetic processor expression
+type logsArithmeticExpression struct {
+	number    float64
+	attribute string
+	operator  byte
+	function  string
+	operands  []*logsArithmeticExpression
+}
+
+var logsArithmeticFunctions = map[string]func(float64) float64{
+	"abs":   math.Abs,
+	"ceil":  math.Ceil,
+	"floor": math.Floor,
+	"round": math.Round,
+}
+
+func (e *logsArithmeticExpression) evaluate(event map[string]interface{}, replaceMissing bool) (float64, bool) {
+	switch {
+	case e.attribute != "":
+		if number, ok := logsValueToNumber(getLogsAttribute(event, e.attribute)); ok {
+			return number, true
+		}
+		return 0, replaceMissing
+	case e.function != "":
+		operand, ok := e.operands[0].evaluate(event, replaceMissing)
+		return logsArithmeticFunctions[e.function](operand), ok
+	case e.operator != 0:
+		left, ok := e.operands[0].evaluate(event, replaceMissing)
+		if !ok {
+			return 0, false
+		}
+		if e.operator == '~' {
+			return -left, true
+		}
+		right, ok := e.operands[1].evaluate(event, replaceMissing)
+		if !ok {
+			return 0, false
+		}
+		switch e.operator {
+		case '+':
+			return left + right, true
+		case '-':
+			return left - right, true
+		case '*':
+			return left * right, true
+		case '/':
+			return left / right, true
+		}
+	}
+	return e.number, true
+}
+
+// parseLogsArithmeticExpression parses numbers, attributes, + - * /, parentheses and abs, ceil, floor and round
+func parseLogsArithmeticExpression(expression string) (*logsArithmeticExpression, error) {
+	p := &logsArithmeticParser{input: expression}
+	parsed, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at position %d of expression %q", p.input[p.pos:], p.pos, expression)
+	}
+	return parsed, nil
+}
+
+type logsArithmeticParser struct {
+	input string
+	pos   int
+}
+
+var logsArithmeticOperandRegexp = regexp.MustCompile(`^(?:\d+(?:\.\d*)?(?:[eE][+-]?\d+)?|\.\d+|@?[A-Za-z_][\w.@-]*)`)
+
+func (p *logsArithmeticParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *logsArithmeticParser) parseSum() (*logsArithmeticExpression, error) {
+	return p.parseBinary("+-", p.parseProduct)
+}
+
+func (p *logsArithmeticParser) parseProduct() (*logsArithmeticExpression, error) {
+	return p.parseBinary("*/", p.parseUnary)
+}
+
+func (p *logsArithmeticParser) parseBinary(operators string, parseOperand func() (*logsArithmeticExpression, error)) (*logsArithmeticExpression, error) {
+	left, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || !strings.ContainsRune(operators, rune(p.input[p.pos])) {
+			return left, nil
+		}
+		operator := p.input[p.pos]
+		p.pos++
+		right, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		left = &logsArithmeticExpression{operator: operator, operands: []*logsArithmeticExpression{left, right}}
+	}
+}
+
+func (p *logsArithmeticParser) parseUnary() (*logsArithmeticExpression, error) {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == '-' {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &logsArithmeticExpression{operator: '~', operands: []*logsArithmeticExpression{operand}}, nil
+	}
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		return p.parseParenthesized()
+	}
+	token := logsArithmeticOperandRegexp.FindString(p.input[p.pos:])
+	if token == "" {
+		return nil, fmt.Errorf("expected a number or an attribute at position %d of expression %q", p.pos, p.input)
+	}
+	p.pos += len(token)
+	if number, err := strconv.ParseFloat(token, 64); err == nil {
+		return &logsArithmeticExpression{number: number}, nil
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		if _, ok := logsArithmeticFunctions[token]; !ok {
+			return nil, fmt.Errorf("unknown function %q in expression %q", token, p.input)
+		}
+		operand, err := p.parseParenthesized()
+		if err != nil {
+			return nil, err
+		}
+		return &logsArithmeticExpression{function: token, operands: []*logsArithmeticExpression{operand}}, nil
+	}
+	return &logsArithmeticExpression{attribute: strings.TrimPrefix(token, "@")}, nil
+}
+
+func (p *logsArithmeticParser) parseParenthesized() (*logsArithmeticExpression, error) {
+	p.pos++
+	inner, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+		return nil, fmt.Errorf("missing closing parenthesis in expression %q", p.input)
+	}
+	p.pos++
+	return inner, nil
+}
+
+// getLogsAttribute returns the value of an attribute, nested objects being accessed with dots
+func getLogsAttribute(event map[string]interface{}, path string) interface{} {
+	if value, ok := event[path]; ok {
+		return value
+	}
+	current := event
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		value, ok := current[part]
+		if !ok {
+			// Keys may contain dots themselves
+			if rest := strings.Join(parts[i:], "."); i > 0 {
+				return current[rest]
+			}
+			return nil
+		}
+		if i == len(parts)-1 {
+			return value
+		}
+		if current, ok = value.(map[string]interface{}); !ok {
+			return nil
+		}
+	}
+	return nil
+}
+
+func setLogsAttribute(event map[string]interface{}, path string, value interface{}) {
+	parts := strings.Split(path, ".")
+	current := event
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[part] = next
+		}
+		current = next
+	}
+	current[parts[len(parts)-1]] = value
+}
+
+func deleteLogsAttribute(event map[string]interface{}, path string) {
+	if _, ok := event[path]; ok {
+		delete(event, path)
+		return
+	}
+	parts := strings.Split(path, ".")
+	current := event
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			return
+		}
+		current = next
+	}
+	delete(current, parts[len(parts)-1])
+}
+
+// logsEventTags returns the tags of an event, given as a list or a comma separated string in `tags` or `ddtags`
+func logsEventTags(event map[string]interface{}) []string {
+	var tags []string
+	for _, key := range []string{"tags", "ddtags"} {
+		switch v := event[key].(type) {
+		case string:
+			for _, tag := range strings.Split(v, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+		case []interface{}:
+			for _, tag := range v {
+				tags = append(tags, logsValueToString(tag))
+			}
+		}
+	}
+	return tags
+}
+
+func getLogsTag(event map[string]interface{}, key string) (string, bool) {
+	for _, tag := range logsEventTags(event) {
+		if strings.HasPrefix(tag, key+":") {
+			return strings.TrimPrefix(tag, key+":"), true
+		}
+	}
+	return "", false
+}
+
+func setLogsTags(event map[string]interface{}, tags []string) {
+	delete(event, "ddtags")
+	values := make([]interface{}, len(tags))
+	for i, tag := range tags {
+		values[i] = tag
+	}
+	event["tags"] = values
+}
+
+func addLogsTag(event map[string]interface{}, tag string) {
+	setLogsTags(event, append(logsEventTags(event), tag))
+}
+
+func removeLogsTag(event map[string]interface{}, key string) {
+	tags := logsEventTags(event)
+	kept := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, key+":") {
+			kept = append(kept, tag)
+		}
+	}
+	if len(kept) != len(tags) {
+		setLogsTags(event, kept)
+	}
+}
+
+func logsValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+func logsValueToNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+func copyLogsValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyLogsValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyLogsValue(item)
+		}
+		return copied
+	}
+	return value
+}
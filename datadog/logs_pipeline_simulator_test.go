@@ -0,0 +1,435 @@
+package datadog
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+
+	common "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+)
+
+func TestGrokParser(t *testing.T) {
+	t.Parallel()
+	grok, err := compileGrokParser(
+		`# Apache access logs
+access.common %{_client_ip} %{_ident} %{_auth} \[%{_date_access}\] "(?>%{_method} |)%{_url}(?> %{_version}|)" %{_status_code} (?>%{_bytes_written}|-)
+access.combined %{access.common} "%{notSpace:http.referer}"
+kv \[%{data::keyvalue("=")}\]
+json %{regex("\\{.*\\}"):payload:json}`,
+		`_auth %{notSpace:http.auth:nullIf("-")}
+_bytes_written %{integer:network.bytes_written}
+_client_ip %{ipOrHost:network.client.ip}
+_version HTTP\/%{regex("\\d+\\.\\d+"):http.version}
+_url %{notSpace:http.url}
+_ident %{notSpace:http.ident:nullIf("-")}
+_status_code %{integer:http.status_code}
+_method %{word:http.method}
+_date_access %{date("dd/MMM/yyyy:HH:mm:ss Z"):date_access}`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attributes, rule, matched := grok.parse(`127.0.0.1 - frank [13/Jul/2016:10:55:36 +0000] "GET /apache_pb.gif HTTP/1.0" 200 2326`)
+	if !matched || rule != "access.common" {
+		t.Fatalf("expected access.common to match, got %v %q", matched, rule)
+	}
+	http := attributes["http"].(map[string]interface{})
+	if http["method"] != "GET" || http["url"] != "/apache_pb.gif" || http["version"] != "1.0" || http["status_code"] != float64(200) || http["auth"] != "frank" {
+		t.Errorf("unexpected http attributes %v", http)
+	}
+	if _, ok := http["ident"]; ok {
+		t.Errorf("nullIf should have dropped http.ident, got %v", http["ident"])
+	}
+	if attributes["date_access"] != float64(1468407336000) {
+		t.Errorf("unexpected date_access %v", attributes["date_access"])
+	}
+	if bytes := attributes["network"].(map[string]interface{})["bytes_written"]; bytes != float64(2326) {
+		t.Errorf("unexpected network.bytes_written %v", bytes)
+	}
+
+	attributes, rule, matched = grok.parse(`[user=john status=active]`)
+	if !matched || rule != "kv" || attributes["user"] != "john" || attributes["status"] != "active" {
+		t.Errorf("unexpected key values %v from rule %q", attributes, rule)
+	}
+
+	attributes, rule, matched = grok.parse(`{"a": {"b": 1}}`)
+	if !matched || rule != "json" || attributes["payload"].(map[string]interface{})["a"].(map[string]interface{})["b"] != float64(1) {
+		t.Errorf("unexpected json %v from rule %q", attributes, rule)
+	}
+
+	attributes, rule, matched = grok.parse(`127.0.0.1 - - [13/Jul/2016:10:55:36 +0000] "GET / HTTP/1.1" 200 - "https://example.com/"`)
+	if !matched || rule != "access.combined" || attributes["http"].(map[string]interface{})["referer"] != "https://example.com/" {
+		t.Errorf("unexpected combined access log %v from rule %q", attributes, rule)
+	}
+
+	if _, _, matched = grok.parse(`not an access log`); matched {
+		t.Errorf("expected no rule to match")
+	}
+}
+
+func TestGrokParserFilters(t *testing.T) {
+	t.Parallel()
+	grok, err := compileGrokParser(
+		`xml book=%{data:book:xml}
+csv csv=%{word:kind} %{data:user:csv("first_name,name,st_nb,st_name")}
+csv_separator csv;%{data:user:csv("first_name,name", ";", "'")}
+kv kv=%{data::keyvalue}
+multiline %{word:level} %{data:stack}`,
+		``,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, testCase := range []struct {
+		value      string
+		rule       string
+		attributes string
+	}{
+		{
+			value:      `book=<book category="CHILDREN"><title lang="en">Harry Potter</title><author>J K. Rowling</author><tag>a</tag><tag>b</tag></book>`,
+			rule:       "xml",
+			attributes: `{"book":{"book":{"author":"J K. Rowling","category":"CHILDREN","tag":["a","b"],"title":{"lang":"en","value":"Harry Potter"}}}}`,
+		},
+		{
+			value:      `csv=user John,"Doe, Jr.",120,Jefferson St.,extra`,
+			rule:       "csv",
+			attributes: `{"kind":"user","user":{"first_name":"John","name":"Doe, Jr.","st_name":"Jefferson St.","st_nb":120}}`,
+		},
+		{
+			value:      `csv;'John;Jr';Doe`,
+			rule:       "csv_separator",
+			attributes: `{"user":{"first_name":"John;Jr","name":"Doe"}}`,
+		},
+		{
+			value:      "kv=user=john\nstatus=\"active user\"",
+			rule:       "kv",
+			attributes: `{"status":"active user","user":"john"}`,
+		},
+		{
+			value:      "ERROR java.lang.NullPointerException\n\tat Main.main(Main.java:3)",
+			rule:       "multiline",
+			attributes: `{"level":"ERROR","stack":"java.lang.NullPointerException\n\tat Main.main(Main.java:3)"}`,
+		},
+	} {
+		attributes, rule, matched := grok.parse(testCase.value)
+		if !matched || rule != testCase.rule {
+			t.Errorf("expected rule %q to match %q, got %v %q", testCase.rule, testCase.value, matched, rule)
+			continue
+		}
+		if encoded, _ := json.Marshal(attributes); string(encoded) != testCase.attributes {
+			t.Errorf("expected %s from rule %q, got %s", testCase.attributes, rule, encoded)
+		}
+	}
+}
+
+func TestGrokParserErrors(t *testing.T) {
+	t.Parallel()
+	for _, testCase := range []struct {
+		matchRules   string
+		supportRules string
+		expected     string
+	}{
+		{"invalid", "", "line 1 must be formatted as `name pattern`"},
+		{"rule %{word:a}\nrule %{word:b}", "", `rule "rule" is defined twice`},
+		{"rule %{unknown:a}", "", `unknown matcher or support rule "unknown"`},
+		{"rule %{word:a:unknown}", "", `unknown filter "unknown"`},
+		{"rule %{_missing}", "", `unknown matcher or support rule "_missing"`},
+		{"rule %{_loop}", "_loop %{_loop}", `support rule "_loop" references itself`},
+		{"rule %{word:a:scale}", "", `filter "scale" takes between 1 and 1 arguments, got 0`},
+	} {
+		_, err := compileGrokParser(testCase.matchRules, testCase.supportRules)
+		if err == nil || !strings.Contains(err.Error(), testCase.expected) {
+			t.Errorf("expected error containing %q for %q, got %v", testCase.expected, testCase.matchRules, err)
+		}
+	}
+}
+
+func TestLogsSearchQuery(t *testing.T) {
+	t.Parallel()
+	event := map[string]interface{}{
+		"message":  "Payment FAILED for order 42",
+		"service":  "checkout",
+		"status":   "error",
+		"tags":     []interface{}{"env:prod", "team:payments"},
+		"severity": "-",
+		"http": map[string]interface{}{
+			"status_code": float64(503),
+			"url":         "/api/v2/payments",
+		},
+		"network": map[string]interface{}{
+			"client": map[string]interface{}{"ip": "10.1.2.3"},
+		},
+	}
+	for query, expected := range map[string]bool{
+		"":                                      true,
+		"*":                                     true,
+		"service:checkout":                      true,
+		"service:check*":                        true,
+		"service:(web OR checkout)":             true,
+		"service:web":                           false,
+		"env:prod team:payments":                true,
+		"env:prod AND -team:payments":           false,
+		"NOT status:info":                       true,
+		"failed":                                true,
+		`"payment failed"`:                      true,
+		`"failed payment"`:                      false,
+		"@http.status_code:>=500":               true,
+		"@http.status_code:[400 TO 499]":        false,
+		"@http.status_code:{500 TO 600}":        true,
+		"@http.url:\\/api\\/v2*":                true,
+		"(service:web OR env:prod) failed":      true,
+		"source:nginx OR @http.status_code:503": true,
+		`@severity: "-"`:                        true,
+		`@severity:"+"`:                         false,
+		"@http.status_code:[500 TO 503}":        false,
+		"@http.status_code:{500 TO 503]":        true,
+		"CIDR(@network.client.ip,10.0.0.0/8)":   true,
+		"CIDR(@network.client.ip,192.168.0.0/16, 172.16.0.0/12)": false,
+	} {
+		parsed, err := compileLogsSearchQuery(query)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %s", query, err)
+			continue
+		}
+		if parsed.matches(event) != expected {
+			t.Errorf("expected %q to match %v", query, expected)
+		}
+	}
+
+	for _, query := range []string{"service:(web", "a AND", "@http.status_code:[1 TO", `"unterminated`, "CIDR(@network.client.ip,10.0.0.0)"} {
+		if _, err := compileLogsSearchQuery(query); err == nil {
+			t.Errorf("expected an error parsing %q", query)
+		}
+	}
+}
+
+func TestLogsPipelineSimulator(t *testing.T) {
+	t.Parallel()
+	enabled := true
+	pipeline := datadogV1.NewLogsPipelineProcessorWithDefaults()
+	pipeline.SetIsEnabled(true)
+	pipeline.SetFilter(datadogV1.LogsFilter{Query: common.PtrString("source:nginx")})
+
+	grok := datadogV1.NewLogsGrokParser(datadogV1.LogsGrokParserRules{
+		MatchRules: `access %{ipOrHost:network.client.ip} %{word:http.method} %{notSpace:http.url} %{integer:http.status_code} %{number:duration} "%{data:http.useragent}" %{notSpace:level} %{date("yyyy-MM-dd'T'HH:mm:ss"):timestamp}`,
+	}, "message", datadogV1.LOGSGROKPARSERTYPE_GROK_PARSER)
+	grok.IsEnabled = &enabled
+
+	dateRemapper := datadogV1.NewLogsDateRemapper([]string{"timestamp"}, datadogV1.LOGSDATEREMAPPERTYPE_DATE_REMAPPER)
+	dateRemapper.IsEnabled = &enabled
+	statusRemapper := datadogV1.NewLogsStatusRemapper([]string{"level"}, datadogV1.LOGSSTATUSREMAPPERTYPE_STATUS_REMAPPER)
+	statusRemapper.IsEnabled = &enabled
+
+	attributeRemapper := datadogV1.NewLogsAttributeRemapper([]string{"duration"}, "duration_ms", datadogV1.LOGSATTRIBUTEREMAPPERTYPE_ATTRIBUTE_REMAPPER)
+	attributeRemapper.IsEnabled = &enabled
+	attributeRemapper.SetSourceType("attribute")
+	attributeRemapper.SetTargetType("attribute")
+
+	categoryProcessor := datadogV1.NewLogsCategoryProcessor([]datadogV1.LogsCategoryProcessorCategory{
+		{Filter: &datadogV1.LogsFilter{Query: common.PtrString("@http.status_code:[200 TO 299]")}, Name: common.PtrString("OK")},
+		{Filter: &datadogV1.LogsFilter{Query: common.PtrString("@http.status_code:[500 TO 599]")}, Name: common.PtrString("Server error")},
+	}, "http.status_category", datadogV1.LOGSCATEGORYPROCESSORTYPE_CATEGORY_PROCESSOR)
+	categoryProcessor.IsEnabled = &enabled
+
+	arithmeticProcessor := datadogV1.NewLogsArithmeticProcessor("round(duration_ms / 1000)", "duration_s", datadogV1.LOGSARITHMETICPROCESSORTYPE_ARITHMETIC_PROCESSOR)
+	arithmeticProcessor.IsEnabled = &enabled
+
+	stringBuilder := datadogV1.NewLogsStringBuilderProcessor("summary", "%{http.method} %{http.url} took %{duration_s}s", datadogV1.LOGSSTRINGBUILDERPROCESSORTYPE_STRING_BUILDER_PROCESSOR)
+	stringBuilder.IsEnabled = &enabled
+
+	urlParser := datadogV1.NewLogsURLParser([]string{"http.url"}, "http.url_details", datadogV1.LOGSURLPARSERTYPE_URL_PARSER)
+	urlParser.IsEnabled = &enabled
+
+	userAgentParser := datadogV1.NewLogsUserAgentParser([]string{"http.useragent"}, "http.useragent_details", datadogV1.LOGSUSERAGENTPARSERTYPE_USER_AGENT_PARSER)
+	userAgentParser.IsEnabled = &enabled
+
+	lookupProcessor := datadogV1.NewLogsLookupProcessor([]string{"GET,read", "POST,write"}, "http.method", "http.kind", datadogV1.LOGSLOOKUPPROCESSORTYPE_LOOKUP_PROCESSOR)
+	lookupProcessor.IsEnabled = &enabled
+	lookupProcessor.SetDefaultLookup("other")
+
+	nested := datadogV1.NewLogsPipelineProcessorWithDefaults()
+	nested.SetIsEnabled(true)
+	nested.SetFilter(datadogV1.LogsFilter{Query: common.PtrString("status:error")})
+	alert := datadogV1.NewLogsStringBuilderProcessor("alert", "%{http.status_category} on %{http.url_details.host}", datadogV1.LOGSSTRINGBUILDERPROCESSORTYPE_STRING_BUILDER_PROCESSOR)
+	alert.IsEnabled = &enabled
+	nested.Processors = []datadogV1.LogsProcessor{datadogV1.LogsStringBuilderProcessorAsLogsProcessor(alert)}
+
+	geoIPParser := datadogV1.NewLogsGeoIPParser([]string{"network.client.ip"}, "network.client.geoip", datadogV1.LOGSGEOIPPARSERTYPE_GEO_IP_PARSER)
+
+	pipeline.Processors = []datadogV1.LogsProcessor{
+		datadogV1.LogsGrokParserAsLogsProcessor(grok),
+		datadogV1.LogsDateRemapperAsLogsProcessor(dateRemapper),
+		datadogV1.LogsStatusRemapperAsLogsProcessor(statusRemapper),
+		datadogV1.LogsAttributeRemapperAsLogsProcessor(attributeRemapper),
+		datadogV1.LogsCategoryProcessorAsLogsProcessor(categoryProcessor),
+		datadogV1.LogsArithmeticProcessorAsLogsProcessor(arithmeticProcessor),
+		datadogV1.LogsStringBuilderProcessorAsLogsProcessor(stringBuilder),
+		datadogV1.LogsURLParserAsLogsProcessor(urlParser),
+		datadogV1.LogsUserAgentParserAsLogsProcessor(userAgentParser),
+		datadogV1.LogsLookupProcessorAsLogsProcessor(lookupProcessor),
+		datadogV1.LogsPipelineProcessorAsLogsProcessor(nested),
+		datadogV1.LogsGeoIPParserAsLogsProcessor(geoIPParser),
+	}
+
+	simulator, err := newLogsPipelineSimulator(pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if warnings := simulator.warnings; len(warnings) != 1 || !strings.Contains(warnings[0], "geo_ip_parser") {
+		t.Errorf("expected a warning about the geo_ip_parser, got %v", warnings)
+	}
+
+	event, matched := simulator.process(parseLogsSimulationEvent(`{"source": "nginx", "message": "10.0.0.1 POST https://shop.example.com/cart/?id=3 502 2500.4 \"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.1 Safari/605.1.15\" ERR 2023-05-04T10:20:30"}`))
+	if !matched {
+		t.Fatal("expected the pipeline filter to match")
+	}
+	for attribute, expected := range map[string]interface{}{
+		"date":     "2023-05-04T10:20:30.000Z",
+		"status":   "error",
+		"summary":  "POST https://shop.example.com/cart/?id=3 took 3s",
+		"alert":    "Server error on shop.example.com",
+		"duration": nil,
+	} {
+		if event[attribute] != expected {
+			t.Errorf("expected %s to be %v, got %v", attribute, expected, event[attribute])
+		}
+	}
+	http := event["http"].(map[string]interface{})
+	if http["kind"] != "write" || http["status_category"] != "Server error" {
+		t.Errorf("unexpected http attributes %v", http)
+	}
+	urlDetails := http["url_details"].(map[string]interface{})
+	if urlDetails["path"] != "/cart/" || urlDetails["port"] != float64(443) || urlDetails["queryString"].(map[string]interface{})["id"] != "3" {
+		t.Errorf("unexpected url details %v", urlDetails)
+	}
+	userAgent := http["useragent_details"].(map[string]interface{})
+	if browser := userAgent["browser"].(map[string]interface{}); browser["family"] != "Safari" || browser["major"] != "16" {
+		t.Errorf("unexpected browser %v", browser)
+	}
+	if os := userAgent["os"].(map[string]interface{}); os["family"] != "Mac OS X" || os["major"] != "10" {
+		t.Errorf("unexpected os %v", os)
+	}
+
+	event, matched = simulator.process(parseLogsSimulationEvent("raw line"))
+	if matched || event["message"] != "raw line" || len(event) != 1 {
+		t.Errorf("expected the raw line to be left unchanged, got %v", event)
+	}
+}
+
+func TestLogsPipelineSimulatorRawProcessors(t *testing.T) {
+	t.Parallel()
+	pipeline := datadogV1.NewLogsPipelineProcessorWithDefaults()
+	pipeline.SetIsEnabled(true)
+	pipeline.SetFilter(datadogV1.LogsFilter{Query: common.PtrString("source:auth")})
+	for _, raw := range []string{
+		`{"type": "decoder-processor", "is_enabled": true, "source": "payload", "target": "payload_decoded", "binary_to_text_encoding": "base64", "input_representation": "utf_8"}`,
+		`{"type": "decoder-processor", "is_enabled": true, "source": "code", "target": "code_decoded", "binary_to_text_encoding": "base16", "input_representation": "integer"}`,
+		`{"type": "array-processor", "is_enabled": true, "operation": {"type": "select", "source": "http.headers", "target": "http.referrer", "filter": "name:Referrer", "value_to_extract": "value"}}`,
+		`{"type": "array-processor", "is_enabled": true, "operation": {"type": "length", "source": "tags", "target": "tags_count"}}`,
+		`{"type": "array-processor", "is_enabled": true, "operation": {"type": "append", "source": "usr.id", "target": "user_ids", "preserve_source": false}}`,
+		`{"type": "schema-processor", "is_enabled": true, "schema": {"schema_type": "ocsf"}}`,
+	} {
+		var processor datadogV1.LogsProcessor
+		if err := json.Unmarshal([]byte(raw), &processor); err != nil {
+			t.Fatal(err)
+		}
+		if processor.UnparsedObject == nil {
+			t.Fatalf("expected %s to be unknown to the API client", raw)
+		}
+		pipeline.Processors = append(pipeline.Processors, processor)
+	}
+
+	simulator, err := newLogsPipelineSimulator(pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if warnings := simulator.warnings; len(warnings) != 1 || !strings.Contains(warnings[0], "schema-processor") {
+		t.Errorf("expected a warning about the schema-processor, got %v", warnings)
+	}
+
+	event, matched := simulator.process(parseLogsSimulationEvent(`{"source": "auth", "payload": "aGVsbG8=", "code": "01f4", "tags": ["a", "b"], "usr": {"id": "u1"}, "user_ids": ["u0"], "http": {"headers": [{"name": "Host", "value": "example.com"}, {"name": "Referrer", "value": "https://example.com/"}]}}`))
+	if !matched {
+		t.Fatal("expected the pipeline filter to match")
+	}
+	for attribute, expected := range map[string]interface{}{
+		"payload_decoded": "hello",
+		"code_decoded":    float64(500),
+		"tags_count":      float64(2),
+	} {
+		if event[attribute] != expected {
+			t.Errorf("expected %s to be %v, got %v", attribute, expected, event[attribute])
+		}
+	}
+	if referrer := event["http"].(map[string]interface{})["referrer"]; referrer != "https://example.com/" {
+		t.Errorf("unexpected referrer %v", referrer)
+	}
+	if userIDs := event["user_ids"].([]interface{}); len(userIDs) != 2 || userIDs[1] != "u1" || len(event["usr"].(map[string]interface{})) != 0 {
+		t.Errorf("unexpected user ids %v and usr %v", userIDs, event["usr"])
+	}
+}
+
+func TestLogsPipelineSimulatorErrors(t *testing.T) {
+	t.Parallel()
+	pipeline := datadogV1.NewLogsPipelineProcessorWithDefaults()
+	pipeline.SetFilter(datadogV1.LogsFilter{Query: common.PtrString("service:web")})
+	arithmeticProcessor := datadogV1.NewLogsArithmeticProcessor("duration * (2", "target", datadogV1.LOGSARITHMETICPROCESSORTYPE_ARITHMETIC_PROCESSOR)
+	arithmeticProcessor.SetIsEnabled(true)
+	pipeline.Processors = []datadogV1.LogsProcessor{datadogV1.LogsArithmeticProcessorAsLogsProcessor(arithmeticProcessor)}
+	if _, err := newLogsPipelineSimulator(pipeline); err == nil || !strings.Contains(err.Error(), "processor.0: missing closing parenthesis") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestLogsPipelineSimulatorFixturePipelines(t *testing.T) {
+	t.Parallel()
+	simulated := 0
+	for _, name := range []string{
+		"TestAccDatadogLogsPipeline_basic",
+		"TestAccDatadogLogsPipeline_import",
+		"TestAccDatadogLogsPipelineEmptyFilterQuery",
+		"TestAccDatadogLogsPipelinesDatasource",
+		"TestAccDatadogLogsPipelinesDatasourceReadonly",
+		"TestAccLogsCustomPipeline_importBasic",
+	} {
+		c, err := cassette.Load(filepath.Join("tests", "cassettes", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, interaction := range c.Interactions {
+			var pipelines []datadogV1.LogsPipeline
+			if strings.HasPrefix(strings.TrimSpace(interaction.Response.Body), "[") {
+				if err := json.Unmarshal([]byte(interaction.Response.Body), &pipelines); err != nil {
+					continue
+				}
+			} else {
+				var pipeline datadogV1.LogsPipeline
+				if err := json.Unmarshal([]byte(interaction.Response.Body), &pipeline); err != nil || pipeline.Name == "" {
+					continue
+				}
+				pipelines = append(pipelines, pipeline)
+			}
+			for _, pipeline := range pipelines {
+				nested := datadogV1.NewLogsPipelineProcessorWithDefaults()
+				nested.SetName(pipeline.GetName())
+				nested.SetIsEnabled(true)
+				nested.SetFilter(pipeline.GetFilter())
+				nested.SetProcessors(pipeline.GetProcessors())
+				simulator, err := newLogsPipelineSimulator(nested)
+				if err != nil {
+					t.Errorf("%s: pipeline %q: %s", name, pipeline.GetName(), err)
+					continue
+				}
+				simulator.process(parseLogsSimulationEvent(`{"message": "GET /index.html 200", "service": "web", "tags": ["env:prod"]}`))
+				simulated++
+			}
+		}
+	}
+	if simulated == 0 {
+		t.Error("expected pipelines to be found in the cassettes")
+	}
+}
@@ -0,0 +1,354 @@
+package datadog
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+)
+
+// Attributes of a log event which are searched without the `@` prefix
+var logsReservedSearchAttributes = map[string]bool{
+	"host":     true,
+	"service":  true,
+	"source":   true,
+	"status":   true,
+	"trace_id": true,
+}
+
+// logsSearchQuery is a compiled logs search query, as used by pipeline and category filters
+type logsSearchQuery interface {
+	matches(event map[string]interface{}) bool
+}
+
+type logsQueryAnd []logsSearchQuery
+
+type logsQueryOr []logsSearchQuery
+
+type logsQueryNot struct {
+	query logsSearchQuery
+}
+
+// logsQueryTerm matches a value, or a range of values, of a key. An empty key searches the message.
+type logsQueryTerm struct {
+	key     string
+	value   string
+	pattern *regexp.Regexp
+	// Ranges and comparisons, with empty bounds left open
+	isRange      bool
+	lower, upper string
+	includeLower bool
+	includeUpper bool
+}
+
+// logsQueryCIDR matches IP addresses of a key within one of the networks
+type logsQueryCIDR struct {
+	key      string
+	networks []*net.IPNet
+}
+
+type logsQueryAll struct{}
+
+func (q logsQueryAnd) matches(event map[string]interface{}) bool {
+	for _, query := range q {
+		if !query.matches(event) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q logsQueryOr) matches(event map[string]interface{}) bool {
+	for _, query := range q {
+		if query.matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q logsQueryNot) matches(event map[string]interface{}) bool {
+	return !q.query.matches(event)
+}
+
+func (logsQueryAll) matches(map[string]interface{}) bool {
+	return true
+}
+
+func (q *logsQueryCIDR) matches(event map[string]interface{}) bool {
+	for _, value := range logsSearchValues(event, q.key) {
+		ip := net.ParseIP(logsValueToString(value))
+		if ip == nil {
+			continue
+		}
+		for _, network := range q.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (q *logsQueryTerm) matches(event map[string]interface{}) bool {
+	for _, value := range logsSearchValues(event, q.key) {
+		if q.matchesValue(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *logsQueryTerm) matchesValue(value interface{}) bool {
+	if q.isRange {
+		return q.matchesRange(value)
+	}
+	text := logsValueToString(value)
+	if q.key == "" {
+		// Full text search looks for the terms in the message, ignoring case
+		text = strings.ToLower(text)
+		if q.pattern != nil {
+			return q.pattern.MatchString(text)
+		}
+		return strings.Contains(text, strings.ToLower(q.value))
+	}
+	if q.key == "status" {
+		text = strings.ToLower(text)
+	}
+	if q.pattern != nil {
+		return q.pattern.MatchString(text)
+	}
+	if number, ok := value.(float64); ok {
+		if queryNumber, err := strconv.ParseFloat(q.value, 64); err == nil {
+			return number == queryNumber
+		}
+	}
+	if q.key == "status" {
+		return text == strings.ToLower(q.value)
+	}
+	return text == q.value
+}
+
+func (q *logsQueryTerm) matchesRange(value interface{}) bool {
+	number, isNumber := logsValueToNumber(value)
+	compare := func(bound string) (int, bool) {
+		if boundNumber, err := strconv.ParseFloat(bound, 64); err == nil && isNumber {
+			switch {
+			case number < boundNumber:
+				return -1, true
+			case number > boundNumber:
+				return 1, true
+			}
+			return 0, true
+		}
+		if isNumber {
+			return 0, false
+		}
+		return strings.Compare(logsValueToString(value), bound), true
+	}
+	if q.lower != "" {
+		c, ok := compare(q.lower)
+		if !ok || c < 0 || (c == 0 && !q.includeLower) {
+			return false
+		}
+	}
+	if q.upper != "" {
+		c, ok := compare(q.upper)
+		if !ok || c > 0 || (c == 0 && !q.includeUpper) {
+			return false
+		}
+	}
+	return true
+}
+
+// logsSearchValues returns the values of an event searched for a key: attributes prefixed by `@`,
+// reserved attributes, tags, or the message for full text search
+func logsSearchValues(event map[string]interface{}, key string) []interface{} {
+	var values []interface{}
+	switch {
+	case key == "":
+		if message, ok := event["message"]; ok {
+			values = append(values, message)
+		}
+	case strings.HasPrefix(key, "@"):
+		values = append(values, logsFlattenValues(getLogsAttribute(event, key[1:]))...)
+	case logsReservedSearchAttributes[key]:
+		value, ok := event[key]
+		if !ok && key == "source" {
+			value, ok = event["ddsource"]
+		}
+		if ok {
+			values = append(values, value)
+		}
+	default:
+		prefix := key + ":"
+		for _, tag := range logsEventTags(event) {
+			if strings.HasPrefix(tag, prefix) {
+				values = append(values, strings.TrimPrefix(tag, prefix))
+			}
+		}
+	}
+	return values
+}
+
+func logsFlattenValues(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		var values []interface{}
+		for _, item := range v {
+			values = append(values, logsFlattenValues(item)...)
+		}
+		return values
+	}
+	return []interface{}{value}
+}
+
+// compileLogsSearchQuery compiles a logs search query, as parsed by the shared log query parser, to a matcher of
+// log events. Pipeline and category filters support the same syntax as log search.
+func compileLogsSearchQuery(query string) (logsSearchQuery, error) {
+	node, err := validators.ParseLogsQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return compileLogsQueryNode(node)
+}
+
+func compileLogsQueryNode(node *validators.LogsQueryNode) (logsSearchQuery, error) {
+	if node == nil {
+		return logsQueryAll{}, nil
+	}
+	children := make([]logsSearchQuery, len(node.Children))
+	for i, child := range node.Children {
+		query, err := compileLogsQueryNode(child)
+		if err != nil {
+			return nil, err
+		}
+		children[i] = query
+	}
+	switch node.Operator {
+	case validators.LogsQueryOperatorAnd:
+		return logsQueryAnd(children), nil
+	case validators.LogsQueryOperatorOr:
+		return logsQueryOr(children), nil
+	case validators.LogsQueryOperatorNot:
+		return logsQueryNot{children[0]}, nil
+	}
+
+	key := unescapeLogsQuery(node.Facet)
+	value := node.Value
+	switch {
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		return &logsQueryTerm{key: key, value: unescapeLogsQuery(value[1 : len(value)-1])}, nil
+	case key != "" && (value[0] == '[' || value[0] == '{'):
+		bounds := strings.Fields(value[1 : len(value)-1])
+		term := &logsQueryTerm{key: key, isRange: true, includeLower: value[0] == '[', includeUpper: value[len(value)-1] == ']'}
+		if bounds[0] != "*" {
+			term.lower = bounds[0]
+		}
+		if bounds[2] != "*" {
+			term.upper = bounds[2]
+		}
+		return term, nil
+	case key == "" && strings.HasPrefix(value, "CIDR(") && strings.HasSuffix(value, ")"):
+		return newLogsQueryCIDR(value)
+	}
+	return newLogsQueryValueTerm(key, value)
+}
+
+// newLogsQueryCIDR compiles a `CIDR(@attribute,cidr1,cidr2)` term, matching IP addresses within one of the ranges
+func newLogsQueryCIDR(value string) (logsSearchQuery, error) {
+	args := strings.Split(strings.TrimSuffix(strings.TrimPrefix(value, "CIDR("), ")"), ",")
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%s must list an attribute and at least one CIDR", value)
+	}
+	query := &logsQueryCIDR{key: strings.TrimSpace(args[0])}
+	for _, arg := range args[1:] {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(arg))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", value, err)
+		}
+		query.networks = append(query.networks, network)
+	}
+	return query, nil
+}
+
+func newLogsQueryValueTerm(key string, value string) (logsSearchQuery, error) {
+	for _, operator := range []string{">=", "<=", ">", "<"} {
+		if !strings.HasPrefix(value, operator) || key == "" {
+			continue
+		}
+		bound := strings.TrimPrefix(value, operator)
+		if bound == "" {
+			return nil, fmt.Errorf("missing value after %s for %q", operator, key)
+		}
+		term := &logsQueryTerm{key: key, isRange: true}
+		if operator[0] == '>' {
+			term.lower, term.includeLower = bound, len(operator) == 2
+		} else {
+			term.upper, term.includeUpper = bound, len(operator) == 2
+		}
+		return term, nil
+	}
+	if value == "*" {
+		if key == "" {
+			return logsQueryAll{}, nil
+		}
+		return &logsQueryTerm{key: key, pattern: regexp.MustCompile(`(?s)^.*$`)}, nil
+	}
+
+	term := &logsQueryTerm{key: key, value: unescapeLogsQuery(value)}
+	if pattern, ok := logsQueryWildcardPattern(value, key == ""); ok {
+		term.pattern = pattern
+	}
+	return term, nil
+}
+
+// logsQueryWildcardPattern converts unescaped `*` and `?` wildcards to a regular expression
+func logsQueryWildcardPattern(value string, fullText bool) (*regexp.Regexp, bool) {
+	var pattern strings.Builder
+	hasWildcard := false
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '\\' && i+1 < len(runes):
+			i++
+			pattern.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case r == '*':
+			hasWildcard = true
+			pattern.WriteString(".*")
+		case r == '?':
+			hasWildcard = true
+			pattern.WriteString(".")
+		default:
+			if fullText {
+				r = unicode.ToLower(r)
+			}
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if !hasWildcard {
+		return nil, false
+	}
+	if fullText {
+		return regexp.MustCompile(`(?s)` + pattern.String()), true
+	}
+	return regexp.MustCompile(`(?s)^` + pattern.String() + `$`), true
+}
+
+func unescapeLogsQuery(value string) string {
+	var unescaped strings.Builder
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\\' && i+1 < len(runes) {
+			i++
+		}
+		unescaped.WriteRune(runes[i])
+	}
+	return unescaped.String()
+}
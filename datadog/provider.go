@@ -174,17 +174,21 @@ func Provider() *schema.Provider {
 			"datadog_logs_archive":                         resourceDatadogLogsArchive(),
 			"datadog_logs_archive_order":                   resourceDatadogLogsArchiveOrder(),
 			"datadog_logs_custom_pipeline":                 resourceDatadogLogsCustomPipeline(),
+			"datadog_logs_custom_pipeline_json":            resourceDatadogLogsCustomPipelineJSON(),
 			"datadog_logs_index":                           resourceDatadogLogsIndex(),
 			"datadog_logs_index_order":                     resourceDatadogLogsIndexOrder(),
 			"datadog_logs_integration_pipeline":            resourceDatadogLogsIntegrationPipeline(),
 			"datadog_logs_metric":                          resourceDatadogLogsMetric(),
 			"datadog_logs_pipeline_order":                  resourceDatadogLogsPipelineOrder(),
+			"datadog_logs_restriction_query":               resourceDatadogLogsRestrictionQuery(),
+			"datadog_logs_restriction_query_role":          resourceDatadogLogsRestrictionQueryRole(),
 			"datadog_metric_metadata":                      resourceDatadogMetricMetadata(),
 			"datadog_metric_tag_configuration":             resourceDatadogMetricTagConfiguration(),
 			"datadog_monitor":                              resourceDatadogMonitor(),
 			"datadog_monitor_config_policy":                resourceDatadogMonitorConfigPolicy(),
 			"datadog_monitor_json":                         resourceDatadogMonitorJSON(),
 			"datadog_organization_settings":                resourceDatadogOrganizationSettings(),
+			"datadog_reference_table":                      resourceDatadogReferenceTable(),
 			"datadog_role":                                 resourceDatadogRole(),
 			"datadog_rum_application":                      resourceDatadogRUMApplication(),
 			"datadog_service_account":                      resourceDatadogServiceAccount(),
@@ -197,6 +201,7 @@ func Provider() *schema.Provider {
 			"datadog_service_definition_yaml":              resourceDatadogServiceDefinitionYAML(),
 			"datadog_slo_correction":                       resourceDatadogSloCorrection(),
 			"datadog_synthetics_test":                      resourceDatadogSyntheticsTest(),
+			"datadog_synthetics_ci_run":                    resourceDatadogSyntheticsCIRun(),
 			"datadog_synthetics_global_variable":           resourceDatadogSyntheticsGlobalVariable(),
 			"datadog_synthetics_private_location":          resourceDatadogSyntheticsPrivateLocation(),
 			"datadog_user":                                 resourceDatadogUser(),
@@ -208,10 +213,12 @@ func Provider() *schema.Provider {
 			"datadog_application_key":                         dataSourceDatadogApplicationKey(),
 			"datadog_cloud_workload_security_agent_rules":     dataSourceDatadogCloudWorkloadSecurityAgentRules(),
 			"datadog_dashboard":                               dataSourceDatadogDashboard(),
+			"datadog_dashboards":                              dataSourceDatadogDashboards(),
 			"datadog_integration_aws_logs_services":           dataSourceDatadogIntegrationAWSLogsServices(),
 			"datadog_logs_archives_order":                     dataSourceDatadogLogsArchivesOrder(),
 			"datadog_logs_indexes":                            dataSourceDatadogLogsIndexes(),
 			"datadog_logs_indexes_order":                      dataSourceDatadogLogsIndexesOrder(),
+			"datadog_logs_pipeline_simulation":                dataSourceDatadogLogsPipelineSimulation(),
 			"datadog_logs_pipelines":                          dataSourceDatadogLogsPipelines(),
 			"datadog_monitor":                                 dataSourceDatadogMonitor(),
 			"datadog_monitors":                                dataSourceDatadogMonitors(),
@@ -225,9 +232,13 @@ func Provider() *schema.Provider {
 			"datadog_sensitive_data_scanner_standard_pattern": dataSourceDatadogSensitiveDataScannerStandardPattern(),
 			"datadog_service_level_objective":                 dataSourceDatadogServiceLevelObjective(),
 			"datadog_service_level_objectives":                dataSourceDatadogServiceLevelObjectives(),
+			"datadog_synthetics_browser_recording":            dataSourceDatadogSyntheticsBrowserRecording(),
 			"datadog_synthetics_locations":                    dataSourceDatadogSyntheticsLocations(),
+			"datadog_synthetics_openapi_requests":             dataSourceDatadogSyntheticsOpenAPIRequests(),
+			"datadog_synthetics_private_location_deployment":  dataSourceDatadogSyntheticsPrivateLocationDeployment(),
 			"datadog_synthetics_global_variable":              dataSourceDatadogSyntheticsGlobalVariable(),
 			"datadog_synthetics_test":                         dataSourceDatadogSyntheticsTest(),
+			"datadog_synthetics_tests":                        dataSourceDatadogSyntheticsTests(),
 			"datadog_user":                                    dataSourceDatadogUser(),
 		},
 
@@ -5384,7 +5384,7 @@ func buildDatadogListStreamRequests(terraformRequests *[]interface{}) *[]datadog
 			if v, ok := q["data_source"].(string); ok && len(v) > 0 {
 				ds := datadogV1.ListStreamSource(v)
 				datadogQuery.SetDataSource(ds)
-				if v, ok := q["event_size"].(string); ds == datadogV1.LISTSTREAMSOURCE_EVENT_STREAM && ok {
+				if v, ok := q["event_size"].(string); ds == datadogV1.LISTSTREAMSOURCE_EVENT_STREAM && ok && len(v) > 0 {
 					datadogQuery.SetEventSize(datadogV1.WidgetEventSize(v))
 				}
 			}
@@ -9130,16 +9130,16 @@ func buildTerraformApmStatsQuery(datadogQuery datadogV1.ApmStatsQueryDefinition)
 		for i, datadogColumn := range *v {
 			terraformColumn := map[string]interface{}{}
 			if name, nameOk := datadogColumn.GetNameOk(); nameOk {
-				terraformColumn["name"] = name
+				terraformColumn["name"] = *name
 			}
 			if alias, aliasOk := datadogColumn.GetAliasOk(); aliasOk {
-				terraformColumn["alias"] = alias
+				terraformColumn["alias"] = *alias
 			}
 			if cellDisplayMode, cellDisplayModeOk := datadogColumn.GetCellDisplayModeOk(); cellDisplayModeOk {
-				terraformColumn["cell_display_mode"] = cellDisplayMode
+				terraformColumn["cell_display_mode"] = *cellDisplayMode
 			}
 			if order, orderOk := datadogColumn.GetOrderOk(); orderOk {
-				terraformColumn["order"] = order
+				terraformColumn["order"] = *order
 			}
 			terraformColumns[i] = terraformColumn
 		}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -16,8 +15,6 @@ import (
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 )
 
-var computedFields = []string{"id", "author_handle", "author_name", "created_at", "modified_at", "url"}
-
 const path = "/api/v1/dashboard"
 
 func resourceDatadogDashboardJSON() *schema.Resource {
@@ -55,7 +52,7 @@ func resourceDatadogDashboardJSON() *schema.Resource {
 					ValidateFunc: validation.StringIsJSON,
 					StateFunc: func(v interface{}) string {
 						attrMap, _ := structure.ExpandJsonFromString(v.(string))
-						prepResource(attrMap)
+						utils.PrepDashboardJSON(attrMap)
 						res, _ := structure.FlattenJsonToString(attrMap)
 						return res
 					},
@@ -84,21 +81,6 @@ func resourceDatadogDashboardJSON() *schema.Resource {
 	}
 }
 
-func deleteWidgetID(widgets []interface{}) {
-	for _, w := range widgets {
-		if widget, ok := w.(map[string]interface{}); ok {
-			if def, ok := widget["definition"].(map[string]interface{}); ok {
-				if def["type"] == "group" {
-					if group, ok := def["widgets"].([]interface{}); ok {
-						deleteWidgetID(group)
-					}
-				}
-				delete(widget, "id")
-			}
-		}
-	}
-}
-
 func resourceDatadogDashboardJSONRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -226,7 +208,7 @@ func updateDashboardJSONState(d *schema.ResourceData, dashboard map[string]inter
 		}
 	}
 
-	prepResource(dashboard)
+	utils.PrepDashboardJSON(dashboard)
 
 	dashboardString, err := structure.FlattenJsonToString(dashboard)
 	if err != nil {
@@ -238,37 +220,3 @@ func updateDashboardJSONState(d *schema.ResourceData, dashboard map[string]inter
 	}
 	return nil
 }
-
-func prepResource(attrMap map[string]interface{}) map[string]interface{} {
-	// This is an edge case where refresh might be called with an empty definition.
-	if attrMap == nil {
-		return attrMap
-	}
-
-	// Remove computed fields when comparing diffs
-	for _, f := range computedFields {
-		delete(attrMap, f)
-	}
-	// Remove every widget id too
-	if widgets, ok := attrMap["widgets"].([]interface{}); ok {
-		deleteWidgetID(widgets)
-	}
-	// 'restricted_roles' takes precedence over 'is_read_only'
-	if _, ok := attrMap["restricted_roles"].([]interface{}); ok {
-		delete(attrMap, "is_read_only")
-	} else {
-		// `is_read_only` defaults to false.
-		// We set it manually to avoid continous diff when not set.
-		if _, ok := attrMap["is_read_only"]; !ok {
-			attrMap["is_read_only"] = false
-		}
-	}
-	// handle `notify_list` order
-	if notifyList, ok := attrMap["notify_list"].([]interface{}); ok {
-		sort.SliceStable(notifyList, func(i, j int) bool {
-			return notifyList[i].(string) < notifyList[j].(string)
-		})
-	}
-
-	return attrMap
-}
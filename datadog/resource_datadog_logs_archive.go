@@ -3,14 +3,28 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	logsArchiveS3EncryptionSSES3  = "SSE_S3"
+	logsArchiveS3EncryptionSSEKMS = "SSE_KMS"
+)
+
+var (
+	kmsKeyARNRegex   = regexp.MustCompile(`^arn:aws(-[a-z]+)*:kms:[a-z0-9-]+:\d{12}:(key|alias)/[A-Za-z0-9/_-]+$`)
+	s3StorageClasses = []interface{}{"STANDARD", "STANDARD_IA", "ONEZONE_IA", "INTELLIGENT_TIERING", "GLACIER_IR"}
 )
 
 func resourceDatadogLogsArchive() *schema.Resource {
@@ -20,13 +34,14 @@ func resourceDatadogLogsArchive() *schema.Resource {
 		UpdateContext: resourceDatadogLogsArchiveUpdate,
 		ReadContext:   resourceDatadogLogsArchiveRead,
 		DeleteContext: resourceDatadogLogsArchiveDelete,
+		CustomizeDiff: resourceDatadogLogsArchiveCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaFunc: func() map[string]*schema.Schema {
 			return map[string]*schema.Schema{
 				"name":  {Description: "Your archive name.", Type: schema.TypeString, Required: true},
-				"query": {Description: "The archive query/filter. Logs matching this query are included in the archive.", Type: schema.TypeString, Required: true},
+				"query": {Description: "The archive query/filter. Logs matching this query are included in the archive.", Type: schema.TypeString, Required: true, ValidateFunc: validators.ValidateLogsQuery},
 				"s3_archive": {
 					Description: "Definition of an s3 archive.",
 					Type:        schema.TypeList,
@@ -34,10 +49,31 @@ func resourceDatadogLogsArchive() *schema.Resource {
 					Optional:    true,
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
-							"bucket":     {Description: "Name of your s3 bucket.", Type: schema.TypeString, Required: true},
+							"bucket":     {Description: "Name of your s3 bucket.", Type: schema.TypeString, Required: true, ValidateFunc: validators.ValidateS3BucketName},
 							"path":       {Description: "Path where the archive is stored.", Type: schema.TypeString, Optional: true},
 							"account_id": {Description: "Your AWS account id.", Type: schema.TypeString, Required: true},
 							"role_name":  {Description: "Your AWS role name", Type: schema.TypeString, Required: true},
+							"encryption_type": {
+								Description:      "The type of server-side encryption applied to the archived logs. `SSE_KMS` requires `encryption_key`.",
+								Type:             schema.TypeString,
+								Optional:         true,
+								Computed:         true,
+								ValidateDiagFunc: validators.ValidateStringEnumValue(logsArchiveS3EncryptionSSES3, logsArchiveS3EncryptionSSEKMS),
+							},
+							"encryption_key": {
+								Description:  "The ARN of the AWS KMS key used to encrypt the archived logs when `encryption_type` is `SSE_KMS`.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								Computed:     true,
+								ValidateFunc: validation.StringMatch(kmsKeyARNRegex, "must be the ARN of an AWS KMS key or key alias"),
+							},
+							"storage_class": {
+								Description:      "The storage class of the archived logs.",
+								Type:             schema.TypeString,
+								Optional:         true,
+								Computed:         true,
+								ValidateDiagFunc: validators.ValidateStringEnumValue(s3StorageClasses...),
+							},
 						},
 					},
 				},
@@ -48,10 +84,10 @@ func resourceDatadogLogsArchive() *schema.Resource {
 					Optional:    true,
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
-							"container":       {Description: "The container where the archive is stored.", Type: schema.TypeString, Required: true},
+							"container":       {Description: "The container where the archive is stored.", Type: schema.TypeString, Required: true, ValidateFunc: validators.ValidateAzureContainerName},
 							"client_id":       {Description: "Your client id.", Type: schema.TypeString, Required: true},
 							"tenant_id":       {Description: "Your tenant id.", Type: schema.TypeString, Required: true},
-							"storage_account": {Description: "The associated storage account.", Type: schema.TypeString, Required: true},
+							"storage_account": {Description: "The associated storage account.", Type: schema.TypeString, Required: true, ValidateFunc: validators.ValidateAzureStorageAccountName},
 							"path":            {Description: "The path where the archive is stored.", Type: schema.TypeString, Optional: true},
 						},
 					},
@@ -63,7 +99,7 @@ func resourceDatadogLogsArchive() *schema.Resource {
 					Optional:    true,
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
-							"bucket":       {Description: "Name of your GCS bucket.", Type: schema.TypeString, Required: true},
+							"bucket":       {Description: "Name of your GCS bucket.", Type: schema.TypeString, Required: true, ValidateFunc: validators.ValidateGCSBucketName},
 							"path":         {Description: "Path where the archive is stored.", Type: schema.TypeString, Optional: true},
 							"client_email": {Description: "Your client email.", Type: schema.TypeString, Required: true},
 							"project_id":   {Description: "Your project id.", Type: schema.TypeString, Required: true},
@@ -94,6 +130,31 @@ func resourceDatadogLogsArchive() *schema.Resource {
 	}
 }
 
+func resourceDatadogLogsArchiveCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// The configuration is checked rather than the planned values, as the computed encryption key is kept from the
+	// state when it's removed from the configuration
+	return validateLogsArchiveS3Encryption(rawConfigFirstBlock(diff.GetRawConfig(), "s3_archive"))
+}
+
+func validateLogsArchiveS3Encryption(s3Archive cty.Value) error {
+	if !s3Archive.IsKnown() || s3Archive.IsNull() {
+		return nil
+	}
+	encryptionType := rawConfigAttr(s3Archive, "encryption_type")
+	encryptionKey := rawConfigAttr(s3Archive, "encryption_key")
+	if !encryptionType.IsKnown() || !encryptionKey.IsKnown() {
+		return nil
+	}
+	isKMS := !encryptionType.IsNull() && encryptionType.AsString() == logsArchiveS3EncryptionSSEKMS
+	if isKMS && encryptionKey.IsNull() {
+		return fmt.Errorf("s3_archive.encryption_key is required when encryption_type is `%s`", logsArchiveS3EncryptionSSEKMS)
+	}
+	if !isKMS && !encryptionKey.IsNull() {
+		return fmt.Errorf("s3_archive.encryption_key can only be set when encryption_type is `%s`", logsArchiveS3EncryptionSSEKMS)
+	}
+	return nil
+}
+
 func resourceDatadogLogsArchiveCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -248,6 +309,11 @@ func buildS3Map(destination datadogV2.LogsArchiveDestinationS3) map[string]inter
 	result["role_name"] = integration.GetRoleName()
 	result["bucket"] = destination.GetBucket()
 	result["path"] = destination.GetPath()
+	// The encryption and storage class options are supported by the archives API, but not by the client models yet
+	encryption, _ := destination.AdditionalProperties["encryption"].(map[string]interface{})
+	result["encryption_type"], _ = encryption["type"].(string)
+	result["encryption_key"], _ = encryption["key"].(string)
+	result["storage_class"], _ = destination.AdditionalProperties["storage_class"].(string)
 	return result
 }
 
@@ -418,9 +484,34 @@ func buildS3Destination(dest interface{}) (*datadogV2.LogsArchiveDestinationS3,
 		datadogV2.LOGSARCHIVEDESTINATIONS3TYPE_S3,
 	)
 	destination.Path = datadog.PtrString(path.(string))
+	options := map[string]interface{}{"storage_class": d["storage_class"]}
+	if encryptionType, _ := d["encryption_type"].(string); encryptionType != "" {
+		encryption := map[string]interface{}{"type": encryptionType}
+		if key, _ := d["encryption_key"].(string); key != "" && encryptionType == logsArchiveS3EncryptionSSEKMS {
+			encryption["key"] = key
+		}
+		options["encryption"] = encryption
+	}
+	destination.AdditionalProperties = buildArchiveDestinationOptions(options)
 	return destination, nil
 }
 
+// buildArchiveDestinationOptions returns the options set on the S3 destination, which aren't part of the client
+// models yet and are sent as additional properties
+func buildArchiveDestinationOptions(options map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range options {
+		if value == nil || value == "" {
+			continue
+		}
+		result[key] = value
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
 func getRehydrationTags(d *schema.ResourceData) []string {
 	tfList := d.Get("rehydration_tags").([]interface{})
 	ddList := make([]string, len(tfList))
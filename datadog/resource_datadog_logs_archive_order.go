@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -30,6 +31,13 @@ func resourceDatadogLogsArchiveOrder() *schema.Resource {
 					Optional:    true,
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
+				"mode": {
+					Description:      "How the `archive_ids` list is enforced. With `full`, it must list all the archives. With `relative`, only the relative order of the listed archives is enforced and the other archives keep their position.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          utils.OrderModeFull,
+					ValidateDiagFunc: validators.ValidateStringEnumValue(utils.OrderModeFull, utils.OrderModeRelative),
+				},
 			}
 		},
 	}
@@ -80,7 +88,13 @@ func resourceDatadogLogsArchiveOrderRead(ctx context.Context, d *schema.Resource
 }
 
 func updateLogsArchiveOrderState(d *schema.ResourceData, order *datadogV2.LogsArchiveOrder) diag.Diagnostics {
-	if err := d.Set("archive_ids", order.Data.Attributes.ArchiveIds); err != nil {
+	archiveIDs := order.Data.Attributes.ArchiveIds
+	if d.Get("mode").(string) == utils.OrderModeRelative {
+		archiveIDs = utils.FilterRelativeOrder(archiveIDs, getArchiveIds(d))
+	} else if err := d.Set("mode", utils.OrderModeFull); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("archive_ids", archiveIDs); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
@@ -95,6 +109,17 @@ func resourceDatadogLogsArchiveOrderUpdate(ctx context.Context, d *schema.Resour
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
 	auth := providerConf.Auth
+	if d.Get("mode").(string) == utils.OrderModeRelative {
+		currentOrder, httpResponse, err := apiInstances.GetLogsArchivesApiV2().GetLogsArchiveOrder(auth)
+		if err != nil {
+			return utils.TranslateClientErrorDiag(err, httpResponse, "error getting logs archive order")
+		}
+		archiveIDs, err := utils.MergeRelativeOrder(currentOrder.Data.Attributes.ArchiveIds, getArchiveIds(d))
+		if err != nil {
+			return diag.Errorf("cannot map archives to existing ones: %s", err)
+		}
+		ddArchiveList.Data.Attributes.SetArchiveIds(archiveIDs)
+	}
 	updatedOrder, httpResponse, err := apiInstances.GetLogsArchivesApiV2().UpdateLogsArchiveOrder(auth, *ddArchiveList)
 	if err != nil {
 		// Cannot map archives to existing ones
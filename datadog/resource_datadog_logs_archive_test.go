@@ -0,0 +1,111 @@
+package datadog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestValidateLogsArchiveS3Encryption(t *testing.T) {
+	keyARN := "arn:aws:kms:us-east-1:001234567888:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	cases := []struct {
+		name           string
+		encryptionType cty.Value
+		encryptionKey  cty.Value
+		err            string
+	}{
+		{name: "no encryption", encryptionType: cty.NullVal(cty.String), encryptionKey: cty.NullVal(cty.String)},
+		{name: "SSE_S3", encryptionType: cty.StringVal("SSE_S3"), encryptionKey: cty.NullVal(cty.String)},
+		{name: "SSE_KMS with a key", encryptionType: cty.StringVal("SSE_KMS"), encryptionKey: cty.StringVal(keyARN)},
+		{name: "SSE_KMS without a key", encryptionType: cty.StringVal("SSE_KMS"), encryptionKey: cty.NullVal(cty.String), err: "encryption_key is required"},
+		{name: "SSE_S3 with a key", encryptionType: cty.StringVal("SSE_S3"), encryptionKey: cty.StringVal(keyARN), err: "encryption_key can only be set"},
+		{name: "key without encryption type", encryptionType: cty.NullVal(cty.String), encryptionKey: cty.StringVal(keyARN), err: "encryption_key can only be set"},
+		{name: "unknown key", encryptionType: cty.StringVal("SSE_KMS"), encryptionKey: cty.UnknownVal(cty.String)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s3Archive := cty.ObjectVal(map[string]cty.Value{
+				"bucket":          cty.StringVal("my-bucket"),
+				"encryption_type": tc.encryptionType,
+				"encryption_key":  tc.encryptionKey,
+			})
+			err := validateLogsArchiveS3Encryption(s3Archive)
+			if (err == nil) != (tc.err == "") || (err != nil && !strings.Contains(err.Error(), tc.err)) {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	if err := validateLogsArchiveS3Encryption(cty.NullVal(cty.DynamicPseudoType)); err != nil {
+		t.Errorf("expected no error without an s3 archive, got %v", err)
+	}
+}
+
+func TestLogsArchiveBucketNameValidation(t *testing.T) {
+	cases := []struct {
+		name        string
+		destination string
+		archive     map[string]interface{}
+		err         string
+	}{
+		{
+			name:        "valid s3 bucket",
+			destination: "s3_archive",
+			archive:     map[string]interface{}{"bucket": "my-bucket", "account_id": "001234567888", "role_name": "role"},
+		},
+		{
+			name:        "invalid s3 bucket",
+			destination: "s3_archive",
+			archive:     map[string]interface{}{"bucket": "My_Bucket", "account_id": "001234567888", "role_name": "role"},
+			err:         "can only contain lowercase letters, numbers, dots and hyphens",
+		},
+		{
+			name:        "valid gcs bucket",
+			destination: "gcs_archive",
+			archive:     map[string]interface{}{"bucket": "my_bucket", "client_email": "email@example.com", "project_id": "project"},
+		},
+		{
+			name:        "invalid gcs bucket",
+			destination: "gcs_archive",
+			archive:     map[string]interface{}{"bucket": "google-logs", "client_email": "email@example.com", "project_id": "project"},
+			err:         "must not start with `goog` or contain `google`",
+		},
+		{
+			name:        "valid azure container",
+			destination: "azure_archive",
+			archive:     map[string]interface{}{"container": "logs-container", "client_id": "client", "tenant_id": "tenant", "storage_account": "storageaccount"},
+		},
+		{
+			name:        "invalid azure container",
+			destination: "azure_archive",
+			archive:     map[string]interface{}{"container": "logs--container", "client_id": "client", "tenant_id": "tenant", "storage_account": "storageaccount"},
+			err:         "can only contain lowercase letters, numbers and single hyphens",
+		},
+		{
+			name:        "invalid azure storage account",
+			destination: "azure_archive",
+			archive:     map[string]interface{}{"container": "logs", "client_id": "client", "tenant_id": "tenant", "storage_account": "storage-account"},
+			err:         "must be between 3 and 24 characters long",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":         "archive",
+				"query":        "service:myservice",
+				tc.destination: []interface{}{tc.archive},
+			})
+			diags := resourceDatadogLogsArchive().Validate(config)
+			var errs []string
+			for _, d := range diags {
+				errs = append(errs, d.Summary)
+			}
+			err := strings.Join(errs, "; ")
+			if (err == "") != (tc.err == "") || !strings.Contains(err, tc.err) {
+				t.Errorf("expected error %q, got %q", tc.err, err)
+			}
+		})
+	}
+}
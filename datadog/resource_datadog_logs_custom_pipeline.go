@@ -2,6 +2,7 @@ package datadog
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -12,6 +13,7 @@ import (
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
@@ -34,8 +36,18 @@ const (
 	tfTraceIDRemapperProcessor      = "trace_id_remapper"
 	tfURLParserProcessor            = "url_parser"
 	tfUserAgentParserProcessor      = "user_agent_parser"
+	tfSpanIDRemapperProcessor       = "span_id_remapper"
+	tfArrayProcessor                = "array_processor"
+	tfDecoderProcessor              = "decoder_processor"
+	tfRawProcessor                  = "raw_processor"
 	// This type string is used to differentiate between LookupProcessor and ReferenceTableLookupProcessor, due to them sharing a `type` in the API.
 	ddReferenceTableLookupProcessor = "reference-table-" + string(datadogV1.LOGSLOOKUPPROCESSORTYPE_LOOKUP_PROCESSOR)
+	// These processors aren't modeled by the API client yet, they're sent and read as raw objects.
+	ddSpanIDRemapperProcessor = "span-id-remapper"
+	ddArrayProcessor          = "array-processor"
+	ddDecoderProcessor        = "decoder-processor"
+	// Any other processor type, like the OCSF schema processor, is kept as its JSON definition.
+	ddRawProcessor = "raw"
 )
 
 var tfProcessorTypes = map[string]string{
@@ -55,6 +67,10 @@ var tfProcessorTypes = map[string]string{
 	tfTraceIDRemapperProcessor:      string(datadogV1.LOGSTRACEREMAPPERTYPE_TRACE_ID_REMAPPER),
 	tfURLParserProcessor:            string(datadogV1.LOGSURLPARSERTYPE_URL_PARSER),
 	tfUserAgentParserProcessor:      string(datadogV1.LOGSUSERAGENTPARSERTYPE_USER_AGENT_PARSER),
+	tfSpanIDRemapperProcessor:       ddSpanIDRemapperProcessor,
+	tfArrayProcessor:                ddArrayProcessor,
+	tfDecoderProcessor:              ddDecoderProcessor,
+	tfRawProcessor:                  ddRawProcessor,
 }
 
 var tfProcessors = map[string]*schema.Schema{
@@ -73,6 +89,10 @@ var tfProcessors = map[string]*schema.Schema{
 	tfTraceIDRemapperProcessor:      traceIDRemapper,
 	tfURLParserProcessor:            urlParser,
 	tfUserAgentParserProcessor:      userAgentParser,
+	tfSpanIDRemapperProcessor:       spanIDRemapper,
+	tfArrayProcessor:                arrayProcessor,
+	tfDecoderProcessor:              decoderProcessor,
+	tfRawProcessor:                  rawProcessor,
 }
 
 var ddProcessorTypes = map[string]string{
@@ -92,6 +112,10 @@ var ddProcessorTypes = map[string]string{
 	string(datadogV1.LOGSTRACEREMAPPERTYPE_TRACE_ID_REMAPPER):                 tfTraceIDRemapperProcessor,
 	string(datadogV1.LOGSURLPARSERTYPE_URL_PARSER):                            tfURLParserProcessor,
 	string(datadogV1.LOGSUSERAGENTPARSERTYPE_USER_AGENT_PARSER):               tfUserAgentParserProcessor,
+	ddSpanIDRemapperProcessor:                                                 tfSpanIDRemapperProcessor,
+	ddArrayProcessor:                                                          tfArrayProcessor,
+	ddDecoderProcessor:                                                        tfDecoderProcessor,
+	ddRawProcessor:                                                            tfRawProcessor,
 }
 
 var arithmeticProcessor = &schema.Schema{
@@ -216,7 +240,7 @@ var grokParser = &schema.Schema{
 			"is_enabled": {Description: "If the processor is enabled or not.", Type: schema.TypeBool, Optional: true},
 			"source":     {Description: "Name of the log attribute to parse.", Type: schema.TypeString, Required: true},
 			"samples": {
-				Description: "List of sample logs for this parser. It can save up to 5 samples. Each sample takes up to 5000 characters.",
+				Description: "List of sample logs for this parser. It can save up to 5 samples. Each sample takes up to 5000 characters. A warning is shown for samples which don't match any of the `match_rules`.",
 				Type:        schema.TypeList,
 				Optional:    true,
 				Elem: &schema.Schema{
@@ -272,7 +296,7 @@ var referenceTableLookupProcessor = &schema.Schema{
 			"is_enabled":              {Description: "If the processor is enabled or not.", Type: schema.TypeBool, Optional: true},
 			"source":                  {Description: "Name of the source attribute used to do the lookup.", Type: schema.TypeString, Required: true},
 			"target":                  {Description: "Name of the attribute that contains the result of the lookup.", Type: schema.TypeString, Required: true},
-			"lookup_enrichment_table": {Description: "Name of the Reference Table for the source attribute and their associated target attribute values.", Type: schema.TypeString, Required: true},
+			"lookup_enrichment_table": {Description: "Name of the Reference Table for the source attribute and their associated target attribute values. Attributes read below `target` by the following processors are checked against the columns of the table at plan time, which fetches each referenced table once per plan.", Type: schema.TypeString, Required: true},
 		},
 	},
 }
@@ -333,6 +357,127 @@ var traceIDRemapper = &schema.Schema{
 	},
 }
 
+var spanIDRemapper = &schema.Schema{
+	Type:        schema.TypeList,
+	MaxItems:    1,
+	Description: "Span ID Remapper Processor. More information can be found in the [official docs](https://docs.datadoghq.com/logs/log_configuration/processors/?tab=ui#span-remapper)",
+	Optional:    true,
+	Elem: &schema.Resource{
+		Schema: sourceRemapper,
+	},
+}
+
+var arrayProcessor = &schema.Schema{
+	Type:        schema.TypeList,
+	MaxItems:    1,
+	Description: "Array Processor. More information can be found in the [official docs](https://docs.datadoghq.com/logs/log_configuration/processors/?tab=ui#array-processor)",
+	Optional:    true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":       {Description: "Name of the processor.", Type: schema.TypeString, Optional: true},
+			"is_enabled": {Description: "If the processor is enabled or not.", Type: schema.TypeBool, Optional: true},
+			"operation": {
+				Description: "Operation to perform on the array. Exactly one of `select`, `append` or `length` must be set.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Required:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"select": {
+							Description: "Extract a value from the first element of an array of objects matching a filter.",
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"source":           {Description: "Attribute path of the array to search.", Type: schema.TypeString, Required: true},
+									"target":           {Description: "Attribute receiving the extracted value.", Type: schema.TypeString, Required: true},
+									"filter":           {Description: "Filter expression matching the element, for example `name:Referrer`.", Type: schema.TypeString, Required: true},
+									"value_to_extract": {Description: "Key of the value to extract from the matching element.", Type: schema.TypeString, Required: true},
+								},
+							},
+						},
+						"append": {
+							Description: "Append an attribute value to the end of an array.",
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"source":          {Description: "Attribute path of the value to append.", Type: schema.TypeString, Required: true},
+									"target":          {Description: "Attribute path of the array to append to.", Type: schema.TypeString, Required: true},
+									"preserve_source": {Description: "Whether to keep the source attribute after appending it.", Type: schema.TypeBool, Optional: true, Default: true},
+								},
+							},
+						},
+						"length": {
+							Description: "Compute the number of elements of an array.",
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"source": {Description: "Attribute path of the array.", Type: schema.TypeString, Required: true},
+									"target": {Description: "Attribute receiving the length of the array.", Type: schema.TypeString, Required: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+var decoderProcessor = &schema.Schema{
+	Type:        schema.TypeList,
+	MaxItems:    1,
+	Description: "Decoder Processor. More information can be found in the [official docs](https://docs.datadoghq.com/logs/log_configuration/processors/?tab=ui#decoder-processor)",
+	Optional:    true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":       {Description: "Name of the processor.", Type: schema.TypeString, Optional: true},
+			"is_enabled": {Description: "If the processor is enabled or not.", Type: schema.TypeBool, Optional: true},
+			"source":     {Description: "Name of the log attribute with the encoded data.", Type: schema.TypeString, Required: true},
+			"target":     {Description: "Name of the log attribute that contains the decoded data.", Type: schema.TypeString, Required: true},
+			"binary_to_text_encoding": {
+				Description:  "The encoding used to represent the binary data.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"base64", "base16"}, false),
+			},
+			"input_representation": {
+				Description:  "The original representation of the input data.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"utf_8", "integer"}, false),
+			},
+		},
+	},
+}
+
+var rawProcessor = &schema.Schema{
+	Type:        schema.TypeList,
+	MaxItems:    1,
+	Description: "Processor not yet supported by the other processor blocks, given as its JSON definition. It is the only way to manage processors like the OCSF schema processor or the threat intel processor. Processors of unknown types are imported as such.",
+	Optional:    true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"definition": {
+				Description:  "The JSON formatted definition of the processor, including its `type`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					attrMap, _ := structure.ExpandJsonFromString(v.(string))
+					res, _ := flattenLogsRawProcessor(attrMap)
+					return res
+				},
+			},
+		},
+	},
+}
+
 var sourceRemapper = map[string]*schema.Schema{
 	"name":       {Description: "Name of the processor.", Type: schema.TypeString, Optional: true},
 	"is_enabled": {Description: "If the processor is enabled or not.", Type: schema.TypeBool, Optional: true},
@@ -377,6 +522,7 @@ func resourceDatadogLogsCustomPipeline() *schema.Resource {
 		UpdateContext: resourceDatadogLogsPipelineUpdate,
 		ReadContext:   resourceDatadogLogsPipelineRead,
 		DeleteContext: resourceDatadogLogsPipelineDelete,
+		CustomizeDiff: resourceDatadogLogsPipelineCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -387,6 +533,41 @@ func resourceDatadogLogsCustomPipeline() *schema.Resource {
 	}
 }
 
+// resourceDatadogLogsPipelineCustomizeDiff builds the processors at plan time to validate them, skipping the
+// ones depending on values which are only known after apply.
+func resourceDatadogLogsPipelineCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	rawProcessors := rawConfigAttr(diff.GetRawConfig(), "processor")
+	if !rawProcessors.IsKnown() || rawProcessors.IsNull() {
+		return nil
+	}
+	tfProcessors, _ := diff.Get("processor").([]interface{})
+	var ddProcessors []datadogV1.LogsProcessor
+	for i, rawProcessor := range rawProcessors.AsValueSlice() {
+		if i >= len(tfProcessors) || !rawProcessor.IsWhollyKnown() {
+			continue
+		}
+		processors, err := buildDatadogProcessors(tfProcessors[i : i+1])
+		if err != nil {
+			return fmt.Errorf("processor.%d: %s", i, err)
+		}
+		ddProcessors = append(ddProcessors, *processors...)
+	}
+
+	providerConf, ok := meta.(*ProviderConfiguration)
+	if !ok {
+		return nil
+	}
+	var processors []interface{}
+	encoded, _ := json.Marshal(ddProcessors)
+	if err := json.Unmarshal(encoded, &processors); err != nil {
+		return nil
+	}
+	// Tables which can't be fetched, for instance because they are created by the same apply, aren't checked
+	return checkReferenceTableLookupColumns(processors, make(map[string]string), func(table string) ([]string, bool) {
+		return getCachedReferenceTableColumns(providerConf.Auth, providerConf.DatadogApiInstances, table)
+	})
+}
+
 func resourceDatadogLogsPipelineCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -403,11 +584,12 @@ func resourceDatadogLogsPipelineCreate(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return utils.TranslateClientErrorDiag(err, httpResponse, "failed to create logs pipeline using Datadog API")
 	}
-	if err := utils.CheckForUnparsed(createdPipeline); err != nil {
+	if err := checkLogsPipelineForUnparsed(createdPipeline); err != nil {
 		return diag.FromErr(err)
 	}
+	diags := logsGrokSampleWarnings(ddPipeline.GetProcessors(), "")
 	d.SetId(*createdPipeline.Id)
-	return updateLogsCustomPipelineState(d, &createdPipeline)
+	return append(diags, updateLogsCustomPipelineState(d, &createdPipeline)...)
 }
 
 func updateLogsCustomPipelineState(d *schema.ResourceData, pipeline *datadogV1.LogsPipeline) diag.Diagnostics {
@@ -443,7 +625,7 @@ func resourceDatadogLogsPipelineRead(ctx context.Context, d *schema.ResourceData
 		}
 		return utils.TranslateClientErrorDiag(err, httpresp, "failed to get logs pipeline using Datadog API")
 	}
-	if err := utils.CheckForUnparsed(ddPipeline); err != nil {
+	if err := checkLogsPipelineForUnparsed(ddPipeline); err != nil {
 		return diag.FromErr(err)
 	}
 	return updateLogsCustomPipelineState(d, &ddPipeline)
@@ -465,10 +647,11 @@ func resourceDatadogLogsPipelineUpdate(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return utils.TranslateClientErrorDiag(err, httpResponse, "error updating logs pipeline")
 	}
-	if err := utils.CheckForUnparsed(updatedPipeline); err != nil {
+	if err := checkLogsPipelineForUnparsed(updatedPipeline); err != nil {
 		return diag.FromErr(err)
 	}
-	return updateLogsCustomPipelineState(d, &updatedPipeline)
+	diags := logsGrokSampleWarnings(ddPipeline.GetProcessors(), "")
+	return append(diags, updateLogsCustomPipelineState(d, &updatedPipeline)...)
 }
 
 func resourceDatadogLogsPipelineDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -554,6 +737,8 @@ func buildTerraformProcessor(ddProcessor datadogV1.LogsProcessor) (map[string]in
 	} else if ddProcessor.LogsUserAgentParser != nil {
 		tfProcessor = buildTerraformUserAgentParser(ddProcessor.LogsUserAgentParser)
 		processorType = string(datadogV1.LOGSUSERAGENTPARSERTYPE_USER_AGENT_PARSER)
+	} else if ddRaw, ok := ddProcessor.UnparsedObject.(map[string]interface{}); ok {
+		tfProcessor, processorType, err = buildTerraformRawProcessor(ddRaw)
 	} else {
 		err = fmt.Errorf("failed to support datadogV1 processor type, %s", ddProcessor.GetActualInstance())
 	}
@@ -813,7 +998,11 @@ func buildDatadogProcessor(ddProcessorType string, tfProcessor map[string]interf
 	case string(datadogV1.LOGSGEOIPPARSERTYPE_GEO_IP_PARSER):
 		ddProcessor = datadogV1.LogsGeoIPParserAsLogsProcessor(buildDatadogGeoIPParser(tfProcessor))
 	case string(datadogV1.LOGSGROKPARSERTYPE_GROK_PARSER):
-		ddProcessor = datadogV1.LogsGrokParserAsLogsProcessor(buildDatadogGrokParser(tfProcessor))
+		ddGrokParser, err := buildDatadogGrokParser(tfProcessor)
+		if err != nil {
+			return &ddProcessor, err
+		}
+		ddProcessor = datadogV1.LogsGrokParserAsLogsProcessor(ddGrokParser)
 	case string(datadogV1.LOGSLOOKUPPROCESSORTYPE_LOOKUP_PROCESSOR):
 		ddProcessor = datadogV1.LogsLookupProcessorAsLogsProcessor(buildDatadogLookupProcessor(tfProcessor))
 	case ddReferenceTableLookupProcessor:
@@ -832,6 +1021,22 @@ func buildDatadogProcessor(ddProcessorType string, tfProcessor map[string]interf
 		ddProcessor = datadogV1.LogsStringBuilderProcessorAsLogsProcessor(ddStringBuilderProcessor)
 	case string(datadogV1.LOGSURLPARSERTYPE_URL_PARSER):
 		ddProcessor = datadogV1.LogsURLParserAsLogsProcessor(buildDatadogURLParser(tfProcessor))
+	case ddSpanIDRemapperProcessor:
+		ddProcessor = datadogV1.LogsProcessor{UnparsedObject: buildDatadogSpanIDRemapper(tfProcessor)}
+	case ddArrayProcessor:
+		ddArray, err := buildDatadogArrayProcessor(tfProcessor)
+		if err != nil {
+			return &ddProcessor, err
+		}
+		ddProcessor = datadogV1.LogsProcessor{UnparsedObject: ddArray}
+	case ddDecoderProcessor:
+		ddProcessor = datadogV1.LogsProcessor{UnparsedObject: buildDatadogDecoderProcessor(tfProcessor)}
+	case ddRawProcessor:
+		ddRaw, err := buildDatadogRawProcessor(tfProcessor)
+		if err != nil {
+			return &ddProcessor, err
+		}
+		ddProcessor = datadogV1.LogsProcessor{UnparsedObject: ddRaw}
 	case string(datadogV1.LOGSUSERAGENTPARSERTYPE_USER_AGENT_PARSER):
 		ddProcessor = datadogV1.LogsUserAgentParserAsLogsProcessor(buildDatadogUserAgentParser(tfProcessor))
 	default:
@@ -990,7 +1195,7 @@ func buildDatadogGeoIPParser(tfProcessor map[string]interface{}) *datadogV1.Logs
 	return ddGeoIPParser
 }
 
-func buildDatadogGrokParser(tfProcessor map[string]interface{}) *datadogV1.LogsGrokParser {
+func buildDatadogGrokParser(tfProcessor map[string]interface{}) (*datadogV1.LogsGrokParser, error) {
 	ddGrokParser := datadogV1.NewLogsGrokParserWithDefaults()
 	if tfSource, exists := tfProcessor["source"].(string); exists {
 		ddGrokParser.SetSource(tfSource)
@@ -1021,7 +1226,54 @@ func buildDatadogGrokParser(tfProcessor map[string]interface{}) *datadogV1.LogsG
 	if tfIsEnabled, exists := tfProcessor["is_enabled"].(bool); exists {
 		ddGrokParser.SetIsEnabled(tfIsEnabled)
 	}
-	return ddGrokParser
+	return ddGrokParser, validateDatadogGrokParser(ddGrokParser)
+}
+
+// validateDatadogGrokParser compiles the rules of a grok parser, as the API accepts rules referencing unknown
+// patterns or filters.
+func validateDatadogGrokParser(ddGrokParser *datadogV1.LogsGrokParser) error {
+	if _, err := compileGrokParser(ddGrokParser.Grok.GetMatchRules(), ddGrokParser.Grok.GetSupportRules()); err != nil {
+		return fmt.Errorf("%s: %s", logsGrokParserName(ddGrokParser), err)
+	}
+	return nil
+}
+
+// logsGrokSampleWarnings warns about the samples of grok parsers not matching any of their rules. The local grok
+// parser is an approximation of the Datadog one, so mismatches don't fail the apply.
+func logsGrokSampleWarnings(ddProcessors []datadogV1.LogsProcessor, path string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for i, ddProcessor := range ddProcessors {
+		processorPath := fmt.Sprintf("%sprocessor.%d", path, i)
+		if ddProcessor.LogsPipelineProcessor != nil {
+			diags = append(diags, logsGrokSampleWarnings(ddProcessor.LogsPipelineProcessor.GetProcessors(), processorPath+".pipeline.0.")...)
+			continue
+		}
+		ddGrokParser := ddProcessor.LogsGrokParser
+		if ddGrokParser == nil {
+			continue
+		}
+		grok, err := compileGrokParser(ddGrokParser.Grok.GetMatchRules(), ddGrokParser.Grok.GetSupportRules())
+		if err != nil {
+			continue
+		}
+		for j, sample := range ddGrokParser.GetSamples() {
+			if _, _, matched := grok.parse(sample); !matched {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("%s: samples.%d doesn't match any of the rules %s", logsGrokParserName(ddGrokParser), j, strings.Join(grok.ruleNames(), ", ")),
+					Detail:   fmt.Sprintf("The sample %q of %s.grok_parser.0 doesn't match any rule with the local grok parser. Check it with the Datadog parser.", sample, processorPath),
+				})
+			}
+		}
+	}
+	return diags
+}
+
+func logsGrokParserName(ddGrokParser *datadogV1.LogsGrokParser) string {
+	if ddGrokParser.GetName() != "" {
+		return fmt.Sprintf("%s %q", tfGrokParserProcessor, ddGrokParser.GetName())
+	}
+	return tfGrokParserProcessor
 }
 
 func buildDatadogMessageRemapper(tfProcessor map[string]interface{}) *datadogV1.LogsMessageRemapper {
@@ -1080,6 +1332,173 @@ func buildDatadogTraceRemapper(tfProcessor map[string]interface{}) *datadogV1.Lo
 	return ddRemapper
 }
 
+func buildDatadogSpanIDRemapper(tfProcessor map[string]interface{}) map[string]interface{} {
+	ddRemapper := map[string]interface{}{
+		"type":    ddSpanIDRemapperProcessor,
+		"sources": buildDatadogSources(tfProcessor),
+	}
+	if tfName, exists := tfProcessor["name"].(string); exists {
+		ddRemapper["name"] = tfName
+	}
+	if tfIsEnabled, exists := tfProcessor["is_enabled"].(bool); exists {
+		ddRemapper["is_enabled"] = tfIsEnabled
+	}
+	return ddRemapper
+}
+
+func buildDatadogArrayProcessor(tfProcessor map[string]interface{}) (map[string]interface{}, error) {
+	ddArray := map[string]interface{}{
+		"type": ddArrayProcessor,
+	}
+	if tfOperations, exists := tfProcessor["operation"].([]interface{}); exists && len(tfOperations) > 0 {
+		tfOperation, _ := tfOperations[0].(map[string]interface{})
+		var ddOperation map[string]interface{}
+		for _, operationType := range []string{"select", "append", "length"} {
+			if tfDetails, exists := tfOperation[operationType].([]interface{}); exists && len(tfDetails) > 0 {
+				if ddOperation != nil {
+					return nil, fmt.Errorf("%s: only one of select, append or length can be set in operation", tfArrayProcessor)
+				}
+				ddOperation = map[string]interface{}{"type": operationType}
+				for key, value := range tfDetails[0].(map[string]interface{}) {
+					ddOperation[key] = value
+				}
+			}
+		}
+		if ddOperation == nil {
+			return nil, fmt.Errorf("%s: one of select, append or length must be set in operation", tfArrayProcessor)
+		}
+		ddArray["operation"] = ddOperation
+	}
+	if tfName, exists := tfProcessor["name"].(string); exists {
+		ddArray["name"] = tfName
+	}
+	if tfIsEnabled, exists := tfProcessor["is_enabled"].(bool); exists {
+		ddArray["is_enabled"] = tfIsEnabled
+	}
+	return ddArray, nil
+}
+
+func buildDatadogDecoderProcessor(tfProcessor map[string]interface{}) map[string]interface{} {
+	ddDecoder := map[string]interface{}{
+		"type": ddDecoderProcessor,
+	}
+	for _, key := range []string{"source", "target", "binary_to_text_encoding", "input_representation", "name"} {
+		if value, exists := tfProcessor[key].(string); exists {
+			ddDecoder[key] = value
+		}
+	}
+	if tfIsEnabled, exists := tfProcessor["is_enabled"].(bool); exists {
+		ddDecoder["is_enabled"] = tfIsEnabled
+	}
+	return ddDecoder
+}
+
+func buildDatadogRawProcessor(tfProcessor map[string]interface{}) (map[string]interface{}, error) {
+	ddRaw, err := structure.ExpandJsonFromString(tfProcessor["definition"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", tfRawProcessor, err)
+	}
+	processorType, _ := ddRaw["type"].(string)
+	if processorType == "" {
+		return nil, fmt.Errorf("%s: the definition must contain the type of the processor", tfRawProcessor)
+	}
+	if tfProcessorType, ok := ddProcessorTypes[processorType]; ok && processorType != ddRawProcessor {
+		return nil, fmt.Errorf("%s: processors of type %q must be defined with the %s block", tfRawProcessor, processorType, tfProcessorType)
+	}
+	return ddRaw, nil
+}
+
+// buildTerraformRawProcessor converts a processor the API client doesn't know, keeping its JSON definition for the
+// types which don't have a dedicated block
+func buildTerraformRawProcessor(ddRaw map[string]interface{}) (map[string]interface{}, string, error) {
+	ddType, _ := ddRaw["type"].(string)
+	tfProcessor := map[string]interface{}{}
+	switch ddType {
+	case ddSpanIDRemapperProcessor:
+		tfProcessor["sources"] = ddRaw["sources"]
+	case ddArrayProcessor:
+		if ddOperation, ok := ddRaw["operation"].(map[string]interface{}); ok {
+			operationType, _ := ddOperation["type"].(string)
+			tfDetails := make(map[string]interface{})
+			for key, value := range ddOperation {
+				if key != "type" {
+					tfDetails[key] = value
+				}
+			}
+			tfProcessor["operation"] = []map[string]interface{}{{operationType: []map[string]interface{}{tfDetails}}}
+		}
+	case ddDecoderProcessor:
+		for _, key := range []string{"source", "target", "binary_to_text_encoding", "input_representation"} {
+			tfProcessor[key] = ddRaw[key]
+		}
+	default:
+		definition, err := flattenLogsRawProcessor(ddRaw)
+		if err != nil {
+			return nil, "", err
+		}
+		return map[string]interface{}{"definition": definition}, ddRawProcessor, nil
+	}
+	tfProcessor["name"], _ = ddRaw["name"].(string)
+	tfProcessor["is_enabled"], _ = ddRaw["is_enabled"].(bool)
+	return tfProcessor, ddType, nil
+}
+
+// flattenLogsRawProcessor returns the JSON definition of a processor without the fields set to their default value
+func flattenLogsRawProcessor(ddRaw map[string]interface{}) (string, error) {
+	prepLogsProcessorsJSON(map[string]interface{}{"processors": []interface{}{ddRaw}})
+	return structure.FlattenJsonToString(ddRaw)
+}
+
+// checkLogsPipelineForUnparsed returns an error when a part of the pipeline couldn't be parsed, ignoring the
+// processors of types unknown to the API client which are managed as raw objects. Processors of types the API client
+// knows are still reported when they can't be parsed, instead of being silently handled as raw objects.
+func checkLogsPipelineForUnparsed(ddPipeline datadogV1.LogsPipeline) error {
+	ddProcessors := ddPipeline.Processors
+	ddPipeline.Processors = nil
+	if err := utils.CheckForUnparsed(ddPipeline); err != nil {
+		return err
+	}
+	return checkLogsProcessorsForUnparsed(ddProcessors)
+}
+
+func checkLogsProcessorsForUnparsed(ddProcessors []datadogV1.LogsProcessor) error {
+	for _, ddProcessor := range ddProcessors {
+		if ddRaw, ok := ddProcessor.UnparsedObject.(map[string]interface{}); ok && isLogsRawProcessorType(ddRaw["type"]) {
+			continue
+		}
+		if ddProcessor.LogsPipelineProcessor != nil {
+			ddNestedPipeline := *ddProcessor.LogsPipelineProcessor
+			ddNestedProcessors := ddNestedPipeline.Processors
+			ddNestedPipeline.Processors = nil
+			if err := utils.CheckForUnparsed(ddNestedPipeline); err != nil {
+				return err
+			}
+			if err := checkLogsProcessorsForUnparsed(ddNestedProcessors); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := utils.CheckForUnparsed(ddProcessor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isLogsRawProcessorType returns whether processors of a type are unknown to the API client, and managed as raw
+// objects through dedicated blocks or raw_processor
+func isLogsRawProcessorType(ddType interface{}) bool {
+	processorType, _ := ddType.(string)
+	switch processorType {
+	case "":
+		return false
+	case ddSpanIDRemapperProcessor, ddArrayProcessor, ddDecoderProcessor:
+		return true
+	}
+	_, known := ddProcessorTypes[processorType]
+	return !known
+}
+
 func buildDatadogCategoryProcessor(tfProcessor map[string]interface{}) *datadogV1.LogsCategoryProcessor {
 	ddCategory := datadogV1.NewLogsCategoryProcessorWithDefaults()
 	if tfTarget, exists := tfProcessor["target"].(string); exists {
@@ -1244,9 +1663,10 @@ func getFilterSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"query": {
-				Description: "Filter criteria of the category.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:  "Filter criteria of the category.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validators.ValidateLogsQuery,
 			},
 		},
 	}
@@ -0,0 +1,31 @@
+package datadog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestLogsGrokSampleWarnings(t *testing.T) {
+	grokParser := datadogV1.NewLogsGrokParser(datadogV1.LogsGrokParserRules{MatchRules: `access %{word:http.method} %{integer:http.status_code}`}, "message", datadogV1.LOGSGROKPARSERTYPE_GROK_PARSER)
+	grokParser.SetName("access logs")
+	grokParser.SetSamples([]string{"GET 200", "GET OK"})
+	nested := datadogV1.NewLogsPipelineProcessorWithDefaults()
+	nested.SetProcessors([]datadogV1.LogsProcessor{datadogV1.LogsGrokParserAsLogsProcessor(grokParser)})
+	processors := []datadogV1.LogsProcessor{
+		datadogV1.LogsGrokParserAsLogsProcessor(grokParser),
+		datadogV1.LogsPipelineProcessorAsLogsProcessor(nested),
+	}
+
+	diags := logsGrokSampleWarnings(processors, "")
+	if len(diags) != 2 {
+		t.Fatalf("expected a warning for each parser, got %v", diags)
+	}
+	for i, path := range []string{"processor.0.grok_parser.0", "processor.1.pipeline.0.processor.0.grok_parser.0"} {
+		if diags[i].Severity != diag.Warning || diags[i].Summary != `grok_parser "access logs": samples.1 doesn't match any of the rules access` || !strings.Contains(diags[i].Detail, `"GET OK" of `+path) {
+			t.Errorf("unexpected warning %+v", diags[i])
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package datadog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var logsPipelineComputedFields = []string{
+	"id",
+	"type",
+	"is_read_only",
+}
+
+// Values set by the API when they are omitted from the definition, removed so definitions exported from the UI don't show diffs
+var logsPipelineDefaults = map[string]interface{}{
+	"name":        "",
+	"is_enabled":  false,
+	"processors":  []interface{}{},
+	"tags":        []interface{}{},
+	"description": "",
+}
+
+var logsProcessorDefaults = map[string]map[string]interface{}{
+	"": {
+		"name":       "",
+		"is_enabled": false,
+	},
+	"arithmetic-processor": {
+		"is_replace_missing": false,
+	},
+	"attribute-remapper": {
+		"source_type":          "attribute",
+		"target_type":          "attribute",
+		"target_format":        "auto",
+		"preserve_source":      false,
+		"override_on_conflict": false,
+	},
+	"geo-ip-parser": {
+		"ip_processing_behavior": "do-nothing",
+	},
+	"grok-parser": {
+		"samples": []interface{}{},
+	},
+	"pipeline": logsPipelineDefaults,
+	"string-builder-processor": {
+		"is_replace_missing": false,
+	},
+	"url-parser": {
+		"normalize_ending_slashes": false,
+	},
+	"user-agent-parser": {
+		"is_encoded": false,
+	},
+}
+
+const logsPipelinePath = "/api/v1/logs/config/pipelines"
+
+func resourceDatadogLogsCustomPipelineJSON() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Datadog logs custom pipeline JSON resource. This can be used to create and manage Datadog logs pipelines using the JSON definition, for example exported from the Datadog UI. As for the `datadog_logs_custom_pipeline` resource, the order of the pipelines is maintained in the `datadog_logs_pipeline_order` resource.",
+		CreateContext: resourceDatadogLogsCustomPipelineJSONCreate,
+		ReadContext:   resourceDatadogLogsCustomPipelineJSONRead,
+		UpdateContext: resourceDatadogLogsCustomPipelineJSONUpdate,
+		DeleteContext: resourceDatadogLogsCustomPipelineJSONDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"pipeline": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsJSON,
+					StateFunc: func(v interface{}) string {
+						attrMap, _ := structure.ExpandJsonFromString(v.(string))
+						prepLogsPipelineJSON(attrMap)
+						res, _ := structure.FlattenJsonToString(attrMap)
+						return res
+					},
+					Description: "The JSON formatted definition of the pipeline. The `id`, `type` and `is_read_only` fields are ignored, as well as the fields set to their default value.",
+				},
+			}
+		},
+	}
+}
+
+// prepLogsPipelineJSON removes the computed fields of a pipeline and the default values of its processors
+func prepLogsPipelineJSON(pipeline map[string]interface{}) {
+	for _, f := range logsPipelineComputedFields {
+		delete(pipeline, f)
+	}
+	prepLogsProcessorsJSON(pipeline)
+	removeLogsDefaults(pipeline, logsPipelineDefaults)
+}
+
+func prepLogsProcessorsJSON(pipeline map[string]interface{}) {
+	processors, _ := pipeline["processors"].([]interface{})
+	for _, processor := range processors {
+		processor, ok := processor.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		processorType, _ := processor["type"].(string)
+		if processorType == "pipeline" {
+			prepLogsProcessorsJSON(processor)
+		}
+		removeLogsDefaults(processor, logsProcessorDefaults[""])
+		removeLogsDefaults(processor, logsProcessorDefaults[processorType])
+	}
+}
+
+func removeLogsDefaults(object map[string]interface{}, defaults map[string]interface{}) {
+	for key, value := range defaults {
+		if v, ok := object[key]; ok && (v == nil || reflect.DeepEqual(v, value)) {
+			delete(object, key)
+		}
+	}
+}
+
+func resourceDatadogLogsCustomPipelineJSONRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "GET", logsPipelinePath+"/"+d.Id(), nil)
+	if err != nil {
+		// API returns 400 when the specific pipeline id doesn't exist
+		if httpResp != nil && (httpResp.StatusCode == http.StatusBadRequest || httpResp.StatusCode == http.StatusNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return utils.TranslateClientErrorDiag(err, httpResp, "failed to get logs pipeline using Datadog API")
+	}
+
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return updateLogsCustomPipelineJSONState(d, respMap)
+}
+
+func resourceDatadogLogsCustomPipelineJSONCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	logCustomPipelineMutex.Lock()
+	defer logCustomPipelineMutex.Unlock()
+
+	pipeline, err := buildLogsCustomPipelineJSON(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "POST", logsPipelinePath, &pipeline)
+	if err != nil {
+		return utils.TranslateClientErrorDiag(err, httpResp, "failed to create logs pipeline using Datadog API")
+	}
+
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, ok := respMap["id"].(string)
+	if !ok {
+		return diag.FromErr(errors.New("error retrieving id from response"))
+	}
+	d.SetId(id)
+
+	return updateLogsCustomPipelineJSONState(d, respMap)
+}
+
+func resourceDatadogLogsCustomPipelineJSONUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	logCustomPipelineMutex.Lock()
+	defer logCustomPipelineMutex.Unlock()
+
+	pipeline, err := buildLogsCustomPipelineJSON(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "PUT", logsPipelinePath+"/"+d.Id(), &pipeline)
+	if err != nil {
+		return utils.TranslateClientErrorDiag(err, httpResp, "error updating logs pipeline")
+	}
+
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return updateLogsCustomPipelineJSONState(d, respMap)
+}
+
+func resourceDatadogLogsCustomPipelineJSONDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	logCustomPipelineMutex.Lock()
+	defer logCustomPipelineMutex.Unlock()
+
+	_, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "DELETE", logsPipelinePath+"/"+d.Id(), nil)
+	if err != nil {
+		// API returns 400 when the specific pipeline id doesn't exist through DELETE request.
+		if httpResp != nil && httpResp.StatusCode == http.StatusBadRequest {
+			return nil
+		}
+		return utils.TranslateClientErrorDiag(err, httpResp, "error deleting logs pipeline")
+	}
+	return nil
+}
+
+// buildLogsCustomPipelineJSON returns the definition of the pipeline without its computed fields, which are
+// rejected by the API when exported from another pipeline
+func buildLogsCustomPipelineJSON(d *schema.ResourceData) (string, error) {
+	pipeline, err := structure.ExpandJsonFromString(d.Get("pipeline").(string))
+	if err != nil {
+		return "", err
+	}
+	prepLogsPipelineJSON(pipeline)
+	return structure.FlattenJsonToString(pipeline)
+}
+
+func updateLogsCustomPipelineJSONState(d *schema.ResourceData, pipeline map[string]interface{}) diag.Diagnostics {
+	if readOnly, _ := pipeline["is_read_only"].(bool); readOnly {
+		return diag.Errorf("logs pipeline %s is read only, use the datadog_logs_integration_pipeline resource to manage integration pipelines", d.Id())
+	}
+	prepLogsPipelineJSON(pipeline)
+
+	pipelineString, err := structure.FlattenJsonToString(pipeline)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("pipeline", pipelineString); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
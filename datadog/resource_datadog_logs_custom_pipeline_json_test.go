@@ -0,0 +1,173 @@
+package datadog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+)
+
+func TestPrepLogsPipelineJSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		json     string
+		expected string
+	}{
+		{
+			name: "exported from the UI",
+			json: `{
+				"id": "abc-123",
+				"type": "pipeline",
+				"is_read_only": false,
+				"name": "Payments",
+				"is_enabled": true,
+				"description": "",
+				"tags": [],
+				"filter": {"query": "service:payments"},
+				"processors": [{
+					"type": "grok-parser",
+					"name": "",
+					"is_enabled": true,
+					"source": "message",
+					"samples": [],
+					"grok": {"support_rules": "", "match_rules": "rule %{word:first}"}
+				}]
+			}`,
+			expected: `{
+				"name": "Payments",
+				"is_enabled": true,
+				"filter": {"query": "service:payments"},
+				"processors": [{
+					"type": "grok-parser",
+					"is_enabled": true,
+					"source": "message",
+					"grok": {"support_rules": "", "match_rules": "rule %{word:first}"}
+				}]
+			}`,
+		},
+		{
+			name: "per type defaults",
+			json: `{
+				"name": "Payments",
+				"processors": [
+					{"type": "attribute-remapper", "name": "Remap", "is_enabled": false, "sources": ["user"], "target": "usr.id", "source_type": "attribute", "target_type": "tag", "target_format": "auto", "preserve_source": true, "override_on_conflict": false},
+					{"type": "arithmetic-processor", "expression": "a + b", "target": "c", "is_replace_missing": false},
+					{"type": "string-builder-processor", "template": "%{a}", "target": "b", "is_replace_missing": true},
+					{"type": "url-parser", "sources": ["http.url"], "target": "http.url_details", "normalize_ending_slashes": false},
+					{"type": "user-agent-parser", "sources": ["http.useragent"], "target": "http.useragent_details", "is_encoded": false},
+					{"type": "geo-ip-parser", "sources": ["network.client.ip"], "target": "network.client.geoip", "ip_processing_behavior": "do-nothing"},
+					{"type": "date-remapper", "sources": ["timestamp"], "is_replace_missing": false, "samples": []}
+				]
+			}`,
+			expected: `{
+				"name": "Payments",
+				"processors": [
+					{"type": "attribute-remapper", "name": "Remap", "sources": ["user"], "target": "usr.id", "target_type": "tag", "preserve_source": true},
+					{"type": "arithmetic-processor", "expression": "a + b", "target": "c"},
+					{"type": "string-builder-processor", "template": "%{a}", "target": "b", "is_replace_missing": true},
+					{"type": "url-parser", "sources": ["http.url"], "target": "http.url_details"},
+					{"type": "user-agent-parser", "sources": ["http.useragent"], "target": "http.useragent_details"},
+					{"type": "geo-ip-parser", "sources": ["network.client.ip"], "target": "network.client.geoip"},
+					{"type": "date-remapper", "sources": ["timestamp"], "is_replace_missing": false, "samples": []}
+				]
+			}`,
+		},
+		{
+			name: "nested pipelines",
+			json: `{
+				"name": "Payments",
+				"processors": [{
+					"type": "pipeline",
+					"id": "nested-123",
+					"name": "Nginx",
+					"is_enabled": true,
+					"description": "",
+					"tags": [],
+					"filter": {"query": "source:nginx"},
+					"processors": [{
+						"type": "pipeline",
+						"name": "",
+						"is_enabled": false,
+						"processors": [{"type": "url-parser", "name": "", "sources": ["http.url"], "target": "http.url_details", "normalize_ending_slashes": false}]
+					}]
+				}]
+			}`,
+			expected: `{
+				"name": "Payments",
+				"processors": [{
+					"type": "pipeline",
+					"id": "nested-123",
+					"name": "Nginx",
+					"is_enabled": true,
+					"filter": {"query": "source:nginx"},
+					"processors": [{
+						"type": "pipeline",
+						"processors": [{"type": "url-parser", "sources": ["http.url"], "target": "http.url_details"}]
+					}]
+				}]
+			}`,
+		},
+		{
+			name:     "null values",
+			json:     `{"name": "Payments", "description": null, "tags": null, "processors": [{"type": "grok-parser", "name": null, "samples": null}]}`,
+			expected: `{"name": "Payments", "processors": [{"type": "grok-parser"}]}`,
+		},
+		{
+			name:     "canonical definition",
+			json:     `{"name": "Payments", "is_enabled": true, "tags": ["team:payments"], "processors": [{"type": "pipeline", "is_enabled": true}]}`,
+			expected: `{"name": "Payments", "is_enabled": true, "tags": ["team:payments"], "processors": [{"type": "pipeline", "is_enabled": true}]}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pipeline, err := structure.ExpandJsonFromString(tc.json)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prepLogsPipelineJSON(pipeline)
+			actual, _ := structure.FlattenJsonToString(pipeline)
+
+			expectedPipeline, err := structure.ExpandJsonFromString(tc.expected)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, _ := structure.FlattenJsonToString(expectedPipeline)
+			if actual != expected {
+				t.Errorf("unexpected pipeline:\n%s\nexpected:\n%s", actual, expected)
+			}
+
+			// The canonical definition is stable, so the state doesn't change on the next refresh
+			prepLogsPipelineJSON(pipeline)
+			if again, _ := structure.FlattenJsonToString(pipeline); again != actual {
+				t.Errorf("expected the canonical definition to be stable, got:\n%s", again)
+			}
+		})
+	}
+}
+
+func TestRemoveLogsDefaults(t *testing.T) {
+	object := map[string]interface{}{
+		"name":       "",
+		"is_enabled": true,
+		"samples":    []interface{}{},
+		"tags":       []interface{}{"team:payments"},
+		"target":     nil,
+		"source":     "message",
+	}
+	removeLogsDefaults(object, map[string]interface{}{
+		"name":       "",
+		"is_enabled": false,
+		"samples":    []interface{}{},
+		"tags":       []interface{}{},
+		"target":     "attribute",
+		"missing":    "",
+	})
+	expected := map[string]interface{}{
+		"is_enabled": true,
+		"tags":       []interface{}{"team:payments"},
+		"source":     "message",
+	}
+	if !reflect.DeepEqual(object, expected) {
+		t.Errorf("expected %v, got %v", expected, object)
+	}
+}
@@ -0,0 +1,27 @@
+package datadog
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+)
+
+func TestCheckLogsPipelineForUnparsed(t *testing.T) {
+	for definition, expectError := range map[string]bool{
+		`{"type": "schema-processor", "name": "ocsf", "is_enabled": true, "schema": {"schema_type": "ocsf"}}`: false,
+		`{"type": "span-id-remapper", "name": "span", "is_enabled": true, "sources": ["dd.span_id"]}`:         false,
+		`{"type": "grok-parser", "name": "grok", "is_enabled": true, "source": "message", "grok": "invalid"}`: true,
+		`{"type": "pipeline", "name": "nested", "is_enabled": "invalid"}`:                                     true,
+		`{"name": "missing type"}`: true,
+	} {
+		var ddProcessor datadogV1.LogsProcessor
+		if err := json.Unmarshal([]byte(definition), &ddProcessor); err != nil {
+			t.Fatal(err)
+		}
+		pipeline := datadogV1.LogsPipeline{Name: "pipeline", Processors: []datadogV1.LogsProcessor{ddProcessor}}
+		if err := checkLogsPipelineForUnparsed(pipeline); (err != nil) != expectError {
+			t.Errorf("expected an error for %s: %v, got %v", definition, expectError, err)
+		}
+	}
+}
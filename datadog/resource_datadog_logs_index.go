@@ -3,9 +3,11 @@ package datadog
 import (
 	"context"
 	"log"
+	"net/url"
 	"sync"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -54,9 +56,10 @@ var indexSchema = map[string]*schema.Schema{
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"query": {
-					Description: "Logs filter criteria. Only logs matching this filter criteria are considered for this index.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:  "Logs filter criteria. Only logs matching this filter criteria are considered for this index.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validators.ValidateLogsQuery,
 				},
 			},
 		},
@@ -69,8 +72,20 @@ var indexSchema = map[string]*schema.Schema{
 			Schema: exclusionFilterSchema,
 		},
 	},
+	"deletion_policy": {
+		Description:      "What happens to the index when the resource is destroyed. With `retain`, the index is only removed from the Terraform state and stays in your account. With `delete`, the index and the logs it contains are deleted.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Default:          logsIndexDeletionPolicyRetain,
+		ValidateDiagFunc: validators.ValidateStringEnumValue(logsIndexDeletionPolicyRetain, logsIndexDeletionPolicyDelete),
+	},
 }
 
+const (
+	logsIndexDeletionPolicyRetain = "retain"
+	logsIndexDeletionPolicyDelete = "delete"
+)
+
 var exclusionFilterSchema = map[string]*schema.Schema{
 	"name": {
 		Description: "The name of the exclusion filter.",
@@ -88,9 +103,10 @@ var exclusionFilterSchema = map[string]*schema.Schema{
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"query": {
-					Description: "Only logs matching the filter criteria and the query of the parent index will be considered for this exclusion filter.",
-					Type:        schema.TypeString,
-					Optional:    true,
+					Description:  "Only logs matching the filter criteria and the query of the parent index will be considered for this exclusion filter.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validators.ValidateLogsQuery,
 				},
 				"sample_rate": {
 					Description: "The fraction of logs excluded by the exclusion filter, when active.",
@@ -104,7 +120,7 @@ var exclusionFilterSchema = map[string]*schema.Schema{
 
 func resourceDatadogLogsIndex() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Provides a Datadog Logs Index API resource. This can be used to create and manage Datadog logs indexes.  \n**Note:** It is not possible to delete logs indexes through Terraform, so an index remains in your account after the resource is removed from your terraform config. Reach out to support to delete a logs index.",
+		Description:   "Provides a Datadog Logs Index API resource. This can be used to create and manage Datadog logs indexes.  \n**Note:** By default an index remains in your account after the resource is removed from your terraform config. Set `deletion_policy` to `delete` to delete it along with its logs. Deleted indexes are also removed from the logs index order, while retained ones are listed in its `retained_indexes`.",
 		CreateContext: resourceDatadogLogsIndexCreate,
 		UpdateContext: resourceDatadogLogsIndexUpdate,
 		ReadContext:   resourceDatadogLogsIndexRead,
@@ -159,6 +175,9 @@ func updateLogsIndexState(d *schema.ResourceData, index *datadogV1.LogsIndex) di
 	if err := d.Set("exclusion_filter", buildTerraformExclusionFilters(index.GetExclusionFilters())); err != nil {
 		return diag.FromErr(err)
 	}
+	if _, ok := d.GetOk("deletion_policy"); !ok {
+		d.Set("deletion_policy", logsIndexDeletionPolicyRetain)
+	}
 	return nil
 }
 
@@ -201,7 +220,25 @@ func resourceDatadogLogsIndexUpdate(ctx context.Context, d *schema.ResourceData,
 	return updateLogsIndexState(d, &updatedIndex)
 }
 
-func resourceDatadogLogsIndexDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+func resourceDatadogLogsIndexDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("deletion_policy").(string) != logsIndexDeletionPolicyDelete {
+		return nil
+	}
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	logsIndexMutex.Lock()
+	defer logsIndexMutex.Unlock()
+
+	// The API client doesn't support deleting indexes
+	_, httpResponse, err := utils.SendRequest(auth, apiInstances.HttpClient, "DELETE", "/api/v1/logs/config/indexes/"+url.PathEscape(d.Id()), nil)
+	if err != nil {
+		if httpResponse != nil && httpResponse.StatusCode == 404 {
+			return nil
+		}
+		return utils.TranslateClientErrorDiag(err, httpResponse, "error deleting logs index")
+	}
 	return nil
 }
 
@@ -2,8 +2,10 @@ package datadog
 
 import (
 	"context"
+	"slices"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -34,6 +36,19 @@ func resourceDatadogLogsIndexOrder() *schema.Resource {
 					Required:    true,
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
+				"mode": {
+					Description:      "How the `indexes` list is enforced. With `full`, it must list all the indexes of the organization. With `relative`, only the relative order of the listed indexes is enforced and the other indexes keep their position.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          utils.OrderModeFull,
+					ValidateDiagFunc: validators.ValidateStringEnumValue(utils.OrderModeFull, utils.OrderModeRelative),
+				},
+				"retained_indexes": {
+					Description: "Indexes removed from `indexes` which still exist, like the ones of `datadog_logs_index` resources destroyed with the `retain` deletion policy. They keep their position in the order, and are left out of `indexes` in `full` mode.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
 			}
 		},
 	}
@@ -59,6 +74,18 @@ func resourceDatadogLogsIndexOrderUpdate(ctx context.Context, d *schema.Resource
 	apiInstances := providerConf.DatadogApiInstances
 	auth := providerConf.Auth
 
+	relative := d.Get("mode").(string) == utils.OrderModeRelative
+	removed := getLogsIndexesRemoved(d)
+	if relative || len(removed) > 0 {
+		currentOrder, httpResponse, err := apiInstances.GetLogsIndexesApiV1().GetLogsIndexOrder(auth)
+		if err != nil {
+			return utils.TranslateClientErrorDiag(err, httpResponse, "error getting logs index list")
+		}
+		if ddIndexList.IndexNames, err = buildLogsIndexOrder(currentOrder.GetIndexNames(), ddList, removed, relative); err != nil {
+			return diag.Errorf("cannot map indexes to existing ones: %s", err)
+		}
+	}
+
 	updatedOrder, httpResponse, err := apiInstances.GetLogsIndexesApiV1().UpdateLogsIndexOrder(auth, ddIndexList)
 	if err != nil {
 		return utils.TranslateClientErrorDiag(err, httpResponse, "error updating logs index list")
@@ -70,8 +97,58 @@ func resourceDatadogLogsIndexOrderUpdate(ctx context.Context, d *schema.Resource
 	return updateLogsIndexOrderState(d, &updatedOrder)
 }
 
+// buildLogsIndexOrder returns the order to send given the current one. In relative mode, the listed indexes are
+// moved to the positions of the listed ones, leaving the other indexes in place.
+func buildLogsIndexOrder(current []string, desired []string, removed []string, relative bool) ([]string, error) {
+	if !relative {
+		// Indexes removed from the list are kept at their position, as they are only deleted by the
+		// datadog_logs_index resource after this update, or retained
+		current = utils.FilterRelativeOrder(current, append(removed, desired...))
+	}
+	return utils.MergeRelativeOrder(current, desired)
+}
+
+// getLogsIndexesRemoved returns the indexes removed from the list, either in this update or retained by an earlier
+// one, which are left out of the list until they are deleted
+func getLogsIndexesRemoved(d *schema.ResourceData) []string {
+	listed := make(map[string]bool)
+	for _, name := range utils.GetStringSlice(d, "indexes") {
+		listed[name] = true
+	}
+	var removed []string
+	candidates := utils.GetStringSlice(d, "retained_indexes")
+	if !d.IsNewResource() && d.HasChange("indexes") {
+		oldList, _ := d.GetChange("indexes")
+		for _, name := range oldList.([]interface{}) {
+			candidates = append(candidates, name.(string))
+		}
+	}
+	for _, name := range candidates {
+		if !listed[name] {
+			listed[name] = true
+			removed = append(removed, name)
+		}
+	}
+	return removed
+}
+
 func updateLogsIndexOrderState(d *schema.ResourceData, order *datadogV1.LogsIndexesOrder) diag.Diagnostics {
-	if err := d.Set("indexes", order.GetIndexNames()); err != nil {
+	indexNames := order.GetIndexNames()
+	var retained []string
+	if d.Get("mode").(string) == utils.OrderModeRelative {
+		indexNames = utils.FilterRelativeOrder(indexNames, utils.GetStringSlice(d, "indexes"))
+	} else {
+		if err := d.Set("mode", utils.OrderModeFull); err != nil {
+			return diag.FromErr(err)
+		}
+		// Removed indexes which still exist are retained, and left out of the list
+		retained = utils.FilterRelativeOrder(indexNames, getLogsIndexesRemoved(d))
+		indexNames = excludeLogsIndexes(indexNames, retained)
+	}
+	if err := d.Set("indexes", indexNames); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("retained_indexes", retained); err != nil {
 		return diag.FromErr(err)
 	}
 	if _, ok := d.GetOk("name"); !ok {
@@ -80,6 +157,16 @@ func updateLogsIndexOrderState(d *schema.ResourceData, order *datadogV1.LogsInde
 	return nil
 }
 
+func excludeLogsIndexes(names []string, excluded []string) []string {
+	var kept []string
+	for _, name := range names {
+		if !slices.Contains(excluded, name) {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
 func resourceDatadogLogsIndexOrderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -0,0 +1,101 @@
+package datadog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestBuildLogsIndexOrder(t *testing.T) {
+	cases := []struct {
+		name            string
+		current         []string
+		desired         []string
+		pendingDeletion []string
+		relative        bool
+		expected        []string
+		err             string
+	}{
+		{
+			name:     "relative mode keeps unlisted indexes in place",
+			current:  []string{"main", "audit", "debug", "archive"},
+			desired:  []string{"debug", "main"},
+			relative: true,
+			expected: []string{"debug", "audit", "main", "archive"},
+		},
+		{
+			name:     "relative mode with an unknown index",
+			current:  []string{"main", "audit"},
+			desired:  []string{"audit", "missing"},
+			relative: true,
+			err:      `"missing" doesn't exist`,
+		},
+		{
+			name:     "full mode",
+			current:  []string{"main", "audit"},
+			desired:  []string{"audit", "main"},
+			expected: []string{"audit", "main"},
+		},
+		{
+			name:            "full mode keeps indexes pending deletion",
+			current:         []string{"main", "old", "audit"},
+			desired:         []string{"audit", "main"},
+			pendingDeletion: []string{"old"},
+			expected:        []string{"audit", "old", "main"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			order, err := buildLogsIndexOrder(tc.current, tc.desired, tc.pendingDeletion, tc.relative)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Errorf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(order, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, order)
+			}
+		})
+	}
+}
+
+func TestUpdateLogsIndexOrderStateRetainedIndexes(t *testing.T) {
+	d := resourceDatadogLogsIndexOrder().Data(&terraform.InstanceState{
+		ID: "order",
+		Attributes: map[string]string{
+			"name":               "order",
+			"mode":               "full",
+			"indexes.#":          "1",
+			"indexes.0":          "main",
+			"retained_indexes.#": "1",
+			"retained_indexes.0": "old",
+		},
+	})
+
+	// A new index shows up in the order, while the retained one is kept out of it
+	if diags := updateLogsIndexOrderState(d, &datadogV1.LogsIndexesOrder{IndexNames: []string{"new", "old", "main"}}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if indexes := utils.GetStringSlice(d, "indexes"); !reflect.DeepEqual(indexes, []string{"new", "main"}) {
+		t.Errorf("expected indexes [new main], got %v", indexes)
+	}
+	if retained := utils.GetStringSlice(d, "retained_indexes"); !reflect.DeepEqual(retained, []string{"old"}) {
+		t.Errorf("expected retained indexes [old], got %v", retained)
+	}
+
+	// Once deleted, the index isn't retained anymore
+	if diags := updateLogsIndexOrderState(d, &datadogV1.LogsIndexesOrder{IndexNames: []string{"new", "main"}}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if retained := utils.GetStringSlice(d, "retained_indexes"); len(retained) != 0 {
+		t.Errorf("expected no retained indexes, got %v", retained)
+	}
+}
@@ -0,0 +1,84 @@
+package datadog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceDatadogLogsIndexDelete(t *testing.T) {
+	cases := []struct {
+		name           string
+		deletionPolicy string
+		status         int
+		requests       []string
+		err            string
+	}{
+		{
+			name:           "retained",
+			deletionPolicy: logsIndexDeletionPolicyRetain,
+		},
+		{
+			name:           "deleted",
+			deletionPolicy: logsIndexDeletionPolicyDelete,
+			status:         http.StatusOK,
+			requests:       []string{"DELETE /api/v1/logs/config/indexes/payments"},
+		},
+		{
+			name:           "already deleted",
+			deletionPolicy: logsIndexDeletionPolicyDelete,
+			status:         http.StatusNotFound,
+			requests:       []string{"DELETE /api/v1/logs/config/indexes/payments"},
+		},
+		{
+			name:           "error",
+			deletionPolicy: logsIndexDeletionPolicyDelete,
+			status:         http.StatusForbidden,
+			requests:       []string{"DELETE /api/v1/logs/config/indexes/payments"},
+			err:            "error deleting logs index",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			p := Provider()
+			if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+				"api_key":  "api-key",
+				"app_key":  "app-key",
+				"api_url":  server.URL,
+				"validate": "false",
+			})); diags.HasError() {
+				t.Fatalf("error configuring the provider: %v", diags)
+			}
+			d := schema.TestResourceDataRaw(t, resourceDatadogLogsIndex().SchemaMap(), map[string]interface{}{
+				"name":            "payments",
+				"deletion_policy": tc.deletionPolicy,
+			})
+			d.SetId("payments")
+
+			diags := resourceDatadogLogsIndexDelete(context.Background(), d, p.Meta())
+			if tc.err == "" && diags.HasError() {
+				t.Errorf("unexpected error: %v", diags)
+			}
+			if tc.err != "" && (len(diags) != 1 || !strings.Contains(diags[0].Summary, tc.err)) {
+				t.Errorf("expected error %q, got %v", tc.err, diags)
+			}
+			if strings.Join(requests, ", ") != strings.Join(tc.requests, ", ") {
+				t.Errorf("expected requests %v, got %v", tc.requests, requests)
+			}
+		})
+	}
+}
@@ -56,7 +56,7 @@ func resourceDatadogLogsIntegrationPipelineRead(ctx context.Context, d *schema.R
 		}
 		return utils.TranslateClientErrorDiag(err, httpresp, "error getting logs integration pipeline")
 	}
-	if err := utils.CheckForUnparsed(ddPipeline); err != nil {
+	if err := checkLogsPipelineForUnparsed(ddPipeline); err != nil {
 		return diag.FromErr(err)
 	}
 	if !ddPipeline.GetIsReadOnly() {
@@ -77,7 +77,7 @@ func resourceDatadogLogsIntegrationPipelineUpdate(ctx context.Context, d *schema
 	if err != nil {
 		return utils.TranslateClientErrorDiag(err, httpResponse, "error updating logs integration pipeline")
 	}
-	if err := utils.CheckForUnparsed(updatedPipeline); err != nil {
+	if err := checkLogsPipelineForUnparsed(updatedPipeline); err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(*updatedPipeline.Id)
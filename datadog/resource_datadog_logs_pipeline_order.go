@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -34,6 +35,13 @@ func resourceDatadogLogsPipelineOrder() *schema.Resource {
 					Required:    true,
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
+				"mode": {
+					Description:      "How the `pipelines` list is enforced. With `full`, it must list all the pipelines, integration pipelines included. With `relative`, only the relative order of the listed pipelines is enforced and the other pipelines keep their position.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          utils.OrderModeFull,
+					ValidateDiagFunc: validators.ValidateStringEnumValue(utils.OrderModeFull, utils.OrderModeRelative),
+				},
 			}
 		},
 	}
@@ -44,7 +52,13 @@ func resourceDatadogLogsPipelineOrderCreate(ctx context.Context, d *schema.Resou
 }
 
 func updateLogsPipelineOrderState(d *schema.ResourceData, order *datadogV1.LogsPipelinesOrder) diag.Diagnostics {
-	if err := d.Set("pipelines", order.PipelineIds); err != nil {
+	pipelineIDs := order.PipelineIds
+	if d.Get("mode").(string) == utils.OrderModeRelative {
+		pipelineIDs = utils.FilterRelativeOrder(pipelineIDs, utils.GetStringSlice(d, "pipelines"))
+	} else if err := d.Set("mode", utils.OrderModeFull); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("pipelines", pipelineIDs); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
@@ -81,6 +95,15 @@ func resourceDatadogLogsPipelineOrderUpdate(ctx context.Context, d *schema.Resou
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
 	auth := providerConf.Auth
+	if d.Get("mode").(string) == utils.OrderModeRelative {
+		currentOrder, httpResponse, err := apiInstances.GetLogsPipelinesApiV1().GetLogsPipelineOrder(auth)
+		if err != nil {
+			return utils.TranslateClientErrorDiag(err, httpResponse, "error getting logs pipeline order")
+		}
+		if ddPipelineList.PipelineIds, err = utils.MergeRelativeOrder(currentOrder.PipelineIds, ddList); err != nil {
+			return diag.Errorf("cannot map pipelines to existing ones: %s", err)
+		}
+	}
 	updatedOrder, httpResponse, err := apiInstances.GetLogsPipelinesApiV1().
 		UpdateLogsPipelineOrder(auth, ddPipelineList)
 	if err != nil {
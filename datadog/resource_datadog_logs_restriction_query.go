@@ -0,0 +1,137 @@
+package datadog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	logsRestrictionQueriesPath = "/api/v2/logs/config/restriction_queries"
+	logsRestrictionQueryType   = "logs_restriction_queries"
+)
+
+func resourceDatadogLogsRestrictionQuery() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Datadog Logs Restriction Query resource. This can be used to create and manage the restriction queries limiting the logs users can see. Roles are assigned to a restriction query with the `datadog_logs_restriction_query_role` resource.",
+		CreateContext: resourceDatadogLogsRestrictionQueryCreate,
+		ReadContext:   resourceDatadogLogsRestrictionQueryRead,
+		UpdateContext: resourceDatadogLogsRestrictionQueryUpdate,
+		DeleteContext: resourceDatadogLogsRestrictionQueryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"restriction_query": {
+					Description:  "The query restricting the logs visible to the users of the assigned roles, for example `service:payments`. Only `key:value` filters combined with boolean operators are supported, full-text search isn't.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validators.ValidateLogsRestrictionQuery,
+				},
+			}
+		},
+	}
+}
+
+func resourceDatadogLogsRestrictionQueryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "POST", logsRestrictionQueriesPath, buildLogsRestrictionQueryRequest(d))
+	if err != nil {
+		return utils.TranslateClientErrorDiag(err, httpResp, "error creating logs restriction query")
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data, _ := respMap["data"].(map[string]interface{})
+	id, ok := data["id"].(string)
+	if !ok {
+		return diag.FromErr(errors.New("error retrieving id from response"))
+	}
+	d.SetId(id)
+
+	return updateLogsRestrictionQueryState(d, data)
+}
+
+func resourceDatadogLogsRestrictionQueryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "GET", logsRestrictionQueriesPath+"/"+url.PathEscape(d.Id()), nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+		return utils.TranslateClientErrorDiag(err, httpResp, "error getting logs restriction query")
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data, _ := respMap["data"].(map[string]interface{})
+	return updateLogsRestrictionQueryState(d, data)
+}
+
+func resourceDatadogLogsRestrictionQueryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "PATCH", logsRestrictionQueriesPath+"/"+url.PathEscape(d.Id()), buildLogsRestrictionQueryRequest(d))
+	if err != nil {
+		return utils.TranslateClientErrorDiag(err, httpResp, "error updating logs restriction query")
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data, _ := respMap["data"].(map[string]interface{})
+	return updateLogsRestrictionQueryState(d, data)
+}
+
+func resourceDatadogLogsRestrictionQueryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	_, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "DELETE", logsRestrictionQueriesPath+"/"+url.PathEscape(d.Id()), nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return utils.TranslateClientErrorDiag(err, httpResp, "error deleting logs restriction query")
+	}
+	return nil
+}
+
+func buildLogsRestrictionQueryRequest(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": logsRestrictionQueryType,
+			"attributes": map[string]interface{}{
+				"restriction_query": d.Get("restriction_query").(string),
+			},
+		},
+	}
+}
+
+func updateLogsRestrictionQueryState(d *schema.ResourceData, data map[string]interface{}) diag.Diagnostics {
+	attributes, _ := data["attributes"].(map[string]interface{})
+	if err := d.Set("restriction_query", attributes["restriction_query"]); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
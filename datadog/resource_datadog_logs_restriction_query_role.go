@@ -0,0 +1,132 @@
+package datadog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDatadogLogsRestrictionQueryRole() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Datadog Logs Restriction Query Role resource. This can be used to assign a role to a logs restriction query, so that users with this role only see the logs matching the query. A role can only be assigned to one restriction query: assigning it to another restriction query removes it from the previous one.",
+		CreateContext: resourceDatadogLogsRestrictionQueryRoleCreate,
+		ReadContext:   resourceDatadogLogsRestrictionQueryRoleRead,
+		DeleteContext: resourceDatadogLogsRestrictionQueryRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"restriction_query_id": {
+					Description: "The ID of the logs restriction query.",
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+				},
+				"role_id": {
+					Description: "The ID of the role to assign to the restriction query.",
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+				},
+			}
+		},
+	}
+}
+
+func resourceDatadogLogsRestrictionQueryRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	restrictionQueryID := d.Get("restriction_query_id").(string)
+	roleID := d.Get("role_id").(string)
+	path := logsRestrictionQueriesPath + "/" + url.PathEscape(restrictionQueryID) + "/roles"
+	if _, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "POST", path, buildLogsRestrictionQueryRoleRequest(roleID)); err != nil {
+		return utils.TranslateClientErrorDiag(err, httpResp, "error assigning role to logs restriction query")
+	}
+	d.SetId(restrictionQueryID + ":" + roleID)
+
+	return resourceDatadogLogsRestrictionQueryRoleRead(ctx, d, meta)
+}
+
+func resourceDatadogLogsRestrictionQueryRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	restrictionQueryID, roleID, err := utils.RestrictionQueryAndRoleFromID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "GET", logsRestrictionQueriesPath+"/role/"+url.PathEscape(roleID), nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+		return utils.TranslateClientErrorDiag(err, httpResp, "error getting logs restriction query of role")
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The role is removed from the state when it was assigned to another restriction query
+	found := false
+	restrictionQueries, _ := respMap["data"].([]interface{})
+	for _, restrictionQuery := range restrictionQueries {
+		restrictionQuery, _ := restrictionQuery.(map[string]interface{})
+		if restrictionQuery["id"] == restrictionQueryID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("restriction_query_id", restrictionQueryID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_id", roleID); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceDatadogLogsRestrictionQueryRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	restrictionQueryID, roleID, err := utils.RestrictionQueryAndRoleFromID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	path := logsRestrictionQueriesPath + "/" + url.PathEscape(restrictionQueryID) + "/roles"
+	if _, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "DELETE", path, buildLogsRestrictionQueryRoleRequest(roleID)); err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return utils.TranslateClientErrorDiag(err, httpResp, "error removing role from logs restriction query")
+	}
+	return nil
+}
+
+func buildLogsRestrictionQueryRoleRequest(roleID string) map[string]interface{} {
+	return map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   roleID,
+			"type": "roles",
+		},
+	}
+}
@@ -0,0 +1,759 @@
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	referenceTablesPath       = "/api/v2/reference-tables/tables"
+	referenceTableUploadsPath = "/api/v2/reference-tables/uploads"
+	// Local files are uploaded in parts of at most 5 MiB
+	referenceTableUploadPartSize = 5 * 1024 * 1024
+
+	referenceTableFieldTypeString = "STRING"
+	referenceTableFieldTypeInt32  = "INT32"
+)
+
+var referenceTableNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// referenceTableSources maps the blocks defining the content of a table to the API source types
+var referenceTableSources = map[string]string{
+	"local_file":   "LOCAL_FILE",
+	"s3_source":    "S3",
+	"gcs_source":   "GCS",
+	"azure_source": "AZURE",
+}
+
+func resourceDatadogReferenceTable() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Datadog Reference Table resource. This can be used to create and manage the reference tables used by `reference_table_lookup_processor` in logs pipelines, from a local CSV file or a file stored in a cloud storage bucket.",
+		CreateContext: resourceDatadogReferenceTableCreate,
+		ReadContext:   resourceDatadogReferenceTableRead,
+		UpdateContext: resourceDatadogReferenceTableUpdate,
+		DeleteContext: resourceDatadogReferenceTableDelete,
+		CustomizeDiff: resourceDatadogReferenceTableCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"table_name": {
+					Description:  "The name of the reference table, as referenced by the `lookup_enrichment_table` of lookup processors.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringMatch(referenceTableNameRegex, "must start with a lowercase letter and only contain lowercase letters, numbers and underscores"),
+				},
+				"description": {
+					Description: "The description of the reference table.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"tags": {
+					Description: "A list of tags to associate with the reference table.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"schema": {
+					Description: "The schema of the reference table.",
+					Type:        schema.TypeList,
+					Required:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"primary_key": {
+								Description: "The name of the column used as primary key. Lookup processors match the value of their source attribute against this column.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"field": {
+								Description: "The columns of the table.",
+								Type:        schema.TypeList,
+								Required:    true,
+								MinItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"name": {
+											Description:  "The name of the column.",
+											Type:         schema.TypeString,
+											Required:     true,
+											ValidateFunc: validation.StringMatch(referenceTableNameRegex, "must start with a lowercase letter and only contain lowercase letters, numbers and underscores"),
+										},
+										"type": {
+											Description:      "The type of the column.",
+											Type:             schema.TypeString,
+											Optional:         true,
+											Default:          referenceTableFieldTypeString,
+											ValidateDiagFunc: validators.ValidateStringEnumValue(referenceTableFieldTypeString, referenceTableFieldTypeInt32),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"local_file": {
+					Description:  "A local CSV file uploaded to the table. The file is validated against the schema at plan time, and uploaded again when its content changes.",
+					Type:         schema.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: []string{"local_file", "s3_source", "gcs_source", "azure_source"},
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"path": {Description: "The path of the CSV file. Its header must list the columns of the schema.", Type: schema.TypeString, Required: true},
+						},
+					},
+				},
+				"s3_source": {
+					Description: "A CSV file stored in an S3 bucket, read through the AWS integration.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"bucket":     {Description: "Name of your S3 bucket.", Type: schema.TypeString, Required: true, ValidateFunc: validators.ValidateS3BucketName},
+							"path":       {Description: "Path of the CSV file in the bucket.", Type: schema.TypeString, Required: true},
+							"account_id": {Description: "Your AWS account id.", Type: schema.TypeString, Required: true},
+						},
+					},
+				},
+				"gcs_source": {
+					Description: "A CSV file stored in a GCS bucket, read through the Google Cloud integration.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"bucket":       {Description: "Name of your GCS bucket.", Type: schema.TypeString, Required: true, ValidateFunc: validators.ValidateGCSBucketName},
+							"path":         {Description: "Path of the CSV file in the bucket.", Type: schema.TypeString, Required: true},
+							"project_id":   {Description: "Your project id.", Type: schema.TypeString, Required: true},
+							"client_email": {Description: "Your service account email.", Type: schema.TypeString, Required: true},
+						},
+					},
+				},
+				"azure_source": {
+					Description: "A CSV file stored in an Azure storage container, read through the Azure integration.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"container":       {Description: "The container where the file is stored.", Type: schema.TypeString, Required: true, ValidateFunc: validators.ValidateAzureContainerName},
+							"storage_account": {Description: "The associated storage account.", Type: schema.TypeString, Required: true, ValidateFunc: validators.ValidateAzureStorageAccountName},
+							"path":            {Description: "Path of the CSV file in the container.", Type: schema.TypeString, Required: true},
+							"tenant_id":       {Description: "Your tenant id.", Type: schema.TypeString, Required: true},
+							"client_id":       {Description: "Your client id.", Type: schema.TypeString, Required: true},
+						},
+					},
+				},
+				"sync_enabled": {
+					Description: "Whether the table is refreshed when the file stored in cloud storage changes. Ignored for local files.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"local_file_sha256": {
+					Description: "The SHA256 checksum of the uploaded local file.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"row_count": {
+					Description: "The number of rows of the table.",
+					Type:        schema.TypeInt,
+					Computed:    true,
+				},
+				"status": {
+					Description: "The status of the last import of the table content.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			}
+		},
+	}
+}
+
+// resourceDatadogReferenceTableCustomizeDiff validates the schema and the local file at plan time, and tracks the
+// checksum of the file so that changes of its content are uploaded
+func resourceDatadogReferenceTableCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !rawConfigFirstBlock(diff.GetRawConfig(), "schema").IsWhollyKnown() {
+		return nil
+	}
+	if err := validateReferenceTableSchema(diff); err != nil {
+		return err
+	}
+
+	path, _ := diff.Get("local_file.0.path").(string)
+	if path == "" {
+		if diff.Get("local_file_sha256").(string) != "" && diff.NewValueKnown("local_file.0.path") {
+			return diff.SetNew("local_file_sha256", "")
+		}
+		return nil
+	}
+	content, _, err := readReferenceTableFile(diff, path)
+	if err != nil {
+		return err
+	}
+	if checksum := referenceTableChecksum(content); checksum != diff.Get("local_file_sha256").(string) {
+		return diff.SetNew("local_file_sha256", checksum)
+	}
+	return nil
+}
+
+func resourceDatadogReferenceTableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+	defer forgetReferenceTableColumns(d.Get("table_name").(string))
+
+	attributes, err := buildReferenceTableAttributes(auth, apiInstances, d, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attributes["table_name"] = d.Get("table_name").(string)
+	body := map[string]interface{}{"data": map[string]interface{}{"type": "reference_table", "attributes": attributes}}
+
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "POST", referenceTablesPath, body)
+	if err != nil {
+		return utils.TranslateClientErrorDiag(err, httpResp, "error creating reference table")
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data, _ := respMap["data"].(map[string]interface{})
+	id, ok := data["id"].(string)
+	if !ok {
+		return diag.FromErr(errors.New("error retrieving id from response"))
+	}
+	d.SetId(id)
+
+	return updateReferenceTableState(d, data)
+}
+
+func resourceDatadogReferenceTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "GET", referenceTablesPath+"/"+url.PathEscape(d.Id()), nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+		return utils.TranslateClientErrorDiag(err, httpResp, "error getting reference table")
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data, _ := respMap["data"].(map[string]interface{})
+	return updateReferenceTableState(d, data)
+}
+
+func resourceDatadogReferenceTableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+	defer forgetReferenceTableColumns(d.Get("table_name").(string))
+
+	upload := d.HasChanges("local_file", "local_file_sha256")
+	attributes, err := buildReferenceTableAttributes(auth, apiInstances, d, upload)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body := map[string]interface{}{"data": map[string]interface{}{"type": "reference_table", "attributes": attributes}}
+
+	respByte, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "PATCH", referenceTablesPath+"/"+url.PathEscape(d.Id()), body)
+	if err != nil {
+		return utils.TranslateClientErrorDiag(err, httpResp, "error updating reference table")
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data, _ := respMap["data"].(map[string]interface{})
+	return updateReferenceTableState(d, data)
+}
+
+func resourceDatadogReferenceTableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+	defer forgetReferenceTableColumns(d.Get("table_name").(string))
+
+	_, httpResp, err := utils.SendRequest(auth, apiInstances.HttpClient, "DELETE", referenceTablesPath+"/"+url.PathEscape(d.Id()), nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return utils.TranslateClientErrorDiag(err, httpResp, "error deleting reference table")
+	}
+	return nil
+}
+
+// buildReferenceTableAttributes returns the attributes of a create or update request. Local files are only uploaded
+// when upload is true, otherwise the current content of the table is kept.
+func buildReferenceTableAttributes(ctx context.Context, apiInstances *utils.ApiInstances, d *schema.ResourceData, upload bool) (map[string]interface{}, error) {
+	attributes := map[string]interface{}{
+		"description": d.Get("description").(string),
+		"tags":        buildReferenceTableTags(d),
+		"schema":      buildReferenceTableSchema(d),
+	}
+
+	for block, source := range referenceTableSources {
+		if _, ok := d.GetOk(block + ".0"); !ok {
+			continue
+		}
+		attributes["source"] = source
+		if block == "local_file" {
+			if !upload {
+				return attributes, nil
+			}
+			content, header, err := readReferenceTableFile(d, d.Get("local_file.0.path").(string))
+			if err != nil {
+				return nil, err
+			}
+			uploadID, err := uploadReferenceTableFile(ctx, apiInstances, d.Get("table_name").(string), header, content)
+			if err != nil {
+				return nil, err
+			}
+			attributes["file_metadata"] = map[string]interface{}{"upload_id": uploadID}
+			return attributes, nil
+		}
+		attributes["file_metadata"] = map[string]interface{}{
+			"sync_enabled":   d.Get("sync_enabled").(bool),
+			"access_details": buildReferenceTableAccessDetails(d, block),
+		}
+	}
+	return attributes, nil
+}
+
+func buildReferenceTableTags(d *schema.ResourceData) []string {
+	tags := make([]string, 0)
+	for _, tag := range d.Get("tags").(*schema.Set).List() {
+		tags = append(tags, tag.(string))
+	}
+	return tags
+}
+
+func buildReferenceTableSchema(d utils.Resource) map[string]interface{} {
+	var fields []interface{}
+	for _, field := range d.Get("schema.0.field").([]interface{}) {
+		field := field.(map[string]interface{})
+		fields = append(fields, map[string]interface{}{"name": field["name"], "type": field["type"]})
+	}
+	return map[string]interface{}{
+		"primary_keys": []string{d.Get("schema.0.primary_key").(string)},
+		"fields":       fields,
+	}
+}
+
+func buildReferenceTableAccessDetails(d *schema.ResourceData, block string) map[string]interface{} {
+	source := d.Get(block + ".0").(map[string]interface{})
+	switch block {
+	case "s3_source":
+		return map[string]interface{}{"aws_detail": map[string]interface{}{
+			"aws_account_id":  source["account_id"],
+			"aws_bucket_name": source["bucket"],
+			"file_path":       source["path"],
+		}}
+	case "gcs_source":
+		return map[string]interface{}{"gcp_detail": map[string]interface{}{
+			"gcp_project_id":            source["project_id"],
+			"gcp_bucket_name":           source["bucket"],
+			"gcp_service_account_email": source["client_email"],
+			"file_path":                 source["path"],
+		}}
+	default:
+		return map[string]interface{}{"azure_detail": map[string]interface{}{
+			"azure_tenant_id":            source["tenant_id"],
+			"azure_client_id":            source["client_id"],
+			"azure_storage_account_name": source["storage_account"],
+			"azure_container_name":       source["container"],
+			"file_path":                  source["path"],
+		}}
+	}
+}
+
+func updateReferenceTableState(d *schema.ResourceData, data map[string]interface{}) diag.Diagnostics {
+	attributes, _ := data["attributes"].(map[string]interface{})
+	if err := d.Set("table_name", attributes["table_name"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", attributes["description"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", attributes["tags"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("row_count", attributes["row_count"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", attributes["status"]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if tableSchema, ok := attributes["schema"].(map[string]interface{}); ok {
+		primaryKey := ""
+		if primaryKeys, _ := tableSchema["primary_keys"].([]interface{}); len(primaryKeys) > 0 {
+			primaryKey, _ = primaryKeys[0].(string)
+		}
+		var fields []interface{}
+		tfFields, _ := tableSchema["fields"].([]interface{})
+		for _, field := range tfFields {
+			field, _ := field.(map[string]interface{})
+			fields = append(fields, map[string]interface{}{"name": field["name"], "type": field["type"]})
+		}
+		if err := d.Set("schema", []interface{}{map[string]interface{}{"primary_key": primaryKey, "field": fields}}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// The path of uploaded files isn't returned, so the local_file block is kept as configured
+	fileMetadata, _ := attributes["file_metadata"].(map[string]interface{})
+	accessDetails, _ := fileMetadata["access_details"].(map[string]interface{})
+	if syncEnabled, ok := fileMetadata["sync_enabled"].(bool); ok {
+		if err := d.Set("sync_enabled", syncEnabled); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	var err error
+	switch attributes["source"] {
+	case referenceTableSources["s3_source"]:
+		detail, _ := accessDetails["aws_detail"].(map[string]interface{})
+		err = d.Set("s3_source", []interface{}{map[string]interface{}{
+			"account_id": detail["aws_account_id"],
+			"bucket":     detail["aws_bucket_name"],
+			"path":       detail["file_path"],
+		}})
+	case referenceTableSources["gcs_source"]:
+		detail, _ := accessDetails["gcp_detail"].(map[string]interface{})
+		err = d.Set("gcs_source", []interface{}{map[string]interface{}{
+			"project_id":   detail["gcp_project_id"],
+			"bucket":       detail["gcp_bucket_name"],
+			"client_email": detail["gcp_service_account_email"],
+			"path":         detail["file_path"],
+		}})
+	case referenceTableSources["azure_source"]:
+		detail, _ := accessDetails["azure_detail"].(map[string]interface{})
+		err = d.Set("azure_source", []interface{}{map[string]interface{}{
+			"tenant_id":       detail["azure_tenant_id"],
+			"client_id":       detail["azure_client_id"],
+			"storage_account": detail["azure_storage_account_name"],
+			"container":       detail["azure_container_name"],
+			"path":            detail["file_path"],
+		}})
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// validateReferenceTableSchema ensures the columns of a table are unique and include the primary key
+func validateReferenceTableSchema(d utils.Resource) error {
+	primaryKey := d.Get("schema.0.primary_key").(string)
+	seen := make(map[string]bool)
+	for _, field := range d.Get("schema.0.field").([]interface{}) {
+		field := field.(map[string]interface{})
+		name := field["name"].(string)
+		if seen[name] {
+			return fmt.Errorf("schema.field: column %q is defined more than once", name)
+		}
+		seen[name] = true
+		if name == primaryKey && field["type"] != referenceTableFieldTypeString {
+			return fmt.Errorf("schema.primary_key: column %q must be of type `%s`", name, referenceTableFieldTypeString)
+		}
+	}
+	if !seen[primaryKey] {
+		return fmt.Errorf("schema.primary_key: column %q isn't defined in schema.field", primaryKey)
+	}
+	return nil
+}
+
+// readReferenceTableFile reads a CSV file and validates its content against the schema of the table: the header must
+// list all the columns, primary keys must be set and unique, and integer columns must hold 32-bit integers
+func readReferenceTableFile(d utils.Resource, path string) ([]byte, []string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("local_file.path: %s", err)
+	}
+
+	fieldTypes := make(map[string]string)
+	for _, field := range d.Get("schema.0.field").([]interface{}) {
+		field := field.(map[string]interface{})
+		fieldTypes[field["name"].(string)] = field["type"].(string)
+	}
+	primaryKey := d.Get("schema.0.primary_key").(string)
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("local_file %s: cannot read header: %s", path, err)
+	}
+	var missing, unknown []string
+	for _, column := range header {
+		if _, ok := fieldTypes[column]; !ok {
+			unknown = append(unknown, column)
+		}
+	}
+	for name := range fieldTypes {
+		if !slices.Contains(header, name) {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	if len(unknown) > 0 {
+		return nil, nil, fmt.Errorf("local_file %s: columns %s aren't defined in the schema", path, strings.Join(unknown, ", "))
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("local_file %s: columns %s of the schema are missing from the header", path, strings.Join(missing, ", "))
+	}
+
+	keys := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("local_file %s: %s", path, err)
+		}
+		for i, value := range record {
+			column := header[i]
+			if column == primaryKey {
+				if value == "" {
+					return nil, nil, fmt.Errorf("local_file %s:%d: primary key %q is empty", path, line, column)
+				}
+				if previous, ok := keys[value]; ok {
+					return nil, nil, fmt.Errorf("local_file %s:%d: primary key %q already used on line %d", path, line, value, previous)
+				}
+				keys[value] = line
+			}
+			if fieldTypes[column] == referenceTableFieldTypeInt32 && value != "" {
+				if _, err := strconv.ParseInt(value, 10, 32); err != nil {
+					return nil, nil, fmt.Errorf("local_file %s:%d: column %q must be a 32-bit integer, got %q", path, line, column, value)
+				}
+			}
+		}
+	}
+	return content, header, nil
+}
+
+func referenceTableChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// uploadReferenceTableFile uploads the content of a local file in parts, returning the id of the upload referenced
+// by the table
+func uploadReferenceTableFile(ctx context.Context, apiInstances *utils.ApiInstances, tableName string, header []string, content []byte) (string, error) {
+	partSize := referenceTableUploadPartSize
+	if len(content) < partSize {
+		partSize = len(content)
+	}
+	partCount := (len(content) + partSize - 1) / partSize
+	body := map[string]interface{}{"data": map[string]interface{}{
+		"type": "upload",
+		"attributes": map[string]interface{}{
+			"table_name": tableName,
+			"headers":    header,
+			"part_count": partCount,
+			"part_size":  partSize,
+		},
+	}}
+	respByte, httpResp, err := utils.SendRequest(ctx, apiInstances.HttpClient, "POST", referenceTableUploadsPath, body)
+	if err != nil {
+		return "", utils.TranslateClientError(err, httpResp, "error creating reference table upload")
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return "", err
+	}
+	data, _ := respMap["data"].(map[string]interface{})
+	uploadID, _ := data["id"].(string)
+	attributes, _ := data["attributes"].(map[string]interface{})
+	partURLs, _ := attributes["part_urls"].([]interface{})
+	if uploadID == "" || len(partURLs) != partCount {
+		return "", fmt.Errorf("error creating reference table upload: expected an id and %d part URLs in response", partCount)
+	}
+
+	// Parts are sent to pre-signed URLs, which don't accept the Datadog authentication headers
+	client := apiInstances.HttpClient.GetConfig().HTTPClient
+	for i, partURL := range partURLs {
+		end := (i + 1) * partSize
+		if end > len(content) {
+			end = len(content)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, partURL.(string), bytes.NewReader(content[i*partSize:end]))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "text/csv")
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("error uploading reference table part %d: %s", i+1, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return "", fmt.Errorf("error uploading reference table part %d: %s", i+1, resp.Status)
+		}
+	}
+	return uploadID, nil
+}
+
+// getReferenceTableColumns returns the columns of the reference table with the given name
+// referenceTableColumnsCache keeps the columns of the reference tables looked up while checking pipelines, so each
+// table is fetched once per plan or apply rather than once per pipeline. Tables which can't be fetched are cached as
+// nil. Entries are dropped when a reference table resource is changed.
+var referenceTableColumnsCache = struct {
+	sync.Mutex
+	columns map[string][]string
+}{columns: make(map[string][]string)}
+
+// getCachedReferenceTableColumns returns the columns of a reference table, and false when they can't be fetched
+func getCachedReferenceTableColumns(ctx context.Context, apiInstances *utils.ApiInstances, tableName string) ([]string, bool) {
+	referenceTableColumnsCache.Lock()
+	defer referenceTableColumnsCache.Unlock()
+
+	if columns, ok := referenceTableColumnsCache.columns[tableName]; ok {
+		return columns, columns != nil
+	}
+	columns, err := getReferenceTableColumns(ctx, apiInstances, tableName)
+	if err != nil {
+		log.Printf("[DEBUG] cannot check the columns of reference table %q: %s", tableName, err)
+	}
+	referenceTableColumnsCache.columns[tableName] = columns
+	return columns, columns != nil
+}
+
+func forgetReferenceTableColumns(tableName string) {
+	referenceTableColumnsCache.Lock()
+	defer referenceTableColumnsCache.Unlock()
+	delete(referenceTableColumnsCache.columns, tableName)
+}
+
+func getReferenceTableColumns(ctx context.Context, apiInstances *utils.ApiInstances, tableName string) ([]string, error) {
+	path := referenceTablesPath + "?filter[table_name][exact]=" + url.QueryEscape(tableName)
+	respByte, httpResp, err := utils.SendRequest(ctx, apiInstances.HttpClient, "GET", path, nil)
+	if err != nil {
+		return nil, utils.TranslateClientError(err, httpResp, "error listing reference tables")
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		return nil, err
+	}
+	tables, _ := respMap["data"].([]interface{})
+	for _, table := range tables {
+		attributes, _ := table.(map[string]interface{})["attributes"].(map[string]interface{})
+		if attributes["table_name"] != tableName {
+			continue
+		}
+		tableSchema, _ := attributes["schema"].(map[string]interface{})
+		fields, _ := tableSchema["fields"].([]interface{})
+		columns := make([]string, 0, len(fields))
+		for _, field := range fields {
+			name, _ := field.(map[string]interface{})["name"].(string)
+			columns = append(columns, name)
+		}
+		return columns, nil
+	}
+	return nil, fmt.Errorf("reference table %q not found", tableName)
+}
+
+// checkReferenceTableLookupColumns ensures the attributes read by processors placed after a reference table lookup
+// processor, below its target, are columns of the table. getColumns returns false when the columns of a table aren't
+// known, for instance when it's created by the same apply. lookupTargets maps the targets written so far to the table
+// looked up in them, or to an empty string when another processor wrote them, which shadows the columns below.
+func checkReferenceTableLookupColumns(processors []interface{}, lookupTargets map[string]string, getColumns func(string) ([]string, bool)) error {
+	for _, processor := range processors {
+		processor, ok := processor.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, attribute := range logsProcessorSourceAttributes(processor) {
+			// The deepest target holding the attribute is the last one to have written it
+			target := ""
+			for t := range lookupTargets {
+				if (attribute == t || strings.HasPrefix(attribute, t+".")) && len(t) > len(target) {
+					target = t
+				}
+			}
+			table := lookupTargets[target]
+			if table == "" || attribute == target {
+				continue
+			}
+			columns, ok := getColumns(table)
+			if !ok {
+				continue
+			}
+			column := strings.SplitN(strings.TrimPrefix(attribute, target+"."), ".", 2)[0]
+			if !slices.Contains(columns, column) {
+				return fmt.Errorf("processor %q reads attribute %q, but %q isn't a column of reference table %q looked up in %q (columns: %s)",
+					processor["name"], attribute, column, table, target, strings.Join(columns, ", "))
+			}
+		}
+
+		if processor["type"] == "pipeline" {
+			nestedTargets := make(map[string]string, len(lookupTargets))
+			for target, table := range lookupTargets {
+				nestedTargets[target] = table
+			}
+			nestedProcessors, _ := processor["processors"].([]interface{})
+			if err := checkReferenceTableLookupColumns(nestedProcessors, nestedTargets, getColumns); err != nil {
+				return err
+			}
+		}
+		if target, _ := processor["target"].(string); target != "" {
+			// Writing the target replaces the attributes below it
+			for t := range lookupTargets {
+				if strings.HasPrefix(t, target+".") {
+					delete(lookupTargets, t)
+				}
+			}
+			table, _ := processor["lookup_enrichment_table"].(string)
+			lookupTargets[target] = table
+		}
+	}
+	return nil
+}
+
+func logsProcessorSourceAttributes(processor map[string]interface{}) []string {
+	var attributes []string
+	if source, ok := processor["source"].(string); ok {
+		attributes = append(attributes, source)
+	}
+	sources, _ := processor["sources"].([]interface{})
+	for _, source := range sources {
+		if source, ok := source.(string); ok {
+			attributes = append(attributes, source)
+		}
+	}
+	return attributes
+}
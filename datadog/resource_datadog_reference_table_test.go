@@ -0,0 +1,153 @@
+package datadog
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCheckReferenceTableLookupColumns(t *testing.T) {
+	referenceTableColumnsCache.Lock()
+	referenceTableColumnsCache.columns["asset_owners"] = []string{"asset_id", "owner"}
+	referenceTableColumnsCache.columns["missing_table"] = nil
+	referenceTableColumnsCache.Unlock()
+	defer forgetReferenceTableColumns("asset_owners")
+	defer forgetReferenceTableColumns("missing_table")
+
+	lookup := func(table string) map[string]interface{} {
+		return map[string]interface{}{"type": "lookup-processor", "source": "instance.id", "target": "asset", "lookup_enrichment_table": table}
+	}
+	remapper := func(source string) map[string]interface{} {
+		return map[string]interface{}{"type": "attribute-remapper", "name": "Remap " + source, "sources": []interface{}{source}}
+	}
+	cases := []struct {
+		name       string
+		processors []interface{}
+		err        string
+	}{
+		{
+			name:       "known column",
+			processors: []interface{}{lookup("asset_owners"), remapper("asset.owner")},
+		},
+		{
+			name:       "unknown column",
+			processors: []interface{}{lookup("asset_owners"), remapper("asset.region")},
+			err:        `"region" isn't a column of reference table "asset_owners"`,
+		},
+		{
+			name:       "attribute read before the lookup",
+			processors: []interface{}{remapper("asset.region"), lookup("asset_owners")},
+		},
+		{
+			name:       "unknown column in a nested pipeline",
+			processors: []interface{}{lookup("asset_owners"), map[string]interface{}{"type": "pipeline", "processors": []interface{}{remapper("asset.region")}}},
+			err:        `"region" isn't a column of reference table "asset_owners"`,
+		},
+		{
+			name:       "table which can't be fetched",
+			processors: []interface{}{lookup("missing_table"), remapper("asset.region")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkReferenceTableLookupColumns(tc.processors, make(map[string]string), func(table string) ([]string, bool) {
+				// Cached tables are returned without calling the API
+				return getCachedReferenceTableColumns(context.Background(), nil, table)
+			})
+			if (err == nil) != (tc.err == "") || (err != nil && !strings.Contains(err.Error(), tc.err)) {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	forgetReferenceTableColumns("asset_owners")
+	referenceTableColumnsCache.Lock()
+	_, cached := referenceTableColumnsCache.columns["asset_owners"]
+	referenceTableColumnsCache.Unlock()
+	if cached {
+		t.Error("expected the columns of asset_owners to be forgotten")
+	}
+}
+
+func TestCheckReferenceTableLookupColumnsTargets(t *testing.T) {
+	tables := map[string][]string{
+		"asset_owners":  {"asset_id", "owner"},
+		"asset_regions": {"asset_id", "region"},
+	}
+	lookup := func(target, table string) map[string]interface{} {
+		return map[string]interface{}{"type": "lookup-processor", "source": "instance.id", "target": target, "lookup_enrichment_table": table}
+	}
+	remapper := func(source string) map[string]interface{} {
+		return map[string]interface{}{"type": "attribute-remapper", "name": "Remap " + source, "sources": []interface{}{source}, "target": "remapped"}
+	}
+	builder := func(target string) map[string]interface{} {
+		return map[string]interface{}{"type": "string-builder-processor", "name": "Build " + target, "template": "%{service}", "target": target}
+	}
+	pipeline := func(processors ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"type": "pipeline", "processors": processors}
+	}
+	cases := []struct {
+		name       string
+		processors []interface{}
+		err        string
+	}{
+		{
+			name:       "nested pipelines",
+			processors: []interface{}{lookup("asset", "asset_owners"), pipeline(pipeline(remapper("asset.region")))},
+			err:        `processor "Remap asset.region" reads attribute "asset.region", but "region" isn't a column of reference table "asset_owners" looked up in "asset"`,
+		},
+		{
+			name:       "lookup of a nested pipeline",
+			processors: []interface{}{lookup("asset", "asset_owners"), pipeline(lookup("asset", "asset_regions"), remapper("asset.region"))},
+		},
+		{
+			name:       "lookup of a nested pipeline isn't applied after it",
+			processors: []interface{}{pipeline(lookup("asset", "asset_owners")), remapper("asset.region")},
+		},
+		{
+			name:       "target shadowed by a later lookup",
+			processors: []interface{}{lookup("asset", "asset_owners"), lookup("asset", "asset_regions"), remapper("asset.region")},
+		},
+		{
+			name:       "deeper target",
+			processors: []interface{}{lookup("asset", "asset_owners"), lookup("asset.geo", "asset_regions"), remapper("asset.geo.region"), remapper("asset.owner")},
+		},
+		{
+			name:       "outside of the deeper target",
+			processors: []interface{}{lookup("asset", "asset_owners"), lookup("asset.geo", "asset_regions"), remapper("asset.geography")},
+			err:        `"geography" isn't a column of reference table "asset_owners" looked up in "asset"`,
+		},
+		{
+			name:       "attribute written by another processor",
+			processors: []interface{}{lookup("asset", "asset_owners"), builder("asset.region"), remapper("asset.region"), remapper("asset.region.code")},
+		},
+		{
+			name:       "target overwritten by another processor",
+			processors: []interface{}{lookup("asset", "asset_owners"), builder("asset"), remapper("asset.region")},
+		},
+		{
+			name:       "deeper target overwritten by a lookup",
+			processors: []interface{}{lookup("asset.geo", "asset_regions"), lookup("asset", "asset_owners"), remapper("asset.geo.region")},
+			err:        `"geo" isn't a column of reference table "asset_owners" looked up in "asset"`,
+		},
+		{
+			name:       "target read as a whole",
+			processors: []interface{}{lookup("asset", "asset_owners"), remapper("asset")},
+		},
+		{
+			name:       "unknown table",
+			processors: []interface{}{lookup("asset", "new_table"), remapper("asset.region")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkReferenceTableLookupColumns(tc.processors, make(map[string]string), func(table string) ([]string, bool) {
+				columns, ok := tables[table]
+				return columns, ok
+			})
+			if (err == nil) != (tc.err == "") || (err != nil && !strings.Contains(err.Error(), tc.err)) {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -21,6 +21,9 @@ func resourceDatadogSecurityMonitoringDefaultRule() *schema.Resource {
 		ReadContext:   resourceDatadogSecurityMonitoringDefaultRuleRead,
 		UpdateContext: resourceDatadogSecurityMonitoringDefaultRuleUpdate,
 		DeleteContext: resourceDatadogSecurityMonitoringDefaultRuleDelete,
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			return validateSecurityMonitoringLogsQueries(diff, "filter")
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -20,6 +20,7 @@ func resourceDatadogSecurityMonitoringRule() *schema.Resource {
 		ReadContext:   resourceDatadogSecurityMonitoringRuleRead,
 		UpdateContext: resourceDatadogSecurityMonitoringRuleUpdate,
 		DeleteContext: resourceDatadogSecurityMonitoringRuleDelete,
+		CustomizeDiff: resourceDatadogSecurityMonitoringRuleCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -356,6 +357,27 @@ func datadogSecurityMonitoringRuleSchema() map[string]*schema.Schema {
 	}
 }
 
+func resourceDatadogSecurityMonitoringRuleCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	return validateSecurityMonitoringLogsQueries(diff, "query", "filter")
+}
+
+// validateSecurityMonitoringLogsQueries validates the queries of the given blocks with the log search syntax. Only
+// log detection rules query logs: other rule types query signals or agent events, which use a different syntax.
+func validateSecurityMonitoringLogsQueries(diff *schema.ResourceDiff, blocks ...string) error {
+	if diff.Get("type").(string) != string(datadogV2.SECURITYMONITORINGRULETYPEREAD_LOG_DETECTION) {
+		return nil
+	}
+	for _, block := range blocks {
+		for i := range diff.Get(block).([]interface{}) {
+			key := fmt.Sprintf("%s.%d.query", block, i)
+			if _, errs := validators.ValidateLogsQuery(diff.Get(key), key); len(errs) > 0 {
+				return errs[0]
+			}
+		}
+	}
+	return nil
+}
+
 // securityMonitoringRuleInterface Common Interface to securityMonitoringRuleCreateInterface and SecurityMonitoringRuleReadInterface
 type securityMonitoringRuleInterface interface {
 	GetFilters() []datadogV2.SecurityMonitoringFilter
@@ -0,0 +1,39 @@
+package datadog
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSecurityMonitoringRuleLogsQueryValidation(t *testing.T) {
+	cases := []struct {
+		name     string
+		ruleType string
+		query    string
+		err      string
+	}{
+		{name: "valid log detection query", ruleType: "log_detection", query: "source:nginx @http.status_code:>=500"},
+		{name: "invalid log detection query", ruleType: "log_detection", query: "service:(web", err: `"query.0.query" is not a valid log search query`},
+		{name: "workload security query", ruleType: "workload_security", query: "@agent.rule_id:(open OR exec"},
+		{name: "signal correlation query", ruleType: "signal_correlation", query: "ruleId:abc-def ["},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":    "rule",
+				"message": "message",
+				"type":    tc.ruleType,
+				"query":   []interface{}{map[string]interface{}{"query": tc.query}},
+				"case":    []interface{}{map[string]interface{}{"status": "high", "condition": "a > 0"}},
+				"options": []interface{}{map[string]interface{}{"evaluation_window": 300, "keep_alive": 600, "max_signal_duration": 900}},
+			})
+			_, err := resourceDatadogSecurityMonitoringRule().Diff(context.Background(), nil, config, nil)
+			if (err == nil) != (tc.err == "") || (err != nil && !strings.Contains(err.Error(), tc.err)) {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
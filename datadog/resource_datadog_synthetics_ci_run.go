@@ -0,0 +1,270 @@
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Batch and result status returned while tests are running, which isn't part of SyntheticsStatus
+const syntheticsCIRunInProgress = "in_progress"
+
+// Attributes which trigger a new run when they change
+var syntheticsCIRunTriggerAttributes = []string{"public_ids", "triggers", "locations", "variables", "start_url"}
+
+// syntheticsCIBatch holds the fields of a batch, which may be in progress and can't always be decoded by SyntheticsBatchDetailsData
+type syntheticsCIBatch struct {
+	Status  string `json:"status"`
+	Results []struct {
+		Duration      float64 `json:"duration"`
+		ExecutionRule string  `json:"execution_rule"`
+		Location      string  `json:"location"`
+		ResultID      string  `json:"result_id"`
+		Status        string  `json:"status"`
+		TestName      string  `json:"test_name"`
+		TestPublicID  string  `json:"test_public_id"`
+	} `json:"results"`
+}
+
+func resourceDatadogSyntheticsCIRun() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Datadog synthetics CI run resource. It triggers synthetics tests through the CI API when it is created, or when its arguments change, waits for their results and fails if a blocking test fails. Deleting it doesn't trigger anything.",
+		CreateContext: resourceDatadogSyntheticsCIRunCreate,
+		ReadContext:   resourceDatadogSyntheticsCIRunRead,
+		UpdateContext: resourceDatadogSyntheticsCIRunUpdate,
+		DeleteContext: resourceDatadogSyntheticsCIRunDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"public_ids": {
+					Description: "Public IDs of the synthetics tests to run.",
+					Type:        schema.TypeSet,
+					Required:    true,
+					MinItems:    1,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"triggers": {
+					Description: "Arbitrary map of values that, when changed, will trigger a new run of the tests. For example the version of the deployed service.",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"locations": {
+					Description: "Locations to run the tests from, instead of the locations of the tests.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"variables": {
+					Description: "Values of the config variables of the tests.",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Sensitive:   true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"start_url": {
+					Description: "Start URL of the browser tests, or URL of the HTTP tests, instead of the one of the tests.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				// Computed values
+				"batch_id": {
+					Description: "ID of the batch of the last run.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"status": {
+					Description: "Status of the last run, `passed` or `failed`.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"result": {
+					Description: "Results of the last run, one for each test and location.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"public_id": {
+								Description: "Public ID of the test.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"test_name": {
+								Description: "Name of the test.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"location": {
+								Description: "Location of the run.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"result_id": {
+								Description: "ID of the result.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"status": {
+								Description: "Status of the result, `passed`, `failed` or `skipped`.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"execution_rule": {
+								Description: "Execution rule of the test, failures of `non_blocking` tests don't fail the run.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"duration": {
+								Description: "Duration of the run in milliseconds.",
+								Type:        schema.TypeFloat,
+								Computed:    true,
+							},
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+func resourceDatadogSyntheticsCIRunCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return runSyntheticsCITests(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
+}
+
+func resourceDatadogSyntheticsCIRunRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Results of a run don't change, there is nothing to refresh
+	return nil
+}
+
+func resourceDatadogSyntheticsCIRunUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChanges(syntheticsCIRunTriggerAttributes...) {
+		return nil
+	}
+	diags := runSyntheticsCITests(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		// Keep the previous arguments in the state, so that the next apply runs the tests again
+		d.Partial(true)
+	}
+	return diags
+}
+
+func resourceDatadogSyntheticsCIRunDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Runs can't be deleted, the resource is only removed from the state
+	return nil
+}
+
+func runSyntheticsCITests(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) diag.Diagnostics {
+	providerConf := meta.(*ProviderConfiguration)
+	apiInstances := providerConf.DatadogApiInstances
+	auth := providerConf.Auth
+
+	triggerResponse, httpResponse, err := apiInstances.GetSyntheticsApiV1().TriggerCITests(auth, *buildSyntheticsCITestBody(d))
+	if err != nil {
+		return utils.TranslateClientErrorDiag(err, httpResponse, "error triggering synthetics tests")
+	}
+	// Only the batch ID is used, the rest of the response isn't checked for unparsed elements
+	batchID := triggerResponse.GetBatchId()
+	if batchID == "" {
+		return diag.Errorf("error triggering synthetics tests: no batch was created, check the public IDs")
+	}
+	// The ID is set before waiting so that a failed run is tainted, and triggered again by the next apply
+	d.SetId(batchID)
+	d.Set("batch_id", batchID)
+
+	var batch syntheticsCIBatch
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		batchResponse, httpResponse, err := apiInstances.GetSyntheticsApiV1().GetSyntheticsCIBatch(auth, batchID)
+		if err != nil {
+			if httpResponse != nil && httpResponse.StatusCode == 404 {
+				return retry.RetryableError(fmt.Errorf("synthetics batch %s not created yet", batchID))
+			}
+			return retry.NonRetryableError(utils.TranslateClientError(err, httpResponse, "error getting synthetics batch"))
+		}
+		// In progress batches aren't decoded by the client, the raw object is read instead
+		rawBatch, err := json.Marshal(batchResponse.GetData())
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		batch = syntheticsCIBatch{}
+		if err := json.Unmarshal(rawBatch, &batch); err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if batch.Status == "" || batch.Status == syntheticsCIRunInProgress {
+			return retry.RetryableError(fmt.Errorf("synthetics batch %s still in progress", batchID))
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.Errorf("error waiting for the results of synthetics batch %s: %s", batchID, err)
+	}
+
+	results := make([]map[string]interface{}, 0, len(batch.Results))
+	var failures []string
+	for _, result := range batch.Results {
+		results = append(results, map[string]interface{}{
+			"public_id":      result.TestPublicID,
+			"test_name":      result.TestName,
+			"location":       result.Location,
+			"result_id":      result.ResultID,
+			"status":         result.Status,
+			"execution_rule": result.ExecutionRule,
+			"duration":       result.Duration,
+		})
+		if result.Status == string(datadogV1.SYNTHETICSSTATUS_failed) && result.ExecutionRule != string(datadogV1.SYNTHETICSTESTEXECUTIONRULE_NON_BLOCKING) {
+			failures = append(failures, fmt.Sprintf("%s (%s) from %s, result %s", result.TestName, result.TestPublicID, result.Location, result.ResultID))
+		}
+	}
+	status := string(datadogV1.SYNTHETICSSTATUS_PASSED)
+	if len(failures) > 0 {
+		status = string(datadogV1.SYNTHETICSSTATUS_failed)
+	}
+	d.Set("status", status)
+	if err := d.Set("result", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(failures) > 0 {
+		return diag.Errorf("synthetics tests of batch %s failed:\n  %s", batchID, strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+func buildSyntheticsCITestBody(d *schema.ResourceData) *datadogV1.SyntheticsCITestBody {
+	var locations []string
+	for _, location := range d.Get("locations").(*schema.Set).List() {
+		locations = append(locations, location.(string))
+	}
+	variables := make(map[string]string)
+	for name, value := range d.Get("variables").(map[string]interface{}) {
+		variables[name] = value.(string)
+	}
+	startURL, hasStartURL := d.GetOk("start_url")
+
+	body := datadogV1.NewSyntheticsCITestBody()
+	for _, publicID := range d.Get("public_ids").(*schema.Set).List() {
+		test := datadogV1.NewSyntheticsCITest(publicID.(string))
+		if len(locations) > 0 {
+			test.SetLocations(locations)
+		}
+		if len(variables) > 0 {
+			test.SetVariables(variables)
+		}
+		if hasStartURL {
+			test.SetStartUrl(startURL.(string))
+		}
+		body.Tests = append(body.Tests, *test)
+	}
+	return body
+}
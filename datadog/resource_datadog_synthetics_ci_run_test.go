@@ -0,0 +1,161 @@
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestRunSyntheticsCITests(t *testing.T) {
+	passed := map[string]interface{}{
+		"test_public_id": "abc-def-ghi", "test_name": "Checkout", "location": "aws:eu-central-1",
+		"result_id": "1111", "status": "passed", "execution_rule": "blocking", "duration": 1250,
+	}
+	failedNonBlocking := map[string]interface{}{
+		"test_public_id": "jkl-mno-pqr", "test_name": "Search", "location": "aws:eu-central-1",
+		"result_id": "2222", "status": "failed", "execution_rule": "non_blocking", "duration": 800,
+	}
+	failedBlocking := map[string]interface{}{
+		"test_public_id": "abc-def-ghi", "test_name": "Checkout", "location": "aws:eu-central-1",
+		"result_id": "3333", "status": "failed", "execution_rule": "blocking", "duration": 900,
+	}
+	cases := []struct {
+		name string
+		// batches are returned by successive polls, the last one is repeated
+		batches        []map[string]interface{}
+		batchNotFound  bool
+		timeout        time.Duration
+		expectedStatus string
+		expectedPolls  int32
+		err            string
+	}{
+		{
+			name: "passed after polling",
+			batches: []map[string]interface{}{
+				{"status": "in_progress", "results": []interface{}{}},
+				{"status": "passed", "results": []interface{}{passed, failedNonBlocking}},
+			},
+			expectedStatus: "passed",
+			expectedPolls:  2,
+		},
+		{
+			name:          "batch not created yet",
+			batchNotFound: true,
+			batches: []map[string]interface{}{
+				{"status": "passed", "results": []interface{}{passed}},
+			},
+			expectedStatus: "passed",
+			expectedPolls:  2,
+		},
+		{
+			name: "blocking failure",
+			batches: []map[string]interface{}{
+				{"status": "failed", "results": []interface{}{failedBlocking, failedNonBlocking}},
+			},
+			expectedStatus: "failed",
+			expectedPolls:  1,
+			err:            `synthetics tests of batch batch-1 failed:\s+Checkout \(abc-def-ghi\) from aws:eu-central-1, result 3333$`,
+		},
+		{
+			name: "timeout",
+			batches: []map[string]interface{}{
+				{"status": "in_progress", "results": []interface{}{}},
+			},
+			timeout: 2 * time.Second,
+			err:     `error waiting for the results of synthetics batch batch-1: .*still in progress`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var polls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v1/synthetics/tests/trigger/ci":
+					var body map[string]interface{}
+					json.NewDecoder(r.Body).Decode(&body)
+					tests, _ := body["tests"].([]interface{})
+					if len(tests) != 1 || tests[0].(map[string]interface{})["public_id"] != "abc-def-ghi" {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					json.NewEncoder(w).Encode(map[string]interface{}{"batch_id": "batch-1"})
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v1/synthetics/ci/batch/batch-1":
+					poll := atomic.AddInt32(&polls, 1)
+					if tc.batchNotFound && poll == 1 {
+						w.WriteHeader(http.StatusNotFound)
+						json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{"Batch not found"}})
+						return
+					}
+					index := int(poll) - 1
+					if tc.batchNotFound {
+						index--
+					}
+					if index >= len(tc.batches) {
+						index = len(tc.batches) - 1
+					}
+					json.NewEncoder(w).Encode(map[string]interface{}{"data": tc.batches[index]})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			p := Provider()
+			if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+				"api_key":  "api-key",
+				"app_key":  "app-key",
+				"api_url":  server.URL,
+				"validate": "false",
+			})); diags.HasError() {
+				t.Fatalf("error configuring the provider: %v", diags)
+			}
+			d := schema.TestResourceDataRaw(t, resourceDatadogSyntheticsCIRun().SchemaMap(), map[string]interface{}{
+				"public_ids": []interface{}{"abc-def-ghi"},
+			})
+
+			timeout := tc.timeout
+			if timeout == 0 {
+				timeout = time.Minute
+			}
+			diags := runSyntheticsCITests(context.Background(), d, p.Meta(), timeout)
+			var errs []string
+			for _, diag := range diags {
+				errs = append(errs, diag.Summary)
+			}
+			err := strings.Join(errs, "; ")
+			if (err == "") != (tc.err == "") || (tc.err != "" && !regexp.MustCompile(tc.err).MatchString(err)) {
+				t.Fatalf("expected error matching %q, got %q", tc.err, err)
+			}
+			if d.Id() != "batch-1" || d.Get("batch_id") != "batch-1" {
+				t.Errorf("expected the ID and batch ID to be batch-1, got %q and %q", d.Id(), d.Get("batch_id"))
+			}
+			if tc.expectedPolls > 0 && atomic.LoadInt32(&polls) != tc.expectedPolls {
+				t.Errorf("expected %d polls, got %d", tc.expectedPolls, polls)
+			}
+			if status := d.Get("status").(string); status != tc.expectedStatus {
+				t.Errorf("expected status %q, got %q", tc.expectedStatus, status)
+			}
+			if tc.expectedStatus == "" {
+				return
+			}
+			results := d.Get("result").([]interface{})
+			batch := tc.batches[len(tc.batches)-1]
+			if len(results) != len(batch["results"].([]interface{})) {
+				t.Fatalf("expected %d results, got %v", len(batch["results"].([]interface{})), results)
+			}
+			first := results[0].(map[string]interface{})
+			if first["public_id"] != "abc-def-ghi" || first["test_name"] != "Checkout" || first["result_id"] == "" {
+				t.Errorf("unexpected first result %v", first)
+			}
+		})
+	}
+}
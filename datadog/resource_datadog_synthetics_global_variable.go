@@ -23,6 +23,7 @@ func resourceDatadogSyntheticsGlobalVariable() *schema.Resource {
 		ReadContext:   resourceDatadogSyntheticsGlobalVariableRead,
 		UpdateContext: resourceDatadogSyntheticsGlobalVariableUpdate,
 		DeleteContext: resourceDatadogSyntheticsGlobalVariableDelete,
+		CustomizeDiff: resourceDatadogSyntheticsGlobalVariableCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -46,10 +47,14 @@ func resourceDatadogSyntheticsGlobalVariable() *schema.Resource {
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
 				"value": {
-					Description: "The value of the global variable.",
+					Description: "The value of the global variable. For TOTP variables, the base32 encoded secret shared by the authenticator setup page. The value is stored in plain text in the state, including TOTP secrets.",
 					Type:        schema.TypeString,
 					Required:    true,
 					Sensitive:   true,
+					// TOTP secrets are sent normalized
+					DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+						return d.Get("is_totp").(bool) && validators.NormalizeTOTPSecret(oldValue) == validators.NormalizeTOTPSecret(newValue)
+					},
 				},
 				"secure": {
 					Description: "If set to true, the value of the global variable is hidden. Defaults to `false`.",
@@ -57,6 +62,13 @@ func resourceDatadogSyntheticsGlobalVariable() *schema.Resource {
 					Type:        schema.TypeBool,
 					Optional:    true,
 				},
+				"is_totp": {
+					Description:   "If set to true, the global variable is a TOTP variable generating one-time passwords from the secret in `value`, for example to log in through MFA in browser tests. The secret is stored in plain text in the state, like any `value`. Defaults to `false`.",
+					Type:          schema.TypeBool,
+					Optional:      true,
+					Default:       false,
+					ConflictsWith: []string{"parse_test_id"},
+				},
 				"parse_test_id": {
 					Description: "Id of the Synthetics test to use for a variable from test.",
 					Type:        schema.TypeString,
@@ -102,7 +114,7 @@ func resourceDatadogSyntheticsGlobalVariable() *schema.Resource {
 							},
 							"local_variable_name": {
 								Type:        schema.TypeString,
-								Description: "When type is `local_variable`, name of the local variable to use to extract the value.",
+								Description: "When type is `local_variable`, name of the local variable of a browser test, or of a value extracted by a step of a multistep API test, to use to extract the value.",
 								Optional:    true,
 							},
 						},
@@ -223,7 +235,7 @@ func resourceDatadogSyntheticsGlobalVariableUpdate(ctx context.Context, d *schem
 	syntheticsGlobalVariable := buildSyntheticsGlobalVariableStruct(d)
 	if _, httpResponse, err := apiInstances.GetSyntheticsApiV1().EditGlobalVariable(auth, d.Id(), *syntheticsGlobalVariable); err != nil {
 		// If the Update callback returns with or without an error, the full state is saved.
-		utils.TranslateClientErrorDiag(err, httpResponse, "error updating synthetics global variable")
+		return utils.TranslateClientErrorDiag(err, httpResponse, "error updating synthetics global variable")
 	}
 
 	// Return the read function to ensure the state is reflected in the terraform.state file
@@ -244,6 +256,66 @@ func resourceDatadogSyntheticsGlobalVariableDelete(ctx context.Context, d *schem
 	return nil
 }
 
+func resourceDatadogSyntheticsGlobalVariableCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Get("is_totp").(bool) && diff.NewValueKnown("value") {
+		if err := validators.ValidateTOTPSecret(diff.Get("value").(string)); err != nil {
+			return fmt.Errorf("invalid value: %s", err)
+		}
+	}
+
+	if _, ok := diff.GetOk("parse_test_options.0"); !ok {
+		return nil
+	}
+	parseTestOptionsType := diff.Get("parse_test_options.0.type").(string)
+	field := diff.Get("parse_test_options.0.field").(string)
+	localVariableName := diff.Get("parse_test_options.0.local_variable_name").(string)
+	_, hasParser := diff.GetOk("parse_test_options.0.parser.0")
+
+	switch datadogV1.SyntheticsGlobalVariableParseTestOptionsType(parseTestOptionsType) {
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSETESTOPTIONSTYPE_LOCAL_VARIABLE:
+		if localVariableName == "" && diff.NewValueKnown("parse_test_options.0.local_variable_name") {
+			return fmt.Errorf("parse_test_options.local_variable_name is required when type is `local_variable`")
+		}
+		if hasParser || field != "" {
+			return fmt.Errorf("parse_test_options.parser and parse_test_options.field can't be set when type is `local_variable`, the value is extracted by the test")
+		}
+		return nil
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSETESTOPTIONSTYPE_HTTP_HEADER:
+		if field == "" && diff.NewValueKnown("parse_test_options.0.field") {
+			return fmt.Errorf("parse_test_options.field is required when type is `http_header`")
+		}
+	}
+	if localVariableName != "" {
+		return fmt.Errorf("parse_test_options.local_variable_name can only be set when type is `local_variable`")
+	}
+	if !hasParser {
+		return fmt.Errorf("parse_test_options.parser is required when type is `%s`", parseTestOptionsType)
+	}
+
+	parserValue := diff.Get("parse_test_options.0.parser.0.value").(string)
+	if !diff.NewValueKnown("parse_test_options.0.parser.0.value") {
+		return nil
+	}
+	var err error
+	switch datadogV1.SyntheticsGlobalVariableParserType(diff.Get("parse_test_options.0.parser.0.type").(string)) {
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_RAW:
+		return nil
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_JSON_PATH:
+		err = validators.ValidateJSONPath(parserValue)
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_REGEX:
+		err = validators.ValidateRegex(parserValue)
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_X_PATH:
+		err = validators.ValidateXPath(parserValue)
+	}
+	if parserValue == "" {
+		return fmt.Errorf("parse_test_options.parser.value is required for parser type `%s`", diff.Get("parse_test_options.0.parser.0.type"))
+	}
+	if err != nil {
+		return fmt.Errorf("invalid parse_test_options.parser.value: %s", err)
+	}
+	return nil
+}
+
 func buildSyntheticsGlobalVariableStruct(d *schema.ResourceData) *datadogV1.SyntheticsGlobalVariable {
 	syntheticsGlobalVariable := datadogV1.NewSyntheticsGlobalVariableWithDefaults()
 
@@ -263,7 +335,11 @@ func buildSyntheticsGlobalVariableStruct(d *schema.ResourceData) *datadogV1.Synt
 
 	syntheticsGlobalVariableValue := datadogV1.SyntheticsGlobalVariableValue{}
 
-	syntheticsGlobalVariableValue.SetValue(d.Get("value").(string))
+	value := d.Get("value").(string)
+	if d.Get("is_totp").(bool) {
+		value = validators.NormalizeTOTPSecret(value)
+	}
+	syntheticsGlobalVariableValue.SetValue(value)
 	syntheticsGlobalVariableValue.SetSecure(d.Get("secure").(bool))
 
 	if _, ok := d.GetOk("options.0"); ok {
@@ -281,6 +357,11 @@ func buildSyntheticsGlobalVariableStruct(d *schema.ResourceData) *datadogV1.Synt
 
 	syntheticsGlobalVariable.SetValue(syntheticsGlobalVariableValue)
 
+	// The flag isn't part of the client models yet
+	if d.Get("is_totp").(bool) {
+		syntheticsGlobalVariable.AdditionalProperties = map[string]interface{}{"is_totp": true}
+	}
+
 	if parseTestID, ok := d.GetOk("parse_test_id"); ok {
 		if _, ok := d.GetOk("parse_test_options.0"); ok {
 			syntheticsGlobalVariable.SetParseTestPublicId(parseTestID.(string))
@@ -337,6 +418,8 @@ func updateSyntheticsGlobalVariableLocalState(d *schema.ResourceData, synthetics
 	}
 
 	d.Set("secure", syntheticsGlobalVariableValue.GetSecure())
+	isTotp, _ := syntheticsGlobalVariable.AdditionalProperties["is_totp"].(bool)
+	d.Set("is_totp", isTotp)
 
 	d.Set("tags", syntheticsGlobalVariable.Tags)
 
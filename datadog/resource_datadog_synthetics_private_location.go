@@ -43,7 +43,7 @@ func resourceDatadogSyntheticsPrivateLocation() *schema.Resource {
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
 				"config": {
-					Description: "Configuration skeleton for the private location. See installation instructions of the private location on how to use this configuration.",
+					Description: "Configuration skeleton for the private location. See installation instructions of the private location on how to use this configuration, or the `datadog_synthetics_private_location_deployment` data source to render deployment artifacts from it.",
 					Type:        schema.TypeString,
 					Computed:    true,
 					Sensitive:   true,
@@ -8,18 +8,22 @@ import (
 	"context"
 	b64 "encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	_nethttp "net/http"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -33,6 +37,7 @@ func resourceDatadogSyntheticsTest() *schema.Resource {
 		ReadContext:   resourceDatadogSyntheticsTestRead,
 		UpdateContext: resourceDatadogSyntheticsTestUpdate,
 		DeleteContext: resourceDatadogSyntheticsTestDelete,
+		CustomizeDiff: resourceDatadogSyntheticsTestCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -70,9 +75,44 @@ func resourceDatadogSyntheticsTest() *schema.Resource {
 				"request_proxy":              syntheticsTestRequestProxy(),
 				"request_client_certificate": syntheticsTestRequestClientCertificate(),
 				"request_metadata":           syntheticsTestRequestMetadata(),
-				"assertion":                  syntheticsAPIAssertion(),
-				"browser_variable":           syntheticsBrowserVariable(),
-				"config_variable":            syntheticsConfigVariable(),
+				"client_certificate_expiry_warning_days": {
+					Description:  "Number of days before the expiry of a client certificate from which the provider warns about it. The warning is reported when the test is read, created or updated, so by refreshes and applies. Plans skipping the refresh only log it, with the `WARN` level, as warnings can't be returned while computing a plan. Defaults to `30`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"client_certificate_info": {
+					Description: "Details of the PEM encoded client certificates of the test, parsed from the configuration since their content isn't stored in the state.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"path": {
+								Description: "Path of the `request_client_certificate` block holding the certificate.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"subject": {
+								Description: "Subject of the certificate.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"issuer": {
+								Description: "Issuer of the certificate.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"not_after": {
+								Description: "Expiry date of the certificate, in RFC 3339 format.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+						},
+					},
+				},
+				"assertion":        syntheticsAPIAssertion(),
+				"browser_variable": syntheticsBrowserVariable(),
+				"config_variable":  syntheticsConfigVariable(),
 				"device_ids": {
 					Description: "Required if `type = \"browser\"`. Array with the different device IDs used to run the test.",
 					Type:        schema.TypeList,
@@ -1042,7 +1082,7 @@ func syntheticsBrowserStepParams() schema.Schema {
 					Optional:    true,
 				},
 				"subtest_public_id": {
-					Description: "ID of the Synthetics test to use as subtest.",
+					Description: "ID of the browser test to play as subtest in a `playSubTest` step. Referencing the `id` of another `datadog_synthetics_test` resource ensures Terraform doesn't delete the subtest while this step plays it.",
 					Type:        schema.TypeString,
 					Optional:    true,
 				},
@@ -1244,7 +1284,7 @@ func resourceDatadogSyntheticsTestCreate(ctx context.Context, d *schema.Resource
 
 		d.SetId(getSyntheticsApiTestResponse.GetPublicId())
 
-		return updateSyntheticsAPITestLocalState(d, &getSyntheticsApiTestResponse)
+		return append(updateSyntheticsAPITestLocalState(d, &getSyntheticsApiTestResponse), updateSyntheticsClientCertificateLocalState(d)...)
 	} else if *testType == datadogV1.SYNTHETICSTESTDETAILSTYPE_BROWSER {
 		syntheticsTest := buildSyntheticsBrowserTestStruct(d)
 		createdSyntheticsTest, httpResponse, err := apiInstances.GetSyntheticsApiV1().CreateSyntheticsBrowserTest(auth, *syntheticsTest)
@@ -1279,7 +1319,7 @@ func resourceDatadogSyntheticsTestCreate(ctx context.Context, d *schema.Resource
 
 		d.SetId(getSyntheticsBrowserTestResponse.GetPublicId())
 
-		return updateSyntheticsBrowserTestLocalState(d, &getSyntheticsBrowserTestResponse)
+		return append(updateSyntheticsBrowserTestLocalState(d, &getSyntheticsBrowserTestResponse), updateSyntheticsClientCertificateLocalState(d)...)
 	}
 
 	return diag.Errorf("unrecognized synthetics test type %v", testType)
@@ -1329,13 +1369,13 @@ func resourceDatadogSyntheticsTestRead(ctx context.Context, d *schema.ResourceDa
 		if err := utils.CheckForUnparsed(syntheticsBrowserTest); err != nil {
 			return diag.FromErr(err)
 		}
-		return updateSyntheticsBrowserTestLocalState(d, &syntheticsBrowserTest)
+		return append(updateSyntheticsBrowserTestLocalState(d, &syntheticsBrowserTest), updateSyntheticsClientCertificateLocalState(d)...)
 	}
 
 	if err := utils.CheckForUnparsed(syntheticsAPITest); err != nil {
 		return diag.FromErr(err)
 	}
-	return updateSyntheticsAPITestLocalState(d, &syntheticsAPITest)
+	return append(updateSyntheticsAPITestLocalState(d, &syntheticsAPITest), updateSyntheticsClientCertificateLocalState(d)...)
 }
 
 func resourceDatadogSyntheticsTestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -1355,7 +1395,7 @@ func resourceDatadogSyntheticsTestUpdate(ctx context.Context, d *schema.Resource
 		if err := utils.CheckForUnparsed(updatedTest); err != nil {
 			return diag.FromErr(err)
 		}
-		return updateSyntheticsAPITestLocalState(d, &updatedTest)
+		return append(updateSyntheticsAPITestLocalState(d, &updatedTest), updateSyntheticsClientCertificateLocalState(d)...)
 	} else if *testType == datadogV1.SYNTHETICSTESTDETAILSTYPE_BROWSER {
 		syntheticsTest := buildSyntheticsBrowserTestStruct(d)
 		updatedTest, httpResponse, err := apiInstances.GetSyntheticsApiV1().UpdateBrowserTest(auth, d.Id(), *syntheticsTest)
@@ -1366,7 +1406,7 @@ func resourceDatadogSyntheticsTestUpdate(ctx context.Context, d *schema.Resource
 		if err := utils.CheckForUnparsed(updatedTest); err != nil {
 			return diag.FromErr(err)
 		}
-		return updateSyntheticsBrowserTestLocalState(d, &updatedTest)
+		return append(updateSyntheticsBrowserTestLocalState(d, &updatedTest), updateSyntheticsClientCertificateLocalState(d)...)
 	}
 
 	return diag.Errorf("unrecognized synthetics test type %v", testType)
@@ -1379,6 +1419,15 @@ func resourceDatadogSyntheticsTestDelete(ctx context.Context, d *schema.Resource
 
 	syntheticsDeleteTestsPayload := datadogV1.SyntheticsDeleteTestsPayload{PublicIds: []string{d.Id()}}
 	if _, httpResponse, err := apiInstances.GetSyntheticsApiV1().DeleteTests(auth, syntheticsDeleteTestsPayload); err != nil {
+		// Browser tests can't be deleted while other tests play them as subtests, name these tests
+		// instead of only returning the API error. They can't be looked up at plan time, as CustomizeDiff
+		// isn't called for destroy plans and no attribute forces the replacement of a test. Finding them
+		// fetches every browser test, so it's only done once the API refused the deletion.
+		if d.Get("type").(string) == string(datadogV1.SYNTHETICSTESTDETAILSTYPE_BROWSER) {
+			if parents, parentsErr := getSyntheticsSubtestParents(auth, apiInstances, d.Id()); parentsErr == nil && len(parents) > 0 {
+				return diag.Errorf("synthetics test %s is used as a subtest by %s, remove the playSubTest steps referencing it before deleting it", d.Id(), strings.Join(parents, ", "))
+			}
+		}
 		// The resource is assumed to still exist, and all prior state is preserved.
 		return utils.TranslateClientErrorDiag(err, httpResponse, "error deleting synthetics test")
 	}
@@ -1387,6 +1436,342 @@ func resourceDatadogSyntheticsTestDelete(ctx context.Context, d *schema.Resource
 	return nil
 }
 
+// Names of the variables defined by config_variable and extracted_value blocks
+var syntheticsVariableNameRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+func resourceDatadogSyntheticsTestCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	var errs []error
+	subtype := diff.Get("subtype").(string)
+	for i, assertion := range diff.Get("assertion").([]interface{}) {
+		for _, err := range validateSyntheticsAssertion(assertion) {
+			errs = append(errs, fmt.Errorf("assertion.%d: %s", i, err))
+		}
+		if err := validateSyntheticsCertificateAssertion(assertion, subtype); err != nil {
+			errs = append(errs, fmt.Errorf("assertion.%d: %s", i, err))
+		}
+	}
+
+	// The state only holds hashes of the client certificates, their details are parsed from the configuration
+	if certificates, known := syntheticsRawClientCertificates(diff.GetRawConfig()); !known {
+		if err := diff.SetNewComputed("client_certificate_info"); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		info := make([]interface{}, 0, len(certificates))
+		for _, certificate := range certificates {
+			// Contents which aren't PEM encoded, like placeholders, are sent as is
+			if !strings.Contains(certificate.cert, "-----BEGIN") {
+				continue
+			}
+			parsed, err := validators.ParseClientCertificate(certificate.cert, certificate.key)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s", certificate.path, err))
+				continue
+			}
+			info = append(info, map[string]interface{}{
+				"path":      certificate.path,
+				"subject":   parsed.Subject.String(),
+				"issuer":    parsed.Issuer.String(),
+				"not_after": parsed.NotAfter.UTC().Format(time.RFC3339),
+			})
+		}
+		if !reflect.DeepEqual(info, diff.Get("client_certificate_info")) && (len(info) > 0 || diff.Get("client_certificate_info.#").(int) > 0) {
+			if err := diff.SetNew("client_certificate_info", info); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		// CustomizeDiff can't return warnings, the certificates expiring soon are logged when planning and reported as
+		// warnings when the test is read, created or updated
+		for _, warning := range syntheticsClientCertificateExpiryWarnings(diff.Id(), info, diff.Get("client_certificate_expiry_warning_days").(int)) {
+			log.Printf("[WARN] %s: %s", warning.Summary, warning.Detail)
+		}
+	}
+
+	// Variable references are only checked when all the names are known
+	checkReferences := subtype == string(datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_MULTI) &&
+		diff.NewValueKnown("config_variable") && diff.NewValueKnown("api_step")
+	errs = append(errs, validateSyntheticsAPISteps(diff.Get("config_variable").([]interface{}), diff.Get("api_step").([]interface{}), checkReferences)...)
+
+	errs = append(errs, validateSyntheticsSubtests(ctx, diff, meta)...)
+	return errors.Join(errs...)
+}
+
+// validateSyntheticsAPISteps checks the assertions and extracted values of the steps of a multistep API test, and
+// the variables they reference when checkReferences is true. A step can reference the test config variables, then
+// the values extracted by previous steps. Values that are not known yet are read as empty strings, references are
+// only checked when all names are known.
+func validateSyntheticsAPISteps(configVariables []interface{}, steps []interface{}, checkReferences bool) []error {
+	var errs []error
+	availableVariables := make(map[string]bool)
+	for _, variable := range configVariables {
+		if variableMap, ok := variable.(map[string]interface{}); ok {
+			name, _ := variableMap["name"].(string)
+			checkReferences = checkReferences && name != ""
+			availableVariables[name] = true
+		}
+	}
+	for i, step := range steps {
+		stepMap, ok := step.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		stepPath := fmt.Sprintf("api_step.%d (%s)", i, stepMap["name"])
+		for j, assertion := range stepMap["assertion"].([]interface{}) {
+			for _, err := range validateSyntheticsAssertion(assertion) {
+				errs = append(errs, fmt.Errorf("%s assertion.%d: %s", stepPath, j, err))
+			}
+			stepSubtype, _ := stepMap["subtype"].(string)
+			if err := validateSyntheticsCertificateAssertion(assertion, stepSubtype); err != nil {
+				errs = append(errs, fmt.Errorf("%s assertion.%d: %s", stepPath, j, err))
+			}
+		}
+		if checkReferences {
+			for k, v := range stepMap {
+				if k == "name" || k == "extracted_value" {
+					continue
+				}
+				for _, name := range syntheticsVariableReferences(v) {
+					if !availableVariables[name] {
+						errs = append(errs, fmt.Errorf("%s: variable %s is neither a config_variable nor extracted by a previous step", stepPath, name))
+					}
+				}
+			}
+		}
+		for j, extractedValue := range stepMap["extracted_value"].([]interface{}) {
+			extractedValueMap, ok := extractedValue.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := extractedValueMap["name"].(string)
+			checkReferences = checkReferences && name != ""
+			availableVariables[name] = true
+			if err := validateSyntheticsParser(extractedValueMap["parser"]); err != nil {
+				errs = append(errs, fmt.Errorf("%s extracted_value.%d: %s", stepPath, j, err))
+			}
+		}
+	}
+	return errs
+}
+
+// validateSyntheticsSubtests checks the playSubTest steps of a browser test. Subtests are fetched when
+// their public ID is known and changed. The ID of a subtest created in the same run is only known once it is
+// created, so such references are checked, and fail, during the apply rather than the plan.
+func validateSyntheticsSubtests(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) []error {
+	var errs []error
+	for i, step := range diff.Get("browser_step").([]interface{}) {
+		stepMap, ok := step.(map[string]interface{})
+		if !ok || stepMap["type"] != string(datadogV1.SYNTHETICSSTEPTYPE_PLAY_SUB_TEST) {
+			continue
+		}
+		stepPath := fmt.Sprintf("browser_step.%d (%s)", i, stepMap["name"])
+		subtestKey := fmt.Sprintf("browser_step.%d.params.0.subtest_public_id", i)
+		if !diff.NewValueKnown(subtestKey) {
+			continue
+		}
+		oldSubtestID, newSubtestID := diff.GetChange(subtestKey)
+		subtestID := newSubtestID.(string)
+		if subtestID == "" {
+			errs = append(errs, fmt.Errorf("%s: params.subtest_public_id is required for a playSubTest step", stepPath))
+			continue
+		}
+		if subtestID == diff.Id() {
+			errs = append(errs, fmt.Errorf("%s: a test can't play itself as a subtest", stepPath))
+			continue
+		}
+		providerConf, ok := meta.(*ProviderConfiguration)
+		if !ok || oldSubtestID.(string) == subtestID {
+			continue
+		}
+
+		subtest, httpResponse, err := providerConf.DatadogApiInstances.GetSyntheticsApiV1().GetTest(providerConf.Auth, subtestID)
+		if err != nil {
+			if httpResponse != nil && httpResponse.StatusCode == 404 {
+				errs = append(errs, fmt.Errorf("%s: subtest %s doesn't exist", stepPath, subtestID))
+			} else {
+				errs = append(errs, utils.TranslateClientError(err, httpResponse, fmt.Sprintf("%s: error getting subtest %s", stepPath, subtestID)))
+			}
+			continue
+		}
+		if subtest.GetType() != datadogV1.SYNTHETICSTESTDETAILSTYPE_BROWSER {
+			errs = append(errs, fmt.Errorf("%s: subtest %s is a %s test, only browser tests can be played as subtests", stepPath, subtestID, subtest.GetType()))
+		}
+	}
+	return errs
+}
+
+// getSyntheticsSubtestParents returns the browser tests playing the given test as a subtest. The list endpoint
+// doesn't return the steps of browser tests, so each browser test is fetched.
+func getSyntheticsSubtestParents(auth context.Context, apiInstances *utils.ApiInstances, publicID string) ([]string, error) {
+	var parents []string
+	items, cancel := apiInstances.GetSyntheticsApiV1().ListTestsWithPagination(auth)
+	defer cancel()
+	for item := range items {
+		if item.Error != nil {
+			return nil, item.Error
+		}
+		if item.Item.GetType() != datadogV1.SYNTHETICSTESTDETAILSTYPE_BROWSER || item.Item.GetPublicId() == publicID {
+			continue
+		}
+		test, _, err := apiInstances.GetSyntheticsApiV1().GetBrowserTest(auth, item.Item.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		for _, step := range test.GetSteps() {
+			if step.GetType() != datadogV1.SYNTHETICSSTEPTYPE_PLAY_SUB_TEST {
+				continue
+			}
+			if params, ok := step.GetParams().(map[string]interface{}); ok && params["subtestPublicId"] == publicID {
+				parents = append(parents, fmt.Sprintf("%q (%s)", test.GetName(), test.GetPublicId()))
+				break
+			}
+		}
+	}
+	return parents, nil
+}
+
+// validateSyntheticsAssertion returns the problems of an assertion block which would be rejected by the API,
+// or silently changed by buildAssertions. Empty values may be unknown at plan time and are skipped.
+func validateSyntheticsAssertion(assertion interface{}) []error {
+	assertionMap, ok := assertion.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var errs []error
+	assertionType, _ := assertionMap["type"].(string)
+	assertionOperator, _ := assertionMap["operator"].(string)
+	switch assertionOperator {
+	case string(datadogV1.SYNTHETICSASSERTIONJSONPATHOPERATOR_VALIDATES_JSON_PATH):
+		if targetMap := firstSyntheticsBlock(assertionMap["targetjsonpath"]); targetMap != nil {
+			if path, _ := targetMap["jsonpath"].(string); path != "" {
+				if err := validators.ValidateJSONPath(path); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			errs = append(errs, validateSyntheticsSubTargetValue(targetMap)...)
+		}
+	case string(datadogV1.SYNTHETICSASSERTIONXPATHOPERATOR_VALIDATES_X_PATH):
+		if targetMap := firstSyntheticsBlock(assertionMap["targetxpath"]); targetMap != nil {
+			if path, _ := targetMap["xpath"].(string); path != "" {
+				if err := validators.ValidateXPath(path); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			errs = append(errs, validateSyntheticsSubTargetValue(targetMap)...)
+		}
+	default:
+		target, _ := assertionMap["target"].(string)
+		if target == "" || validators.SyntheticsTemplateRegexp.MatchString(target) {
+			break
+		}
+		switch {
+		case assertionOperator == string(datadogV1.SYNTHETICSASSERTIONOPERATOR_MATCHES) || assertionOperator == string(datadogV1.SYNTHETICSASSERTIONOPERATOR_DOES_NOT_MATCH):
+			if err := validators.ValidateRegex(target); err != nil {
+				errs = append(errs, err)
+			}
+		case isTargetOfTypeInt(datadogV1.SyntheticsAssertionType(assertionType), datadogV1.SyntheticsAssertionOperator(assertionOperator)):
+			if _, err := strconv.Atoi(target); err != nil {
+				errs = append(errs, fmt.Errorf("target %q must be an integer for %s assertions", target, assertionType))
+			}
+		case assertionType == string(datadogV1.SYNTHETICSASSERTIONTYPE_PACKET_LOSS_PERCENTAGE):
+			if _, err := strconv.ParseFloat(target, 64); err != nil {
+				errs = append(errs, fmt.Errorf("target %q must be a number for %s assertions", target, assertionType))
+			}
+		}
+	}
+	return errs
+}
+
+// validateSyntheticsCertificateAssertion checks that certificate assertions, which check the number of days
+// before the expiry of the server certificate, are only used by SSL tests.
+func validateSyntheticsCertificateAssertion(assertion interface{}, subtype string) error {
+	assertionMap, ok := assertion.(map[string]interface{})
+	if !ok || assertionMap["type"] != string(datadogV1.SYNTHETICSASSERTIONTYPE_CERTIFICATE) {
+		return nil
+	}
+	if subtype != "" && subtype != string(datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_SSL) {
+		return fmt.Errorf("certificate assertions are only supported by ssl tests, not %s tests", subtype)
+	}
+	switch operator, _ := assertionMap["operator"].(string); datadogV1.SyntheticsAssertionOperator(operator) {
+	case "", datadogV1.SYNTHETICSASSERTIONOPERATOR_IS_IN_MORE_DAYS_THAN, datadogV1.SYNTHETICSASSERTIONOPERATOR_IS_IN_LESS_DAYS_THAN:
+	default:
+		return fmt.Errorf("certificate assertions must use the %s or %s operators, not %s", datadogV1.SYNTHETICSASSERTIONOPERATOR_IS_IN_MORE_DAYS_THAN, datadogV1.SYNTHETICSASSERTIONOPERATOR_IS_IN_LESS_DAYS_THAN, operator)
+	}
+	return nil
+}
+
+// validateSyntheticsSubTargetValue checks the targetvalue of a targetjsonpath or targetxpath block.
+func validateSyntheticsSubTargetValue(targetMap map[string]interface{}) []error {
+	targetValue, _ := targetMap["targetvalue"].(string)
+	if targetValue == "" || validators.SyntheticsTemplateRegexp.MatchString(targetValue) {
+		return nil
+	}
+	switch operator, _ := targetMap["operator"].(string); datadogV1.SyntheticsAssertionOperator(operator) {
+	case datadogV1.SYNTHETICSASSERTIONOPERATOR_MATCHES, datadogV1.SYNTHETICSASSERTIONOPERATOR_DOES_NOT_MATCH:
+		if err := validators.ValidateRegex(targetValue); err != nil {
+			return []error{err}
+		}
+	case datadogV1.SYNTHETICSASSERTIONOPERATOR_LESS_THAN, datadogV1.SYNTHETICSASSERTIONOPERATOR_MORE_THAN:
+		if _, err := strconv.ParseFloat(targetValue, 64); err != nil {
+			return []error{fmt.Errorf("targetvalue %q must be a number for the %s operator", targetValue, operator)}
+		}
+	}
+	return nil
+}
+
+// validateSyntheticsParser checks the value of an extracted_value parser compiles for its type.
+func validateSyntheticsParser(parser interface{}) error {
+	parserMap := firstSyntheticsBlock(parser)
+	if parserMap == nil {
+		return nil
+	}
+	value, _ := parserMap["value"].(string)
+	if value == "" {
+		return nil
+	}
+	switch parserType, _ := parserMap["type"].(string); datadogV1.SyntheticsGlobalVariableParserType(parserType) {
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_REGEX:
+		return validators.ValidateRegex(value)
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_JSON_PATH:
+		return validators.ValidateJSONPath(value)
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_X_PATH:
+		return validators.ValidateXPath(value)
+	}
+	return nil
+}
+
+// firstSyntheticsBlock returns the content of a `MaxItems: 1` block, or nil when it is not set.
+func firstSyntheticsBlock(v interface{}) map[string]interface{} {
+	if blocks, ok := v.([]interface{}); ok && len(blocks) > 0 {
+		if block, ok := blocks[0].(map[string]interface{}); ok {
+			return block
+		}
+	}
+	return nil
+}
+
+// syntheticsVariableReferences lists the `{{ NAME }}` variables referenced in the strings of a configuration value.
+// Built-in variables and functions, such as `{{ uuid }}` or `{{ numeric(4) }}`, aren't uppercase and are ignored.
+func syntheticsVariableReferences(v interface{}) []string {
+	var names []string
+	switch value := v.(type) {
+	case string:
+		for _, match := range validators.SyntheticsTemplateRegexp.FindAllStringSubmatch(value, -1) {
+			if syntheticsVariableNameRegexp.MatchString(match[1]) {
+				names = append(names, match[1])
+			}
+		}
+	case []interface{}:
+		for _, element := range value {
+			names = append(names, syntheticsVariableReferences(element)...)
+		}
+	case map[string]interface{}:
+		for _, element := range value {
+			names = append(names, syntheticsVariableReferences(element)...)
+		}
+	}
+	return names
+}
+
 func isTargetOfTypeInt(assertionType datadogV1.SyntheticsAssertionType, assertionOperator datadogV1.SyntheticsAssertionOperator) bool {
 	for _, intTargetAssertionType := range []datadogV1.SyntheticsAssertionType{
 		datadogV1.SYNTHETICSASSERTIONTYPE_RESPONSE_TIME,
@@ -2272,38 +2657,7 @@ func buildSyntheticsBrowserTestStruct(d *schema.ResourceData) *datadogV1.Synthet
 			step.SetTimeout(int64(stepMap["timeout"].(int)))
 			step.SetNoScreenshot(stepMap["no_screenshot"].(bool))
 
-			params := make(map[string]interface{})
-			stepParams := stepMap["params"].([]interface{})[0]
-			stepTypeParams := getParamsKeysForStepType(step.GetType())
-
-			for _, key := range stepTypeParams {
-				if stepMap, ok := stepParams.(map[string]interface{}); ok && stepMap[key] != "" {
-					convertedValue := convertStepParamsValueForConfig(step.GetType(), key, stepMap[key])
-					params[convertStepParamsKey(key)] = convertedValue
-				}
-			}
-
-			if stepParamsMap, ok := stepParams.(map[string]interface{}); ok && stepParamsMap["element_user_locator"] != "" {
-				userLocatorsParams := stepParamsMap["element_user_locator"].([]interface{})
-
-				if len(userLocatorsParams) != 0 {
-					userLocatorParams := userLocatorsParams[0].(map[string]interface{})
-					values := userLocatorParams["value"].([]interface{})
-					userLocator := map[string]interface{}{
-						"failTestOnCannotLocate": userLocatorParams["fail_test_on_cannot_locate"],
-						"values":                 []map[string]interface{}{values[0].(map[string]interface{})},
-					}
-
-					stepElement := make(map[string]interface{})
-					if stepParamsElement, ok := stepParamsMap["element"]; ok {
-						utils.GetMetadataFromJSON([]byte(stepParamsElement.(string)), &stepElement)
-					}
-					stepElement["userLocator"] = userLocator
-					params["element"] = stepElement
-				}
-			}
-
-			step.SetParams(params)
+			step.SetParams(buildSyntheticsBrowserStepParams(step.GetType(), stepMap["params"].([]interface{})[0]))
 
 			steps = append(steps, step)
 		}
@@ -2920,35 +3274,7 @@ func updateSyntheticsBrowserTestLocalState(d *schema.ResourceData, syntheticsTes
 			localStep["no_screenshot"] = hasNoScreenshot
 		}
 
-		localParams := make(map[string]interface{})
-		params := step.GetParams()
-		paramsMap := params.(map[string]interface{})
-
-		for key, value := range paramsMap {
-			localParams[convertStepParamsKey(key)] = convertStepParamsValueForState(convertStepParamsKey(key), value)
-		}
-
-		if elementParams, ok := localParams["element"]; ok {
-			var stepElement interface{}
-			utils.GetMetadataFromJSON([]byte(elementParams.(string)), &stepElement)
-
-			if elementUserLocator, ok := stepElement.(map[string]interface{})["userLocator"]; ok {
-				userLocator := elementUserLocator.(map[string]interface{})
-				values := userLocator["values"]
-				value := values.([]interface{})[0]
-
-				localElementUserLocator := map[string]interface{}{
-					"fail_test_on_cannot_locate": userLocator["failTestOnCannotLocate"],
-					"value": []map[string]interface{}{
-						value.(map[string]interface{}),
-					},
-				}
-
-				localParams["element_user_locator"] = []map[string]interface{}{localElementUserLocator}
-			}
-		}
-
-		localStep["params"] = []interface{}{localParams}
+		localStep["params"] = []interface{}{buildLocalBrowserStepParams(step.GetParams())}
 
 		if forceElementUpdate, ok := d.GetOk(fmt.Sprintf("browser_step.%d.force_element_update", stepIndex)); ok {
 			localStep["force_element_update"] = forceElementUpdate
@@ -3253,6 +3579,117 @@ func validateSyntheticsAssertionOperator(val interface{}, key string) (warns []s
 	return
 }
 
+// Default number of days before the expiry of a client certificate from which refreshing a test warns about it
+const syntheticsClientCertificateExpiryWarningDays = 30
+
+// syntheticsClientCertificate holds the contents of a request_client_certificate block of the configuration
+type syntheticsClientCertificate struct {
+	path string
+	cert string
+	key  string
+}
+
+// syntheticsRawClientCertificates returns the client certificates of the configuration of a test.
+// known is false when the content of one of them isn't known yet.
+func syntheticsRawClientCertificates(rawConfig cty.Value) (certificates []syntheticsClientCertificate, known bool) {
+	blocks := map[string]cty.Value{"request_client_certificate": rawConfigFirstBlock(rawConfig, "request_client_certificate")}
+	paths := []string{"request_client_certificate"}
+	if steps := rawConfigAttr(rawConfig, "api_step"); steps.IsKnown() && !steps.IsNull() {
+		for i, step := range steps.AsValueSlice() {
+			path := fmt.Sprintf("api_step.%d.request_client_certificate", i)
+			blocks[path] = rawConfigFirstBlock(step, "request_client_certificate")
+			paths = append(paths, path)
+		}
+	}
+
+	for _, path := range paths {
+		block := blocks[path]
+		if block.IsNull() {
+			continue
+		}
+		cert := rawConfigAttr(rawConfigFirstBlock(block, "cert"), "content")
+		key := rawConfigAttr(rawConfigFirstBlock(block, "key"), "content")
+		if !cert.IsKnown() || !key.IsKnown() {
+			return nil, false
+		}
+		if cert.IsNull() || key.IsNull() {
+			continue
+		}
+		certificates = append(certificates, syntheticsClientCertificate{path: path, cert: cert.AsString(), key: key.AsString()})
+	}
+	return certificates, true
+}
+
+// rawConfigAttr returns an attribute of a configuration object, or null when the object is null
+func rawConfigAttr(object cty.Value, name string) cty.Value {
+	if !object.IsKnown() {
+		return cty.DynamicVal
+	}
+	if object.IsNull() || !object.Type().IsObjectType() || !object.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return object.GetAttr(name)
+}
+
+// rawConfigFirstBlock returns the first nested block of a configuration object, or null when there is none
+func rawConfigFirstBlock(object cty.Value, name string) cty.Value {
+	blocks := rawConfigAttr(object, name)
+	if !blocks.IsKnown() {
+		return cty.DynamicVal
+	}
+	if blocks.IsNull() || blocks.LengthInt() == 0 {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return blocks.AsValueSlice()[0]
+}
+
+// updateSyntheticsClientCertificateLocalState keeps the details of the client certificates computed at plan time,
+// as they can't be read from the API, and warns about the certificates expiring soon
+func updateSyntheticsClientCertificateLocalState(d *schema.ResourceData) diag.Diagnostics {
+	if err := d.Set("client_certificate_info", d.Get("client_certificate_info")); err != nil {
+		return diag.FromErr(err)
+	}
+	return syntheticsClientCertificateExpiryWarnings(d.Id(), d.Get("client_certificate_info").([]interface{}), d.Get("client_certificate_expiry_warning_days").(int))
+}
+
+// syntheticsClientCertificateExpiryWarnings returns a warning for each client certificate expiring within warningDays,
+// or within the default number of days when it is 0
+func syntheticsClientCertificateExpiryWarnings(id string, certificatesInfo []interface{}, warningDays int) diag.Diagnostics {
+	if warningDays <= 0 {
+		warningDays = syntheticsClientCertificateExpiryWarningDays
+	}
+
+	var diags diag.Diagnostics
+	for _, info := range certificatesInfo {
+		infoMap, ok := info.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		notAfter, err := time.Parse(time.RFC3339, infoMap["not_after"].(string))
+		if err != nil {
+			continue
+		}
+		remaining := time.Until(notAfter)
+		if remaining > time.Duration(warningDays)*24*time.Hour {
+			continue
+		}
+		summary := fmt.Sprintf("client certificate of %s expires in %d days", infoMap["path"], int(remaining.Hours()/24))
+		if remaining <= 0 {
+			summary = fmt.Sprintf("client certificate of %s has expired", infoMap["path"])
+		}
+		test := "the synthetics test"
+		if id != "" {
+			test = "synthetics test " + id
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("The certificate %s is valid until %s, the requests of %s will fail after that.", infoMap["subject"], infoMap["not_after"], test),
+		})
+	}
+	return diags
+}
+
 func isCertHash(content string) bool {
 	// a sha256 hash consists of 64 hexadecimal characters
 	isHash, _ := regexp.MatchString("^[A-Fa-f0-9]{64}$", content)
@@ -3350,6 +3787,73 @@ func getParamsKeysForStepType(stepType datadogV1.SyntheticsStepType) []string {
 	return []string{}
 }
 
+// buildSyntheticsBrowserStepParams converts the `params` block of a browser step into the params sent to the API.
+func buildSyntheticsBrowserStepParams(stepType datadogV1.SyntheticsStepType, stepParams interface{}) map[string]interface{} {
+	params := make(map[string]interface{})
+	stepTypeParams := getParamsKeysForStepType(stepType)
+
+	for _, key := range stepTypeParams {
+		if stepMap, ok := stepParams.(map[string]interface{}); ok && stepMap[key] != "" {
+			convertedValue := convertStepParamsValueForConfig(stepType, key, stepMap[key])
+			params[convertStepParamsKey(key)] = convertedValue
+		}
+	}
+
+	if stepParamsMap, ok := stepParams.(map[string]interface{}); ok && stepParamsMap["element_user_locator"] != "" {
+		userLocatorsParams := stepParamsMap["element_user_locator"].([]interface{})
+
+		if len(userLocatorsParams) != 0 {
+			userLocatorParams := userLocatorsParams[0].(map[string]interface{})
+			values := userLocatorParams["value"].([]interface{})
+			userLocator := map[string]interface{}{
+				"failTestOnCannotLocate": userLocatorParams["fail_test_on_cannot_locate"],
+				"values":                 []map[string]interface{}{values[0].(map[string]interface{})},
+			}
+
+			stepElement := make(map[string]interface{})
+			if stepParamsElement, ok := stepParamsMap["element"]; ok {
+				utils.GetMetadataFromJSON([]byte(stepParamsElement.(string)), &stepElement)
+			}
+			stepElement["userLocator"] = userLocator
+			params["element"] = stepElement
+		}
+	}
+
+	return params
+}
+
+// buildLocalBrowserStepParams converts the params of a browser step returned by the API into a `params` block.
+func buildLocalBrowserStepParams(params interface{}) map[string]interface{} {
+	localParams := make(map[string]interface{})
+	paramsMap := params.(map[string]interface{})
+
+	for key, value := range paramsMap {
+		localParams[convertStepParamsKey(key)] = convertStepParamsValueForState(convertStepParamsKey(key), value)
+	}
+
+	if elementParams, ok := localParams["element"]; ok {
+		var stepElement interface{}
+		utils.GetMetadataFromJSON([]byte(elementParams.(string)), &stepElement)
+
+		if elementUserLocator, ok := stepElement.(map[string]interface{})["userLocator"]; ok {
+			userLocator := elementUserLocator.(map[string]interface{})
+			values := userLocator["values"]
+			value := values.([]interface{})[0]
+
+			localElementUserLocator := map[string]interface{}{
+				"fail_test_on_cannot_locate": userLocator["failTestOnCannotLocate"],
+				"value": []map[string]interface{}{
+					value.(map[string]interface{}),
+				},
+			}
+
+			localParams["element_user_locator"] = []map[string]interface{}{localElementUserLocator}
+		}
+	}
+
+	return localParams
+}
+
 func convertStepParamsValueForConfig(stepType datadogV1.SyntheticsStepType, key string, value interface{}) interface{} {
 	switch key {
 	case "element", "email", "file", "files", "request":
@@ -0,0 +1,125 @@
+package datadog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestUpdateSyntheticsClientCertificateLocalState(t *testing.T) {
+	cases := []struct {
+		name        string
+		notAfter    time.Duration
+		warningDays int
+		warning     string
+	}{
+		{
+			name:     "valid for longer than the default",
+			notAfter: 60 * 24 * time.Hour,
+		},
+		{
+			name:     "expiring within the default",
+			notAfter: 10*24*time.Hour + time.Hour,
+			warning:  "client certificate of request_client_certificate.0 expires in 10 days",
+		},
+		{
+			name:        "expiring within the configured days",
+			notAfter:    60*24*time.Hour + time.Hour,
+			warningDays: 90,
+			warning:     "client certificate of request_client_certificate.0 expires in 60 days",
+		},
+		{
+			name:     "expired",
+			notAfter: -time.Hour,
+			warning:  "client certificate of request_client_certificate.0 has expired",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"client_certificate_info": []interface{}{map[string]interface{}{
+					"path":      "request_client_certificate.0",
+					"subject":   "CN=payments",
+					"issuer":    "CN=payments",
+					"not_after": time.Now().Add(tc.notAfter).UTC().Format(time.RFC3339),
+				}},
+			}
+			if tc.warningDays > 0 {
+				raw["client_certificate_expiry_warning_days"] = tc.warningDays
+			}
+			d := schema.TestResourceDataRaw(t, resourceDatadogSyntheticsTest().SchemaMap(), raw)
+
+			diags := updateSyntheticsClientCertificateLocalState(d)
+			if tc.warning == "" {
+				if len(diags) > 0 {
+					t.Errorf("expected no warning, got %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Severity != diag.Warning || diags[0].Summary != tc.warning {
+				t.Errorf("expected warning %q, got %v", tc.warning, diags)
+			}
+		})
+	}
+}
+
+func TestValidateSyntheticsCertificateAssertion(t *testing.T) {
+	certificate := map[string]interface{}{"type": "certificate", "operator": "isInMoreThan", "target": "10"}
+	cases := []struct {
+		name      string
+		assertion map[string]interface{}
+		subtype   string
+		err       string
+	}{
+		{name: "ssl test", assertion: certificate, subtype: "ssl"},
+		{name: "http step", assertion: certificate, subtype: "http", err: "certificate assertions are only supported by ssl tests, not http tests"},
+		{name: "grpc test", assertion: certificate, subtype: "grpc", err: "certificate assertions are only supported by ssl tests, not grpc tests"},
+		{name: "other assertion", assertion: map[string]interface{}{"type": "statusCode", "operator": "is", "target": "200"}, subtype: "http"},
+		{
+			name:      "unsupported operator",
+			assertion: map[string]interface{}{"type": "certificate", "operator": "is", "target": "10"},
+			subtype:   "ssl",
+			err:       "certificate assertions must use the isInMoreThan or isInLessThan operators, not is",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSyntheticsCertificateAssertion(tc.assertion, tc.subtype)
+			if (err == nil) != (tc.err == "") || (err != nil && !strings.Contains(err.Error(), tc.err)) {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestSyntheticsClientCertificateExpiryWarnings(t *testing.T) {
+	info := []interface{}{
+		map[string]interface{}{
+			"path":      "request_client_certificate.0",
+			"subject":   "CN=payments",
+			"not_after": time.Now().Add(20*24*time.Hour + time.Hour).UTC().Format(time.RFC3339),
+		},
+		map[string]interface{}{
+			"path":      "api_step.1.request_client_certificate",
+			"subject":   "CN=orders",
+			"not_after": time.Now().Add(45*24*time.Hour + time.Hour).UTC().Format(time.RFC3339),
+		},
+	}
+
+	// Certificates are checked at plan time, before the test has an ID
+	diags := syntheticsClientCertificateExpiryWarnings("", info, 0)
+	if len(diags) != 1 || diags[0].Summary != "client certificate of request_client_certificate.0 expires in 20 days" {
+		t.Fatalf("expected a warning for the first certificate, got %v", diags)
+	}
+	if !strings.HasPrefix(diags[0].Detail, "The certificate CN=payments is valid until ") || !strings.HasSuffix(diags[0].Detail, "the requests of the synthetics test will fail after that.") {
+		t.Errorf("unexpected detail %q", diags[0].Detail)
+	}
+
+	diags = syntheticsClientCertificateExpiryWarnings("abc-def-ghi", info, 60)
+	if len(diags) != 2 || diags[1].Summary != "client certificate of api_step.1.request_client_certificate expires in 45 days" || !strings.Contains(diags[1].Detail, "synthetics test abc-def-ghi") {
+		t.Errorf("expected a warning for both certificates, got %v", diags)
+	}
+}
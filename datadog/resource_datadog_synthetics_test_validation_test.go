@@ -0,0 +1,192 @@
+package datadog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateSyntheticsAssertion(t *testing.T) {
+	cases := []struct {
+		name      string
+		assertion map[string]interface{}
+		err       string
+	}{
+		{
+			name: "invalid JSON path",
+			assertion: map[string]interface{}{
+				"type":           "body",
+				"operator":       "validatesJSONPath",
+				"targetjsonpath": []interface{}{map[string]interface{}{"operator": "is", "jsonpath": "$.items[", "targetvalue": "1"}},
+			},
+			err: `invalid JSON path "$.items["`,
+		},
+		{
+			name: "invalid XPath",
+			assertion: map[string]interface{}{
+				"type":        "body",
+				"operator":    "validatesXPath",
+				"targetxpath": []interface{}{map[string]interface{}{"operator": "contains", "xpath": "//item[", "targetvalue": "1"}},
+			},
+			err: `invalid XPath "//item["`,
+		},
+		{
+			name:      "invalid regular expression",
+			assertion: map[string]interface{}{"type": "body", "operator": "matches", "target": "foo("},
+			err:       `invalid regular expression "foo("`,
+		},
+		{
+			name: "invalid regular expression target value",
+			assertion: map[string]interface{}{
+				"type":           "body",
+				"operator":       "validatesJSONPath",
+				"targetjsonpath": []interface{}{map[string]interface{}{"operator": "doesNotMatch", "jsonpath": "$.name", "targetvalue": "[a-"}},
+			},
+			err: `invalid regular expression "[a-"`,
+		},
+		{
+			name: "non numeric target value",
+			assertion: map[string]interface{}{
+				"type":           "body",
+				"operator":       "validatesJSONPath",
+				"targetjsonpath": []interface{}{map[string]interface{}{"operator": "lessThan", "jsonpath": "$.count", "targetvalue": "ten"}},
+			},
+			err: `targetvalue "ten" must be a number`,
+		},
+		{
+			name:      "non integer status code",
+			assertion: map[string]interface{}{"type": "statusCode", "operator": "is", "target": "OK"},
+			err:       `target "OK" must be an integer for statusCode assertions`,
+		},
+		{
+			name:      "non integer response time",
+			assertion: map[string]interface{}{"type": "responseTime", "operator": "lessThan", "target": "1s"},
+			err:       `target "1s" must be an integer for responseTime assertions`,
+		},
+		{
+			name:      "non numeric packet loss",
+			assertion: map[string]interface{}{"type": "packetLossPercentage", "operator": "lessThan", "target": "low"},
+			err:       `target "low" must be a number for packetLossPercentage assertions`,
+		},
+		{
+			name:      "status code",
+			assertion: map[string]interface{}{"type": "statusCode", "operator": "is", "target": "200"},
+		},
+		{
+			name:      "variable target",
+			assertion: map[string]interface{}{"type": "responseTime", "operator": "lessThan", "target": "{{ MAX_TIME }}"},
+		},
+		{
+			name:      "unknown target",
+			assertion: map[string]interface{}{"type": "statusCode", "operator": "is", "target": ""},
+		},
+		{
+			name:      "regular expression with lookarounds",
+			assertion: map[string]interface{}{"type": "body", "operator": "matches", "target": `^(?!error)\w+(?<=ok)$`},
+		},
+		{
+			name: "JSON path filter",
+			assertion: map[string]interface{}{
+				"type":           "body",
+				"operator":       "validatesJSONPath",
+				"targetjsonpath": []interface{}{map[string]interface{}{"operator": "moreThan", "jsonpath": "$.items[?(@.price < 10)].count", "targetvalue": "2.5"}},
+			},
+		},
+		{
+			name: "XPath predicate",
+			assertion: map[string]interface{}{
+				"type":        "body",
+				"operator":    "validatesXPath",
+				"targetxpath": []interface{}{map[string]interface{}{"operator": "contains", "xpath": "//item[@id='1']/text()", "targetvalue": "foo"}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := errors.Join(validateSyntheticsAssertion(tc.assertion)...)
+			if (err == nil) != (tc.err == "") || (err != nil && !strings.Contains(err.Error(), tc.err)) {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateSyntheticsAPISteps(t *testing.T) {
+	configVariables := []interface{}{map[string]interface{}{"name": "USER", "type": "text"}}
+	steps := func(tokenParser, sessionParser, sessionHeader string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"name":               "login",
+				"request_definition": []interface{}{map[string]interface{}{"url": "https://api.example.com/login?user={{ USER }}&nonce={{ uuid }}"}},
+				"assertion":          []interface{}{},
+				"extracted_value": []interface{}{map[string]interface{}{
+					"name":   "TOKEN",
+					"type":   "http_body",
+					"parser": []interface{}{map[string]interface{}{"type": "json_path", "value": tokenParser}},
+				}},
+			},
+			map[string]interface{}{
+				"name":               "me",
+				"request_definition": []interface{}{map[string]interface{}{"url": "https://api.example.com/me?ts={{ timestamp(0, s) }}"}},
+				"request_headers":    map[string]interface{}{"Authorization": "Bearer {{ TOKEN }}", "X-Session": sessionHeader},
+				"assertion":          []interface{}{map[string]interface{}{"type": "statusCode", "operator": "is", "target": "OK"}},
+				"extracted_value": []interface{}{map[string]interface{}{
+					"name":   "SESSION",
+					"type":   "http_header",
+					"field":  "x-session",
+					"parser": []interface{}{map[string]interface{}{"type": "regex", "value": sessionParser}},
+				}},
+			},
+		}
+	}
+	cases := []struct {
+		name            string
+		steps           []interface{}
+		checkReferences bool
+		errs            []string
+	}{
+		{
+			name:            "valid steps",
+			steps:           steps("$.token", "session-(.*)", "{{ USER }}"),
+			checkReferences: true,
+			errs:            []string{`api_step.1 (me) assertion.0: target "OK" must be an integer`},
+		},
+		{
+			name:            "variable extracted by a later step",
+			steps:           steps("$.token", "session-(.*)", "{{ SESSION }}"),
+			checkReferences: true,
+			errs: []string{
+				`api_step.1 (me) assertion.0: target "OK" must be an integer`,
+				"api_step.1 (me): variable SESSION is neither a config_variable nor extracted by a previous step",
+			},
+		},
+		{
+			name:  "references not checked",
+			steps: steps("$.token", "session-(.*)", "{{ SESSION }}"),
+			errs:  []string{`api_step.1 (me) assertion.0: target "OK" must be an integer`},
+		},
+		{
+			name:            "invalid parsers",
+			steps:           steps("$.token[", "session-(", "{{ USER }}"),
+			checkReferences: true,
+			errs: []string{
+				`api_step.0 (login) extracted_value.0: invalid JSON path "$.token["`,
+				`api_step.1 (me) assertion.0: target "OK" must be an integer`,
+				`api_step.1 (me) extracted_value.0: invalid regular expression "session-("`,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateSyntheticsAPISteps(configVariables, tc.steps, tc.checkReferences)
+			if len(errs) != len(tc.errs) {
+				t.Fatalf("expected %d errors, got %v", len(tc.errs), errs)
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), tc.errs[i]) {
+					t.Errorf("expected error containing %q, got %v", tc.errs[i], err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// Meta-arguments accepted, and ignored, in the resource block
+var syntheticsLocalMetaArguments = []string{"count", "depends_on", "for_each", "provider"}
+
+// LoadSyntheticsAPITest reads a `datadog_synthetics_test` resource from Terraform configuration files
+// and returns the API test the provider would send for it. name is the resource name, it can be left
+// empty when the files only hold one test. Input variables are set from their default value or from
+// inputVariables; references to other objects, such as locals or resources, are not supported.
+func LoadSyntheticsAPITest(filenames []string, name string, inputVariables map[string]string) (*datadogV1.SyntheticsAPITest, error) {
+	parser := hclparse.NewParser()
+	var diags hcl.Diagnostics
+	var resources []*hcl.Block
+	variables := make(map[string]cty.Value)
+	for _, filename := range filenames {
+		var file *hcl.File
+		var fileDiags hcl.Diagnostics
+		if strings.HasSuffix(filename, ".json") {
+			file, fileDiags = parser.ParseJSONFile(filename)
+		} else {
+			file, fileDiags = parser.ParseHCLFile(filename)
+		}
+		diags = append(diags, fileDiags...)
+		if fileDiags.HasErrors() {
+			continue
+		}
+		content, _, contentDiags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{Type: "resource", LabelNames: []string{"type", "name"}},
+				{Type: "variable", LabelNames: []string{"name"}},
+			},
+		})
+		diags = append(diags, contentDiags...)
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "resource":
+				if block.Labels[0] == "datadog_synthetics_test" && (name == "" || name == block.Labels[1]) {
+					resources = append(resources, block)
+				}
+			case "variable":
+				variableContent, _, variableDiags := block.Body.PartialContent(&hcl.BodySchema{
+					Attributes: []hcl.AttributeSchema{{Name: "default"}},
+				})
+				diags = append(diags, variableDiags...)
+				if attr, ok := variableContent.Attributes["default"]; ok {
+					value, valueDiags := attr.Expr.Value(nil)
+					diags = append(diags, valueDiags...)
+					variables[block.Labels[0]] = value
+				}
+			}
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	for k, v := range inputVariables {
+		variables[k] = cty.StringVal(v)
+	}
+
+	switch len(resources) {
+	case 0:
+		if name != "" {
+			return nil, fmt.Errorf("datadog_synthetics_test.%s not found", name)
+		}
+		return nil, fmt.Errorf("no datadog_synthetics_test resource found")
+	case 1:
+	default:
+		return nil, fmt.Errorf("several datadog_synthetics_test resources found, one must be selected by name")
+	}
+	block := resources[0]
+
+	evalCtx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(variables)},
+		Functions: syntheticsLocalFunctions(filepath.Dir(block.DefRange.Filename)),
+	}
+	res := Provider().ResourcesMap["datadog_synthetics_test"]
+	raw, diags := decodeSyntheticsLocalBody(block.Body, res.SchemaMap(), evalCtx, true)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	for _, validateDiag := range res.Validate(terraform.NewResourceConfigRaw(raw)) {
+		if validateDiag.Severity == diag.Error {
+			return nil, fmt.Errorf("invalid datadog_synthetics_test.%s: %s %s", block.Labels[1], validateDiag.Summary, validateDiag.Detail)
+		}
+	}
+	sm := schema.InternalMap(res.SchemaMap())
+	diff, err := sm.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), res.CustomizeDiff, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	d, err := sm.Data(nil, diff)
+	if err != nil {
+		return nil, err
+	}
+	if d.Get("type").(string) != string(datadogV1.SYNTHETICSTESTDETAILSTYPE_API) {
+		return nil, fmt.Errorf("datadog_synthetics_test.%s is not an API test, only API tests can be run locally", block.Labels[1])
+	}
+	return buildSyntheticsAPITestStruct(d), nil
+}
+
+// decodeSyntheticsLocalBody converts an HCL body into the raw configuration map of the given schema.
+func decodeSyntheticsLocalBody(body hcl.Body, s map[string]*schema.Schema, evalCtx *hcl.EvalContext, topLevel bool) (map[string]interface{}, hcl.Diagnostics) {
+	bodySchema := &hcl.BodySchema{}
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, ok := s[k].Elem.(*schema.Resource); ok {
+			bodySchema.Blocks = append(bodySchema.Blocks, hcl.BlockHeaderSchema{Type: k})
+		} else {
+			bodySchema.Attributes = append(bodySchema.Attributes, hcl.AttributeSchema{Name: k})
+		}
+	}
+	if topLevel {
+		for _, k := range syntheticsLocalMetaArguments {
+			bodySchema.Attributes = append(bodySchema.Attributes, hcl.AttributeSchema{Name: k})
+		}
+		bodySchema.Blocks = append(bodySchema.Blocks, hcl.BlockHeaderSchema{Type: "lifecycle"})
+	}
+
+	content, diags := body.Content(bodySchema)
+	raw := make(map[string]interface{})
+	for k, attr := range content.Attributes {
+		if _, ok := s[k]; !ok {
+			continue
+		}
+		value, valueDiags := attr.Expr.Value(evalCtx)
+		diags = append(diags, valueDiags...)
+		if !valueDiags.HasErrors() && !value.IsNull() {
+			raw[k] = syntheticsLocalConfigValue(value)
+		}
+	}
+	for _, block := range content.Blocks {
+		attr, ok := s[block.Type]
+		if !ok {
+			continue
+		}
+		nested, nestedDiags := decodeSyntheticsLocalBody(block.Body, attr.Elem.(*schema.Resource).Schema, evalCtx, false)
+		diags = append(diags, nestedDiags...)
+		blocks, _ := raw[block.Type].([]interface{})
+		raw[block.Type] = append(blocks, nested)
+	}
+	return raw, diags
+}
+
+// syntheticsLocalConfigValue converts an HCL value into the representation used by raw configurations.
+func syntheticsLocalConfigValue(v cty.Value) interface{} {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+	t := v.Type()
+	switch {
+	case t == cty.String:
+		return v.AsString()
+	case t == cty.Bool:
+		return v.True()
+	case t == cty.Number:
+		bf := v.AsBigFloat()
+		if bf.IsInt() {
+			i, _ := bf.Int64()
+			return int(i)
+		}
+		f, _ := bf.Float64()
+		return f
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		values := make([]interface{}, 0, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			_, element := it.Element()
+			values = append(values, syntheticsLocalConfigValue(element))
+		}
+		return values
+	case t.IsMapType() || t.IsObjectType():
+		values := make(map[string]interface{}, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			values[key.AsString()] = syntheticsLocalConfigValue(element)
+		}
+		return values
+	}
+	return nil
+}
+
+// syntheticsLocalFunctions returns the subset of Terraform functions commonly used in test definitions.
+func syntheticsLocalFunctions(baseDir string) map[string]function.Function {
+	return map[string]function.Function{
+		"file": function.New(&function.Spec{
+			Params: []function.Parameter{{Name: "path", Type: cty.String}},
+			Type:   function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+				path := args[0].AsString()
+				if !filepath.IsAbs(path) {
+					path = filepath.Join(baseDir, path)
+				}
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return cty.NilVal, err
+				}
+				return cty.StringVal(string(content)), nil
+			},
+		}),
+		"format":     stdlib.FormatFunc,
+		"join":       stdlib.JoinFunc,
+		"jsondecode": stdlib.JSONDecodeFunc,
+		"jsonencode": stdlib.JSONEncodeFunc,
+		"lower":      stdlib.LowerFunc,
+		"replace":    stdlib.ReplaceFunc,
+		"trimspace":  stdlib.TrimSpaceFunc,
+		"upper":      stdlib.UpperFunc,
+	}
+}
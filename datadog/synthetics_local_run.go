@@ -0,0 +1,892 @@
+package datadog
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptrace"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+	"github.com/hashicorp/go-uuid"
+	"github.com/ohler55/ojg/jp"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+)
+
+// SyntheticsLocalRunOptions configures RunSyntheticsAPITest.
+type SyntheticsLocalRunOptions struct {
+	// Target, when set, replaces the scheme and host of HTTP requests, the host and port of TCP and
+	// SSL tests and the DNS server of DNS tests, for instance to run a test against a local service.
+	Target string
+	// Variables gives a value to global config variables, which can't be read locally, and overrides
+	// the value of text config variables.
+	Variables map[string]string
+}
+
+// SyntheticsLocalRunResult is the outcome of a local run of an API test.
+type SyntheticsLocalRunResult struct {
+	Passed bool
+	// Steps holds a single step named after the test, except for multistep tests
+	Steps []SyntheticsLocalStepResult
+}
+
+// SyntheticsLocalStepResult is the outcome of a single request of an API test.
+type SyntheticsLocalStepResult struct {
+	Name     string
+	Passed   bool
+	Attempts int
+	Duration time.Duration
+	// Error is set when the request could not be performed or a value could not be extracted
+	Error           string
+	Assertions      []SyntheticsLocalAssertionResult
+	ExtractedValues []SyntheticsLocalExtractedValue
+}
+
+// SyntheticsLocalAssertionResult is the outcome of a single assertion. Assertions which can't be
+// evaluated outside of a managed location are skipped.
+type SyntheticsLocalAssertionResult struct {
+	Description string
+	Passed      bool
+	Skipped     bool
+	Actual      string
+	Message     string
+}
+
+// SyntheticsLocalExtractedValue is a variable extracted from the response of a multistep test step.
+type SyntheticsLocalExtractedValue struct {
+	Name   string
+	Value  string
+	Secure bool
+}
+
+type syntheticsLocalVariable struct {
+	value  string
+	secure bool
+}
+
+type syntheticsLocalRunner struct {
+	target    string
+	variables map[string]syntheticsLocalVariable
+}
+
+// syntheticsLocalResponse holds what the assertions of a request are evaluated against.
+type syntheticsLocalResponse struct {
+	duration time.Duration
+	// dnsDuration is subtracted from the duration for the `withoutDNS` timings scope
+	dnsDuration time.Duration
+	// HTTP
+	statusCode int
+	header     http.Header
+	body       []byte
+	// TCP
+	connection string
+	// SSL
+	tlsVersion    uint16
+	certificate   *time.Time
+	minTLSVersion func() (uint16, error)
+	// DNS
+	records map[string][]string
+}
+
+// RunSyntheticsAPITest runs an API test, as built from a `datadog_synthetics_test` resource, from the
+// local machine. HTTP, multistep, TCP, DNS and SSL tests are supported. Config variables and values
+// extracted by multistep steps are substituted with the `{{ NAME }}` syntax, like in managed locations.
+func RunSyntheticsAPITest(ctx context.Context, test *datadogV1.SyntheticsAPITest, opts SyntheticsLocalRunOptions) (*SyntheticsLocalRunResult, error) {
+	r := &syntheticsLocalRunner{
+		target:    opts.Target,
+		variables: make(map[string]syntheticsLocalVariable),
+	}
+	config := test.GetConfig()
+	for _, variable := range config.GetConfigVariables() {
+		if v, ok := opts.Variables[variable.GetName()]; ok {
+			r.variables[variable.GetName()] = syntheticsLocalVariable{value: v, secure: variable.GetSecure()}
+			continue
+		}
+		if variable.GetType() == datadogV1.SYNTHETICSCONFIGVARIABLETYPE_GLOBAL {
+			return nil, fmt.Errorf("global variable %s (%s) can't be read locally, a value must be given for it", variable.GetName(), variable.GetId())
+		}
+		value, err := r.expand(variable.GetPattern())
+		if err != nil {
+			return nil, fmt.Errorf("error computing variable %s: %s", variable.GetName(), err)
+		}
+		r.variables[variable.GetName()] = syntheticsLocalVariable{value: value, secure: variable.GetSecure()}
+	}
+	for name, v := range opts.Variables {
+		if _, ok := r.variables[name]; !ok {
+			r.variables[name] = syntheticsLocalVariable{value: v}
+		}
+	}
+
+	options := test.GetOptions()
+	result := &SyntheticsLocalRunResult{Passed: true}
+	switch subtype := test.GetSubtype(); subtype {
+	case datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_MULTI:
+		for _, step := range config.GetSteps() {
+			request := step.GetRequest()
+			stepResult := r.runStep(ctx, step.GetName(), step.GetRetry(), func() (*syntheticsLocalResponse, error) {
+				return r.doHTTPRequest(ctx, request, request.GetFollowRedirects(), request.GetAllowInsecure())
+			}, step.GetAssertions(), step.GetExtractedValues())
+			result.Steps = append(result.Steps, stepResult)
+			if stepResult.Passed {
+				continue
+			}
+			if !step.GetAllowFailure() || step.GetIsCritical() {
+				result.Passed = false
+			}
+			if !step.GetAllowFailure() {
+				break
+			}
+		}
+	case datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_HTTP,
+		datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_TCP,
+		datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_DNS,
+		datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_SSL:
+		request := config.GetRequest()
+		stepResult := r.runStep(ctx, test.GetName(), options.GetRetry(), func() (*syntheticsLocalResponse, error) {
+			switch subtype {
+			case datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_TCP:
+				return r.doTCPRequest(ctx, request)
+			case datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_DNS:
+				return r.doDNSRequest(ctx, request)
+			case datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_SSL:
+				return r.doSSLRequest(ctx, request, options.GetAcceptSelfSigned())
+			}
+			return r.doHTTPRequest(ctx, request, options.GetFollowRedirects(), options.GetAllowInsecure())
+		}, config.GetAssertions(), nil)
+		result.Steps = append(result.Steps, stepResult)
+		result.Passed = stepResult.Passed
+	default:
+		return nil, fmt.Errorf("%s tests can't be run locally, only http, multi, tcp, dns and ssl tests are supported", subtype)
+	}
+	return result, nil
+}
+
+// runStep performs a request, retrying it as configured, and evaluates its assertions. Values are
+// only extracted from the response of a successful attempt.
+func (r *syntheticsLocalRunner) runStep(ctx context.Context, name string, retry datadogV1.SyntheticsTestOptionsRetry, do func() (*syntheticsLocalResponse, error), assertions []datadogV1.SyntheticsAssertion, extractedValues []datadogV1.SyntheticsParsingOptions) SyntheticsLocalStepResult {
+	var result SyntheticsLocalStepResult
+	var resp *syntheticsLocalResponse
+	for attempt := 0; attempt <= int(retry.GetCount()); attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return result
+			case <-time.After(time.Duration(retry.GetInterval()) * time.Millisecond):
+			}
+		}
+		result = SyntheticsLocalStepResult{Name: name, Passed: true, Attempts: attempt + 1}
+		var err error
+		resp, err = do()
+		if err != nil {
+			result.Passed = false
+			result.Error = err.Error()
+			continue
+		}
+		result.Duration = resp.duration
+		for _, assertion := range assertions {
+			assertionResult := r.evaluateAssertion(resp, assertion)
+			result.Assertions = append(result.Assertions, assertionResult)
+			if !assertionResult.Passed && !assertionResult.Skipped {
+				result.Passed = false
+			}
+		}
+		if result.Passed {
+			break
+		}
+	}
+	if !result.Passed {
+		return result
+	}
+
+	for _, extractedValue := range extractedValues {
+		value, err := r.extractValue(resp, extractedValue)
+		if err != nil {
+			result.Passed = false
+			result.Error = fmt.Sprintf("error extracting %s: %s", extractedValue.GetName(), err)
+			return result
+		}
+		r.variables[extractedValue.GetName()] = syntheticsLocalVariable{value: value, secure: extractedValue.GetSecure()}
+		result.ExtractedValues = append(result.ExtractedValues, SyntheticsLocalExtractedValue{
+			Name:   extractedValue.GetName(),
+			Value:  value,
+			Secure: extractedValue.GetSecure(),
+		})
+	}
+	return result
+}
+
+// expand substitutes the `{{ NAME }}` references to variables and the `{{ numeric(4) }}` like
+// local variable functions.
+func (r *syntheticsLocalRunner) expand(s string) (string, error) {
+	var err error
+	expanded := validators.SyntheticsTemplateRegexp.ReplaceAllStringFunc(s, func(match string) string {
+		expression := validators.SyntheticsTemplateRegexp.FindStringSubmatch(match)[1]
+		if v, ok := r.variables[expression]; ok {
+			return v.value
+		}
+		value, evalErr := evaluateSyntheticsLocalFunction(expression)
+		if evalErr != nil && err == nil {
+			err = evalErr
+		}
+		return value
+	})
+	return expanded, err
+}
+
+var syntheticsFunctionRegexp = regexp.MustCompile(`^(\w+)\((.*)\)$`)
+
+func evaluateSyntheticsLocalFunction(expression string) (string, error) {
+	if expression == "uuid" {
+		return uuid.GenerateUUID()
+	}
+	match := syntheticsFunctionRegexp.FindStringSubmatch(expression)
+	if match == nil {
+		return "", fmt.Errorf("undefined variable %s", expression)
+	}
+	var args []string
+	for _, arg := range strings.Split(match[2], ",") {
+		args = append(args, strings.TrimSpace(arg))
+	}
+	switch match[1] {
+	case "numeric", "alphabetic", "alphanumeric":
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid length in %s", expression)
+		}
+		charset := map[string]string{
+			"numeric":      "0123456789",
+			"alphabetic":   "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ",
+			"alphanumeric": "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789",
+		}[match[1]]
+		value := make([]byte, n)
+		for i := range value {
+			index, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+			if err != nil {
+				return "", err
+			}
+			value[i] = charset[index.Int64()]
+		}
+		return string(value), nil
+	case "timestamp":
+		if len(args) != 2 {
+			return "", fmt.Errorf("%s expects an offset and a unit", expression)
+		}
+		offset, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid offset in %s", expression)
+		}
+		switch args[1] {
+		case "s":
+			return strconv.FormatInt(time.Now().Unix()+offset, 10), nil
+		case "ms":
+			return strconv.FormatInt(time.Now().UnixMilli()+offset, 10), nil
+		}
+		return "", fmt.Errorf("invalid unit in %s, must be s or ms", expression)
+	}
+	return "", fmt.Errorf("%s can't be evaluated locally", expression)
+}
+
+// targetHostPort returns the host and port to connect to for TCP, SSL and DNS requests.
+func (r *syntheticsLocalRunner) targetHostPort(host string, port int64) (string, string, error) {
+	portString := strconv.FormatInt(port, 10)
+	if r.target == "" {
+		return host, portString, nil
+	}
+	target := r.target
+	if u, err := url.Parse(target); err == nil && u.Host != "" {
+		target = u.Host
+	}
+	if h, p, err := net.SplitHostPort(target); err == nil {
+		return h, p, nil
+	}
+	return target, portString, nil
+}
+
+func (r *syntheticsLocalRunner) doHTTPRequest(ctx context.Context, request datadogV1.SyntheticsTestRequest, followRedirects, allowInsecure bool) (*syntheticsLocalResponse, error) {
+	rawURL, err := r.expand(request.GetUrl())
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %s", rawURL, err)
+	}
+	if r.target != "" {
+		target, err := url.Parse(r.target)
+		if err != nil || target.Host == "" {
+			return nil, fmt.Errorf("invalid target %q, it must be a URL", r.target)
+		}
+		u.Scheme = target.Scheme
+		u.Host = target.Host
+	}
+	if query, ok := request.GetQuery().(map[string]interface{}); ok {
+		values := u.Query()
+		for k, v := range query {
+			value, err := r.expand(fmt.Sprint(v))
+			if err != nil {
+				return nil, err
+			}
+			values.Add(k, value)
+		}
+		u.RawQuery = values.Encode()
+	}
+
+	body, err := r.expand(request.GetBody())
+	if err != nil {
+		return nil, err
+	}
+	method := request.GetMethod()
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if bodyType, ok := request.GetBodyTypeOk(); ok {
+		contentType := string(*bodyType)
+		if *bodyType == datadogV1.SYNTHETICSTESTREQUESTBODYTYPE_GRAPHQL {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	for k, v := range request.GetHeaders() {
+		value, err := r.expand(v)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(k, value)
+		if strings.EqualFold(k, "host") {
+			req.Host = value
+		}
+	}
+	if basicAuth, ok := request.GetBasicAuthOk(); ok {
+		web := basicAuth.SyntheticsBasicAuthWeb
+		if web == nil {
+			return nil, fmt.Errorf("only web basic authentication is supported locally")
+		}
+		username, err := r.expand(web.GetUsername())
+		if err != nil {
+			return nil, err
+		}
+		password, err := r.expand(web.GetPassword())
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(username, password)
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: allowInsecure}
+	if certificate, ok := request.GetCertificateOk(); ok {
+		keyPair, err := tls.X509KeyPair([]byte(certificate.Cert.GetContent()), []byte(certificate.Key.GetContent()))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{keyPair}
+	}
+	transport := &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: true}
+	if proxy, ok := request.GetProxyOk(); ok {
+		proxyURL, err := url.Parse(proxy.GetUrl())
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %s", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+		transport.ProxyConnectHeader = http.Header{}
+		for k, v := range proxy.GetHeaders() {
+			transport.ProxyConnectHeader.Set(k, v)
+		}
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(request.GetTimeout()) * time.Second,
+	}
+	if request.GetTimeout() == 0 {
+		client.Timeout = 60 * time.Second
+	}
+	if !followRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	if request.GetPersistCookies() {
+		client.Jar, _ = cookiejar.New(nil)
+	}
+
+	resp := &syntheticsLocalResponse{}
+	var dnsStart time.Time
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:  func(httptrace.DNSDoneInfo) { resp.dnsDuration += time.Since(dnsStart) },
+	}))
+	start := time.Now()
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	resp.body, err = io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.duration = time.Since(start)
+	resp.statusCode = httpResp.StatusCode
+	resp.header = httpResp.Header
+	return resp, nil
+}
+
+func (r *syntheticsLocalRunner) doTCPRequest(ctx context.Context, request datadogV1.SyntheticsTestRequest) (*syntheticsLocalResponse, error) {
+	rawHost, err := r.expand(request.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	host, port, err := r.targetHostPort(rawHost, request.GetPort())
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: time.Duration(request.GetTimeout()) * time.Second}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	resp := &syntheticsLocalResponse{duration: time.Since(start), connection: "established"}
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			resp.connection = "timeout"
+		} else {
+			resp.connection = "refused"
+		}
+		return resp, nil
+	}
+	conn.Close()
+	return resp, nil
+}
+
+func (r *syntheticsLocalRunner) doSSLRequest(ctx context.Context, request datadogV1.SyntheticsTestRequest, acceptSelfSigned bool) (*syntheticsLocalResponse, error) {
+	rawHost, err := r.expand(request.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	host, port, err := r.targetHostPort(rawHost, request.GetPort())
+	if err != nil {
+		return nil, err
+	}
+	serverName, err := r.expand(request.GetServername())
+	if err != nil {
+		return nil, err
+	}
+	if serverName == "" {
+		serverName = rawHost
+	}
+	dial := func(config *tls.Config) (*tls.ConnectionState, error) {
+		dialer := &tls.Dialer{
+			NetDialer: &net.Dialer{Timeout: time.Duration(request.GetTimeout()) * time.Second},
+			Config:    config,
+		}
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		state := conn.(*tls.Conn).ConnectionState()
+		return &state, nil
+	}
+
+	start := time.Now()
+	state, err := dial(&tls.Config{ServerName: serverName, InsecureSkipVerify: acceptSelfSigned})
+	if err != nil {
+		return nil, err
+	}
+	resp := &syntheticsLocalResponse{duration: time.Since(start), tlsVersion: state.Version}
+	if len(state.PeerCertificates) > 0 {
+		resp.certificate = &state.PeerCertificates[0].NotAfter
+	}
+	resp.minTLSVersion = func() (uint16, error) {
+		for _, version := range []uint16{tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13} {
+			if _, err := dial(&tls.Config{ServerName: serverName, InsecureSkipVerify: true, MinVersion: version, MaxVersion: version}); err == nil {
+				return version, nil
+			}
+		}
+		return 0, fmt.Errorf("no TLS version is accepted by the server")
+	}
+	return resp, nil
+}
+
+func (r *syntheticsLocalRunner) doDNSRequest(ctx context.Context, request datadogV1.SyntheticsTestRequest) (*syntheticsLocalResponse, error) {
+	host, err := r.expand(request.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	resolver := net.DefaultResolver
+	if server := request.GetDnsServer(); server != "" || r.target != "" {
+		port := int64(request.GetDnsServerPort())
+		if port == 0 {
+			port = 53
+		}
+		serverHost, serverPort, err := r.targetHostPort(server, port)
+		if err != nil {
+			return nil, err
+		}
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, net.JoinHostPort(serverHost, serverPort))
+			},
+		}
+	}
+
+	resp := &syntheticsLocalResponse{records: make(map[string][]string)}
+	start := time.Now()
+	var notFound *net.DNSError
+	ips, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil && !(errors.As(err, &notFound) && notFound.IsNotFound) {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if ip.IP.To4() != nil {
+			resp.records["A"] = append(resp.records["A"], ip.IP.String())
+		} else {
+			resp.records["AAAA"] = append(resp.records["AAAA"], ip.IP.String())
+		}
+	}
+	resp.duration = time.Since(start)
+	if cname, err := resolver.LookupCNAME(ctx, host); err == nil && strings.TrimSuffix(cname, ".") != strings.TrimSuffix(host, ".") {
+		resp.records["CNAME"] = []string{strings.TrimSuffix(cname, ".")}
+	}
+	if mxs, err := resolver.LookupMX(ctx, host); err == nil {
+		for _, mx := range mxs {
+			resp.records["MX"] = append(resp.records["MX"], strings.TrimSuffix(mx.Host, "."))
+		}
+	}
+	if nss, err := resolver.LookupNS(ctx, host); err == nil {
+		for _, ns := range nss {
+			resp.records["NS"] = append(resp.records["NS"], strings.TrimSuffix(ns.Host, "."))
+		}
+	}
+	if txts, err := resolver.LookupTXT(ctx, host); err == nil {
+		resp.records["TXT"] = append(resp.records["TXT"], txts...)
+	}
+	return resp, nil
+}
+
+func (r *syntheticsLocalRunner) evaluateAssertion(resp *syntheticsLocalResponse, assertion datadogV1.SyntheticsAssertion) SyntheticsLocalAssertionResult {
+	switch {
+	case assertion.SyntheticsAssertionJSONPathTarget != nil:
+		a := assertion.SyntheticsAssertionJSONPathTarget
+		target := a.GetTarget()
+		result := SyntheticsLocalAssertionResult{Description: fmt.Sprintf("%s %s %s %s %v", a.GetType(), a.GetOperator(), target.GetJsonPath(), target.GetOperator(), target.TargetValue)}
+		actual, exists, err := evaluateSyntheticsJSONPath(resp.body, target.GetJsonPath())
+		return r.compareAssertion(result, target.GetOperator(), actual, exists, err, target.TargetValue)
+	case assertion.SyntheticsAssertionXPathTarget != nil:
+		a := assertion.SyntheticsAssertionXPathTarget
+		target := a.GetTarget()
+		result := SyntheticsLocalAssertionResult{Description: fmt.Sprintf("%s %s %s %s %v", a.GetType(), a.GetOperator(), target.GetXPath(), target.GetOperator(), target.TargetValue)}
+		actual, exists, err := evaluateSyntheticsXPath(resp.body, target.GetXPath())
+		return r.compareAssertion(result, target.GetOperator(), actual, exists, err, target.TargetValue)
+	case assertion.SyntheticsAssertionTarget != nil:
+	default:
+		return SyntheticsLocalAssertionResult{Skipped: true, Message: "unknown assertion"}
+	}
+
+	a := assertion.SyntheticsAssertionTarget
+	assertionType := a.GetType()
+	description := string(assertionType)
+	if a.GetProperty() != "" {
+		description += " " + a.GetProperty()
+	}
+	result := SyntheticsLocalAssertionResult{Description: fmt.Sprintf("%s %s %v", description, a.GetOperator(), a.GetTarget())}
+	operator := string(a.GetOperator())
+
+	switch assertionType {
+	case datadogV1.SYNTHETICSASSERTIONTYPE_STATUS_CODE:
+		if resp.header == nil {
+			break
+		}
+		return r.compareAssertion(result, operator, strconv.Itoa(resp.statusCode), true, nil, a.GetTarget())
+	case datadogV1.SYNTHETICSASSERTIONTYPE_BODY:
+		if resp.header == nil {
+			break
+		}
+		return r.compareAssertion(result, operator, string(resp.body), true, nil, a.GetTarget())
+	case datadogV1.SYNTHETICSASSERTIONTYPE_HEADER:
+		if resp.header == nil {
+			break
+		}
+		values, exists := resp.header[http.CanonicalHeaderKey(a.GetProperty())]
+		return r.compareAssertion(result, operator, strings.Join(values, ", "), exists, nil, a.GetTarget())
+	case datadogV1.SYNTHETICSASSERTIONTYPE_RESPONSE_TIME:
+		duration := resp.duration
+		if a.GetTimingsScope() == datadogV1.SYNTHETICSASSERTIONTIMINGSSCOPE_WITHOUT_DNS {
+			duration -= resp.dnsDuration
+		}
+		return r.compareAssertion(result, operator, strconv.FormatInt(duration.Milliseconds(), 10), true, nil, a.GetTarget())
+	case datadogV1.SYNTHETICSASSERTIONTYPE_CONNECTION:
+		if resp.connection == "" {
+			break
+		}
+		return r.compareAssertion(result, operator, resp.connection, true, nil, a.GetTarget())
+	case datadogV1.SYNTHETICSASSERTIONTYPE_TLS_VERSION, datadogV1.SYNTHETICSASSERTIONTYPE_MIN_TLS_VERSION:
+		if resp.tlsVersion == 0 {
+			break
+		}
+		version := resp.tlsVersion
+		if assertionType == datadogV1.SYNTHETICSASSERTIONTYPE_MIN_TLS_VERSION {
+			var err error
+			if version, err = resp.minTLSVersion(); err != nil {
+				return r.compareAssertion(result, operator, "", false, err, a.GetTarget())
+			}
+		}
+		return r.compareAssertion(result, operator, strings.TrimPrefix(tls.VersionName(version), "TLS "), true, nil, a.GetTarget())
+	case datadogV1.SYNTHETICSASSERTIONTYPE_CERTIFICATE:
+		if resp.certificate == nil {
+			break
+		}
+		days := time.Until(*resp.certificate).Hours() / 24
+		result.Actual = fmt.Sprintf("expires in %.1f days", days)
+		target, err := strconv.ParseFloat(fmt.Sprint(a.GetTarget()), 64)
+		if err != nil {
+			result.Message = fmt.Sprintf("invalid target: %s", err)
+			return result
+		}
+		switch a.GetOperator() {
+		case datadogV1.SYNTHETICSASSERTIONOPERATOR_IS_IN_MORE_DAYS_THAN:
+			result.Passed = days > target
+		case datadogV1.SYNTHETICSASSERTIONOPERATOR_IS_IN_LESS_DAYS_THAN:
+			result.Passed = days < target
+		default:
+			result.Message = fmt.Sprintf("operator %s is not supported for certificate assertions", operator)
+		}
+		return result
+	case datadogV1.SYNTHETICSASSERTIONTYPE_RECORD_EVERY, datadogV1.SYNTHETICSASSERTIONTYPE_RECORD_SOME:
+		if resp.records == nil {
+			break
+		}
+		records := resp.records[a.GetProperty()]
+		result.Actual = strings.Join(records, ", ")
+		if len(records) == 0 {
+			result.Message = fmt.Sprintf("no %s record found", a.GetProperty())
+			return result
+		}
+		target, err := r.expand(fmt.Sprint(a.GetTarget()))
+		if err != nil {
+			result.Message = err.Error()
+			return result
+		}
+		result.Passed = assertionType == datadogV1.SYNTHETICSASSERTIONTYPE_RECORD_EVERY
+		for _, record := range records {
+			passed, err := compareSyntheticsValue(operator, record, true, target)
+			if err != nil {
+				result.Passed = false
+				result.Message = err.Error()
+				return result
+			}
+			if passed != result.Passed {
+				result.Passed = passed
+				break
+			}
+		}
+		return result
+	}
+	result.Skipped = true
+	result.Message = fmt.Sprintf("%s assertions can't be evaluated locally for this test", assertionType)
+	return result
+}
+
+// compareAssertion completes an assertion result by comparing the actual value with the target.
+func (r *syntheticsLocalRunner) compareAssertion(result SyntheticsLocalAssertionResult, operator string, actual string, exists bool, err error, target interface{}) SyntheticsLocalAssertionResult {
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	result.Actual = actual
+	if len(result.Actual) > 200 {
+		result.Actual = result.Actual[:200] + "..."
+	}
+	var expandedTarget string
+	if target != nil {
+		if expandedTarget, err = r.expand(fmt.Sprint(target)); err != nil {
+			result.Message = err.Error()
+			return result
+		}
+	}
+	result.Passed, err = compareSyntheticsValue(operator, actual, exists, expandedTarget)
+	if err != nil {
+		result.Message = err.Error()
+	} else if !exists && !result.Passed {
+		result.Message = "value not found"
+	}
+	return result
+}
+
+func compareSyntheticsValue(operator string, actual string, exists bool, target string) (bool, error) {
+	switch datadogV1.SyntheticsAssertionOperator(operator) {
+	case datadogV1.SYNTHETICSASSERTIONOPERATOR_DOES_NOT_EXIST, datadogV1.SYNTHETICSASSERTIONOPERATOR_IS_UNDEFINED:
+		return !exists, nil
+	}
+	if !exists {
+		return false, nil
+	}
+	switch datadogV1.SyntheticsAssertionOperator(operator) {
+	case datadogV1.SYNTHETICSASSERTIONOPERATOR_IS, datadogV1.SYNTHETICSASSERTIONOPERATOR_IS_NOT:
+		equal := actual == target
+		if a, errA := strconv.ParseFloat(actual, 64); errA == nil {
+			if t, errT := strconv.ParseFloat(target, 64); errT == nil {
+				equal = a == t
+			}
+		}
+		return equal == (operator == string(datadogV1.SYNTHETICSASSERTIONOPERATOR_IS)), nil
+	case datadogV1.SYNTHETICSASSERTIONOPERATOR_CONTAINS:
+		return strings.Contains(actual, target), nil
+	case datadogV1.SYNTHETICSASSERTIONOPERATOR_DOES_NOT_CONTAIN:
+		return !strings.Contains(actual, target), nil
+	case datadogV1.SYNTHETICSASSERTIONOPERATOR_MATCHES, datadogV1.SYNTHETICSASSERTIONOPERATOR_DOES_NOT_MATCH:
+		re, err := regexp.Compile(target)
+		if err != nil {
+			return false, fmt.Errorf("invalid regular expression %q: %s", target, err)
+		}
+		return re.MatchString(actual) == (operator == string(datadogV1.SYNTHETICSASSERTIONOPERATOR_MATCHES)), nil
+	case datadogV1.SYNTHETICSASSERTIONOPERATOR_LESS_THAN,
+		datadogV1.SYNTHETICSASSERTIONOPERATOR_LESS_THAN_OR_EQUAL,
+		datadogV1.SYNTHETICSASSERTIONOPERATOR_MORE_THAN,
+		datadogV1.SYNTHETICSASSERTIONOPERATOR_MORE_THAN_OR_EQUAL:
+		a, err := strconv.ParseFloat(actual, 64)
+		if err != nil {
+			return false, fmt.Errorf("%q is not a number", actual)
+		}
+		t, err := strconv.ParseFloat(target, 64)
+		if err != nil {
+			return false, fmt.Errorf("target %q is not a number", target)
+		}
+		switch datadogV1.SyntheticsAssertionOperator(operator) {
+		case datadogV1.SYNTHETICSASSERTIONOPERATOR_LESS_THAN:
+			return a < t, nil
+		case datadogV1.SYNTHETICSASSERTIONOPERATOR_LESS_THAN_OR_EQUAL:
+			return a <= t, nil
+		case datadogV1.SYNTHETICSASSERTIONOPERATOR_MORE_THAN:
+			return a > t, nil
+		}
+		return a >= t, nil
+	}
+	return false, fmt.Errorf("operator %s can't be evaluated locally", operator)
+}
+
+// extractValue parses a value out of a response, with the same parsers as global variables.
+func (r *syntheticsLocalRunner) extractValue(resp *syntheticsLocalResponse, extractedValue datadogV1.SyntheticsParsingOptions) (string, error) {
+	var source string
+	switch extractedValue.GetType() {
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSETESTOPTIONSTYPE_HTTP_BODY:
+		source = string(resp.body)
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSETESTOPTIONSTYPE_HTTP_HEADER:
+		values, ok := resp.header[http.CanonicalHeaderKey(extractedValue.GetField())]
+		if !ok {
+			return "", fmt.Errorf("header %s not found", extractedValue.GetField())
+		}
+		source = strings.Join(values, ", ")
+	default:
+		return "", fmt.Errorf("values of type %s can't be extracted locally", extractedValue.GetType())
+	}
+
+	parser := extractedValue.GetParser()
+	parserValue, err := r.expand(parser.GetValue())
+	if err != nil {
+		return "", err
+	}
+	switch parser.GetType() {
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_RAW:
+		return source, nil
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_REGEX:
+		re, err := regexp.Compile(parserValue)
+		if err != nil {
+			return "", fmt.Errorf("invalid regular expression %q: %s", parserValue, err)
+		}
+		match := re.FindStringSubmatch(source)
+		if match == nil {
+			return "", fmt.Errorf("%q does not match", parserValue)
+		}
+		// The first capture group is used when there is one
+		if len(match) > 1 {
+			return match[1], nil
+		}
+		return match[0], nil
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_JSON_PATH:
+		value, exists, err := evaluateSyntheticsJSONPath([]byte(source), parserValue)
+		if err == nil && !exists {
+			err = fmt.Errorf("%s not found", parserValue)
+		}
+		return value, err
+	case datadogV1.SYNTHETICSGLOBALVARIABLEPARSERTYPE_X_PATH:
+		value, exists, err := evaluateSyntheticsXPath([]byte(source), parserValue)
+		if err == nil && !exists {
+			err = fmt.Errorf("%s not found", parserValue)
+		}
+		return value, err
+	}
+	return "", fmt.Errorf("unknown parser %s", parser.GetType())
+}
+
+// evaluateSyntheticsJSONPath returns the value at path in the JSON document body. Values other than
+// strings are returned JSON encoded.
+func evaluateSyntheticsJSONPath(body []byte, path string) (string, bool, error) {
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return "", false, fmt.Errorf("response body is not valid JSON: %s", err)
+	}
+	expr, err := jp.ParseString(path)
+	if err != nil {
+		return "", false, err
+	}
+	values := expr.Get(document)
+	if len(values) == 0 {
+		return "", false, nil
+	}
+	// Paths selecting a single element, such as `$.items[0].id`, return it rather than a list
+	var value interface{} = values
+	if len(values) == 1 {
+		value = values[0]
+	}
+	if s, ok := value.(string); ok {
+		return s, true, nil
+	}
+	encoded, err := json.Marshal(value)
+	return string(encoded), true, err
+}
+
+// evaluateSyntheticsXPath returns the text of the first node matching path in the XML document body,
+// or the value of the expression when it doesn't select nodes.
+func evaluateSyntheticsXPath(body []byte, path string) (string, bool, error) {
+	expr, err := xpath.Compile(path)
+	if err != nil {
+		return "", false, err
+	}
+	document, err := xmlquery.Parse(strings.NewReader(string(body)))
+	if err != nil {
+		return "", false, fmt.Errorf("response body is not valid XML: %s", err)
+	}
+	switch value := expr.Evaluate(xmlquery.CreateXPathNavigator(document)).(type) {
+	case *xpath.NodeIterator:
+		if !value.MoveNext() {
+			return "", false, nil
+		}
+		return value.Current().Value(), true, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true, nil
+	case bool:
+		return strconv.FormatBool(value), true, nil
+	case string:
+		return value, true, nil
+	}
+	return "", false, nil
+}
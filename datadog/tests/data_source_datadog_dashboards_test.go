@@ -0,0 +1,91 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogDashboardsDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      checkDashboardDestroy(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceDashboardsTitleFilterConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_dashboards.all", "dashboards.#", "2"),
+					resource.TestCheckResourceAttrSet("data.datadog_dashboards.all", "dashboards.0.id"),
+					resource.TestCheckResourceAttrSet("data.datadog_dashboards.all", "dashboards.0.url"),
+					resource.TestCheckResourceAttr("data.datadog_dashboards.all", "dashboards.0.definition", ""),
+				),
+			},
+			{
+				Config: testAccDatasourceDashboardsTagsFilterConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_dashboards.tagged", "dashboards.#", "1"),
+					resource.TestCheckResourceAttr("data.datadog_dashboards.tagged", "dashboards.0.title", uniq+" tagged"),
+					resource.TestCheckResourceAttrSet("data.datadog_dashboards.tagged", "dashboards.0.definition"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDashboardsConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_dashboard" "untagged" {
+  title       = "%[1]s untagged"
+  layout_type = "ordered"
+  widget {
+    note_definition {
+      content = "untagged"
+    }
+  }
+}
+
+resource "datadog_dashboard" "tagged" {
+  title       = "%[1]s tagged"
+  layout_type = "ordered"
+  tags        = ["team:datasource"]
+  widget {
+    note_definition {
+      content = "tagged"
+    }
+  }
+}`, uniq)
+}
+
+func testAccDatasourceDashboardsTitleFilterConfig(uniq string) string {
+	return fmt.Sprintf(`
+%s
+data "datadog_dashboards" "all" {
+  depends_on = [
+    datadog_dashboard.untagged,
+    datadog_dashboard.tagged,
+  ]
+  title_filter = "%s"
+}`, testAccDashboardsConfig(uniq), uniq)
+}
+
+func testAccDatasourceDashboardsTagsFilterConfig(uniq string) string {
+	return fmt.Sprintf(`
+%s
+data "datadog_dashboards" "tagged" {
+  depends_on = [
+    datadog_dashboard.untagged,
+    datadog_dashboard.tagged,
+  ]
+  title_filter       = "%s"
+  tags_filter        = ["team:datasource"]
+  include_definition = true
+}`, testAccDashboardsConfig(uniq), uniq)
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogLogsPipelineSimulationDatasource(t *testing.T) {
+	t.Parallel()
+	_, accProviders := testAccProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceLogsPipelineSimulationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "results.#", "2"),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "results.0.matched", "true"),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "results.0.event",
+						`{"date":"2016-07-13T10:55:36.000Z","http":{"method":"GET","status_category":"OK","status_code":200,"url":"/apache_pb.gif"},"message":"127.0.0.1 [13/Jul/2016:10:55:36 +0000] \"GET /apache_pb.gif\" 200","network":{"client":{"ip":"127.0.0.1"}},"source":"nginx","summary":"GET /apache_pb.gif returned 200","timestamp":1468407336000}`),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "results.1.matched", "false"),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "results.1.event", `{"message":"not an nginx log"}`),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "warnings.#", "0"),
+				),
+			},
+			{
+				Config:      testAccDatasourceLogsPipelineSimulationInvalidConfig,
+				ExpectError: regexp.MustCompile(`grok_parser: rule "access": token %\{unknown:a\}: unknown matcher or support rule "unknown"`),
+			},
+		},
+	})
+}
+
+const testAccDatasourceLogsPipelineSimulationConfig = `
+data "datadog_logs_pipeline_simulation" "nginx" {
+  pipeline {
+    name       = "nginx"
+    is_enabled = true
+    filter {
+      query = "source:nginx"
+    }
+    processor {
+      grok_parser {
+        name       = "access logs"
+        is_enabled = true
+        source     = "message"
+        samples    = []
+        grok {
+          support_rules = ""
+          match_rules   = "access %%{ipOrHost:network.client.ip} \\[%%{date(\"dd/MMM/yyyy:HH:mm:ss Z\"):timestamp}\\] \"%%{word:http.method} %%{notSpace:http.url}\" %%{integer:http.status_code}"
+        }
+      }
+    }
+    processor {
+      date_remapper {
+        is_enabled = true
+        sources    = ["timestamp"]
+      }
+    }
+    processor {
+      category_processor {
+        is_enabled = true
+        target     = "http.status_category"
+        category {
+          name = "OK"
+          filter {
+            query = "@http.status_code:[200 TO 299]"
+          }
+        }
+        category {
+          name = "Error"
+          filter {
+            query = "@http.status_code:>=400"
+          }
+        }
+      }
+    }
+    processor {
+      string_builder_processor {
+        is_enabled = true
+        target     = "summary"
+        template   = "%%{http.method} %%{http.url} returned %%{http.status_code}"
+      }
+    }
+  }
+  events = [
+    jsonencode({
+      source  = "nginx"
+      message = "127.0.0.1 [13/Jul/2016:10:55:36 +0000] \"GET /apache_pb.gif\" 200"
+    }),
+    "not an nginx log",
+  ]
+}
+`
+
+const testAccDatasourceLogsPipelineSimulationInvalidConfig = `
+data "datadog_logs_pipeline_simulation" "invalid" {
+  pipeline {
+    name       = "invalid"
+    is_enabled = true
+    filter {
+      query = "source:nginx"
+    }
+    processor {
+      grok_parser {
+        is_enabled = true
+        source     = "message"
+        grok {
+          support_rules = ""
+          match_rules   = "access %%{unknown:a}"
+        }
+      }
+    }
+  }
+  events = ["a"]
+}
+`
@@ -0,0 +1,139 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsBrowserRecordingDatasource(t *testing.T) {
+	t.Parallel()
+	_, accProviders := testAccProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceSyntheticsBrowserRecordingConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "title", "checkout"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "start_url", "https://shop.example.com/"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.#", "8"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.0.type", "click"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.0.name", "Click on #search"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.0.timeout", "3"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.0.params.0.click_type", "primary"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.0.params.0.element_user_locator.0.value.0.type", "css"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.0.params.0.element_user_locator.0.value.0.value", "#search"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.1.type", "typeText"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.1.params.0.value", "shoes"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.1.params.0.element_user_locator.0.value.0.type", "xpath"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.1.params.0.element_user_locator.0.value.0.value", "//*[@id=\"q\"]"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.2.type", "pressKey"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.2.name", "Press Shift + Enter"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.2.params.0.value", "Enter"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.2.params.0.modifiers.#", "1"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.2.params.0.modifiers.0", "Shift"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.3.type", "assertCurrentUrl"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.3.params.0.check", "equals"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.3.params.0.value", "https://shop.example.com/search?q=shoes"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.4.type", "click"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.4.params.0.click_type", "double"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.5.type", "scroll"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.5.params.0.y", "600"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.6.type", "assertElementPresent"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.6.params.0.element_user_locator.0.value.0.value", ".cart-count"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.7.type", "assertFromJavascript"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_recording.checkout", "browser_step.7.params.0.code", "return document.title.includes('Cart')"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatadogSyntheticsBrowserRecordingDatasource_unsupported(t *testing.T) {
+	t.Parallel()
+	_, accProviders := testAccProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "datadog_synthetics_browser_recording" "frame" {
+  recording = jsonencode({
+    title = "frame"
+    steps = [
+      { type = "navigate", url = "https://shop.example.com/" },
+      { type = "click", target = "main", frame = [0], selectors = [["#pay"]] },
+    ]
+  })
+}`,
+				ExpectError: regexp.MustCompile(`step 1 \(click\): only steps in the main frame of the main page are supported`),
+			},
+			{
+				Config: `
+data "datadog_synthetics_browser_recording" "aria" {
+  recording = jsonencode({
+    title = "aria"
+    steps = [
+      { type = "navigate", url = "https://shop.example.com/" },
+      { type = "click", selectors = [["aria/Pay"], ["text/Pay"]] },
+    ]
+  })
+}`,
+				ExpectError: regexp.MustCompile(`step 1 \(click\): no CSS or XPath selector recorded`),
+			},
+		},
+	})
+}
+
+const testAccDatasourceSyntheticsBrowserRecordingConfig = `
+data "datadog_synthetics_browser_recording" "checkout" {
+  recording = <<EOF
+{
+  "title": "checkout",
+  "steps": [
+    { "type": "setViewport", "width": 1280, "height": 720, "deviceScaleFactor": 1, "isMobile": false, "hasTouch": false, "isLandscape": false },
+    {
+      "type": "navigate",
+      "url": "https://shop.example.com/",
+      "assertedEvents": [{ "type": "navigation", "url": "https://shop.example.com/", "title": "Shop" }]
+    },
+    {
+      "type": "click",
+      "target": "main",
+      "timeout": 2500,
+      "selectors": [["aria/Search"], ["#search"], ["xpath///*[@id=\"search\"]"], ["pierce/#search"]],
+      "offsetX": 10,
+      "offsetY": 5
+    },
+    { "type": "change", "value": "shoes", "selectors": [["aria/Query"], ["xpath///*[@id=\"q\"]"]], "target": "main" },
+    { "type": "keyDown", "target": "main", "key": "Shift" },
+    {
+      "type": "keyDown",
+      "target": "main",
+      "key": "Enter",
+      "assertedEvents": [{ "type": "navigation", "url": "https://shop.example.com/search?q=shoes", "title": "" }]
+    },
+    { "type": "keyUp", "target": "main", "key": "Enter" },
+    { "type": "keyUp", "target": "main", "key": "Shift" },
+    { "type": "doubleClick", "target": "main", "selectors": ["div.product:nth-child(1) > a"], "offsetX": 1, "offsetY": 1 },
+    { "type": "scroll", "target": "main", "x": 0, "y": 600 },
+    { "type": "waitForElement", "target": "main", "selectors": [[".cart-count"]] },
+    { "type": "waitForExpression", "target": "main", "expression": "document.title.includes('Cart')" }
+  ]
+}
+EOF
+}
+`
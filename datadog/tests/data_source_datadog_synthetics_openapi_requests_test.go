@@ -0,0 +1,192 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsOpenAPIRequestsDatasource(t *testing.T) {
+	t.Parallel()
+	_, accProviders := testAccProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceSyntheticsOpenAPIRequestsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.#", "3"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.operation_id", "listPets"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.method", "GET"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.url", "https://api.example.com/v1/pets?limit=20"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.body", ""),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.request_headers.%", "2"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.request_headers.Accept", "application/json"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.request_headers.X-Tenant", "acme"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.assertion.#", "2"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.assertion.0.type", "statusCode"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.assertion.0.target", "200"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.assertion.1.type", "header"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.0.assertion.1.property", "content-type"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.operation_id", "createPet"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.method", "POST"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.body", `{"name":"string","tag":"dog"}`),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.body_type", "application/json"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.request_headers.Content-Type", "application/json"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.assertion.0.target", "201"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.assertion.#", "5"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.assertion.2.operator", "validatesJSONPath"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.assertion.2.targetjsonpath.0.jsonpath", "$.id"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.assertion.2.targetjsonpath.0.operator", "matches"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.assertion.2.targetjsonpath.0.targetvalue", `^-?\d+$`),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.assertion.3.targetjsonpath.0.jsonpath", "$.name"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.assertion.4.targetjsonpath.0.jsonpath", "$['x-status']"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.1.assertion.4.targetjsonpath.0.targetvalue", "^(available|sold)$"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.2.operation_id", "GET /pets/{petId}"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.pets", "request.2.url", "https://api.example.com/v1/pets/42"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.tagged", "request.#", "1"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_openapi_requests.tagged", "request.0.url", "https://staging.example.com/pets/42"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatadogSyntheticsOpenAPIRequestsDatasource_errors(t *testing.T) {
+	t.Parallel()
+	_, accProviders := testAccProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "datadog_synthetics_openapi_requests" "swagger" { spec = jsonencode({ swagger = "2.0", paths = {} }) }`,
+				ExpectError: regexp.MustCompile(`only OpenAPI 3 documents are supported`),
+			},
+			{
+				Config:      `data "datadog_synthetics_openapi_requests" "relative" { spec = jsonencode({ openapi = "3.0.3", servers = [{ url = "/v1" }], paths = {} }) }`,
+				ExpectError: regexp.MustCompile(`server_url must be set`),
+			},
+		},
+	})
+}
+
+const testAccDatasourceSyntheticsOpenAPIRequestsConfig = `
+locals {
+  spec = <<EOF
+openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://{host}/v1
+    variables:
+      host:
+        default: api.example.com
+paths:
+  /pets:
+    parameters:
+      - name: X-Tenant
+        in: header
+        required: true
+        schema:
+          type: string
+          example: acme
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: integer
+            default: 20
+        - name: cursor
+          in: query
+          schema:
+            type: string
+      responses:
+        200:
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: createPet
+      tags: [pets]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/NewPet'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        default:
+          description: Error
+  /pets/{petId}:
+    get:
+      tags: [read]
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          example: 42
+          schema:
+            type: integer
+      responses:
+        '2XX':
+          description: The pet
+components:
+  schemas:
+    NewPet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+          enum: [dog, cat]
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/NewPet'
+        - type: object
+          required: [id, x-status, owner]
+          properties:
+            id:
+              type: integer
+              readOnly: true
+            x-status:
+              type: string
+              enum: [available, sold]
+            owner:
+              type: object
+EOF
+}
+
+data "datadog_synthetics_openapi_requests" "pets" {
+  spec = local.spec
+}
+
+data "datadog_synthetics_openapi_requests" "tagged" {
+  spec       = local.spec
+  server_url = "https://staging.example.com/"
+  tags       = ["read"]
+}
+`
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsTestsDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+	tag := "test_datasource_synthetics_tests:" + strings.ToLower(strings.ReplaceAll(uniq, "-", "_"))
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testSyntheticsTestIsDestroyed(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceSyntheticsTestsConfig(uniq, tag),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.tagged", "tests.#", "2"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.paused_browser", "tests.#", "1"),
+					resource.TestCheckResourceAttrPair("data.datadog_synthetics_tests.paused_browser", "tests.0.public_id", "datadog_synthetics_test.browser", "id"),
+					resource.TestCheckResourceAttrPair("data.datadog_synthetics_tests.paused_browser", "tests.0.name", "datadog_synthetics_test.browser", "name"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.paused_browser", "tests.0.type", "browser"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.paused_browser", "tests.0.status", "paused"),
+					resource.TestCheckResourceAttrPair("data.datadog_synthetics_tests.paused_browser", "tests.0.monitor_id", "datadog_synthetics_test.browser", "monitor_id"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.paused_browser", "tests.0.locations.#", "1"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.paused_browser", "tests.0.locations.0", "aws:eu-central-1"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.paused_browser", "tests.0.tags.#", "2"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.region", "tests.#", "1"),
+					resource.TestCheckResourceAttrPair("data.datadog_synthetics_tests.region", "tests.0.public_id", "datadog_synthetics_test.api", "id"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.region", "tests.0.type", "api"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.region", "tests.0.subtype", "http"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.name", "tests.#", "1"),
+					resource.TestCheckResourceAttrPair("data.datadog_synthetics_tests.name", "tests.0.public_id", "datadog_synthetics_test.api", "id"),
+
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.creator", "tests.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceSyntheticsTestsConfig(uniq string, tag string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test" "browser" {
+  name       = "%[1]s"
+  type       = "browser"
+  status     = "paused"
+  locations  = ["aws:eu-central-1"]
+  device_ids = ["laptop_large"]
+  tags       = ["%[2]s", "team:checkout"]
+  request_definition {
+    method = "GET"
+    url    = "https://www.datadoghq.com"
+  }
+  options_list {
+    tick_every = 900
+  }
+}
+
+resource "datadog_synthetics_test" "api" {
+  name      = "%[1]s-health"
+  type      = "api"
+  subtype   = "http"
+  status    = "paused"
+  locations = ["aws:eu-west-3"]
+  tags      = ["%[2]s"]
+  request_definition {
+    method = "GET"
+    url    = "https://www.datadoghq.com"
+  }
+  assertion {
+    type     = "statusCode"
+    operator = "is"
+    target   = "200"
+  }
+  options_list {
+    tick_every = 900
+  }
+}
+
+data "datadog_synthetics_tests" "tagged" {
+  depends_on  = [datadog_synthetics_test.browser, datadog_synthetics_test.api]
+  tags_filter = ["%[2]s"]
+}
+
+data "datadog_synthetics_tests" "paused_browser" {
+  depends_on    = [datadog_synthetics_test.browser, datadog_synthetics_test.api]
+  type_filter   = "browser"
+  status_filter = "paused"
+  tags_filter   = ["%[2]s", "team:checkout"]
+}
+
+data "datadog_synthetics_tests" "region" {
+  depends_on       = [datadog_synthetics_test.browser, datadog_synthetics_test.api]
+  tags_filter      = ["%[2]s"]
+  locations_filter = ["aws:eu-west-3", "aws:ap-northeast-1"]
+}
+
+data "datadog_synthetics_tests" "name" {
+  depends_on  = [datadog_synthetics_test.browser, datadog_synthetics_test.api]
+  tags_filter = ["%[2]s"]
+  name_filter = "%[3]s"
+}
+
+data "datadog_synthetics_tests" "creator" {
+  depends_on     = [datadog_synthetics_test.browser, datadog_synthetics_test.api]
+  tags_filter    = ["%[2]s"]
+  creator_filter = "nobody@example.com"
+}`, uniq, tag, strings.ToUpper(uniq+"-health"))
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog"
+)
+
+func TestDatadogMonitor_export(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	monitorName := uniqueEntityName(ctx, t)
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testAccCheckDatadogMonitorDestroy(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogMonitorConfigImported(monitorName),
+				Check:  testAccCheckDatadogMonitorExport(accProvider, "datadog_monitor.foo", monitorName),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogMonitorExport(accProvider func() (*schema.Provider, error), name, monitorName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		provider, _ := accProvider()
+		id := s.RootModule().Resources[name].Primary.ID
+
+		var out bytes.Buffer
+		err := datadog.Export(context.Background(), provider.Meta(), datadog.ExportOptions{
+			ResourceType: "datadog_monitor",
+			IDs:          []string{id},
+		}, &out)
+		if err != nil {
+			return err
+		}
+
+		label := strings.ReplaceAll(strings.ToLower(monitorName), "-", "_")
+		expected := []string{
+			fmt.Sprintf(`resource "datadog_monitor" "%s" {`, label),
+			fmt.Sprintf(`name = "%s"`, monitorName),
+			`query = "avg(last_1h):avg:aws.ec2.cpu{environment:foo,host:foo} by {host} > 2.5"`,
+			`renotify_statuses = ["alert", "warn"]`,
+			"monitor_thresholds {",
+			fmt.Sprintf("to = datadog_monitor.%s", label),
+			fmt.Sprintf(`id = "%s"`, id),
+		}
+		// Ignore the alignment of the attributes
+		exported := strings.Join(strings.Fields(out.String()), " ")
+		for _, e := range expected {
+			if !strings.Contains(exported, e) {
+				return fmt.Errorf("exported configuration does not contain %q:\n%s", e, out.String())
+			}
+		}
+		return nil
+	}
+}
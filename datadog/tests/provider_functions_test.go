@@ -0,0 +1,106 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDatadogProviderFunctions(t *testing.T) {
+	t.Parallel()
+	_, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: accProviders,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogProviderFunctionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("normalize_tag", "env:my_service"),
+					resource.TestCheckOutput("monitor_query", `avg(last_5m):max:system.cpu.user{env:prod,!host:a\ b} by {host} > 90.5`),
+					resource.TestCheckOutput("monitor_query_all", `sum(last_1h):sum:trace.http.request.hits{*} < 10`),
+					resource.TestCheckOutput("monitor_id", "12345"),
+					resource.TestCheckOutput("legacy_monitor_id", "678"),
+					resource.TestCheckOutput("dashboard", `{"is_read_only":false,"layout_type":"ordered","title":"foo","widgets":[{"definition":{"type":"note"}}]}`),
+					resource.TestCheckOutput("tag_list", "env:prod,team:foo,unkeyed"),
+					resource.TestCheckOutput("tag_map", "env=prod,team=foo:bar,unkeyed="),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatadogProviderFunctions_errors(t *testing.T) {
+	t.Parallel()
+	_, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: accProviders,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      `output "id" { value = provider::datadog::parse_monitor_id_from_url("https://app.datadoghq.com/dashboard/abc-def") }`,
+				ExpectError: regexp.MustCompile("is not a monitor URL"),
+			},
+			{
+				Config:      `output "query" { value = provider::datadog::monitor_query("avg", "5m", "avg", "system.load.1", [], [], ">", 1) }`,
+				ExpectError: regexp.MustCompile(`timeframe must be an evaluation\s+window`),
+			},
+			{
+				Config:      `output "tags" { value = provider::datadog::tag_list_to_map(["env:prod", "env:staging"]) }`,
+				ExpectError: regexp.MustCompile(`tag key "env" is present more\s+than\s+once`),
+			},
+		},
+	})
+}
+
+const testAccCheckDatadogProviderFunctionsConfig = `
+output "normalize_tag" {
+  value = provider::datadog::normalize_tag("Env:My Service")
+}
+
+output "monitor_query" {
+  value = provider::datadog::monitor_query("avg", "last_5m", "max", "system.cpu.user", ["env:prod", "!host:a b"], ["host"], ">", 90.5)
+}
+
+output "monitor_query_all" {
+  value = provider::datadog::monitor_query("sum", "last_1h", "sum", "trace.http.request.hits", [], [], "<", 10)
+}
+
+output "monitor_id" {
+  value = provider::datadog::parse_monitor_id_from_url("https://app.datadoghq.com/monitors/12345?view=spans")
+}
+
+output "legacy_monitor_id" {
+  value = provider::datadog::parse_monitor_id_from_url("https://app.datadoghq.com/monitors#678")
+}
+
+output "dashboard" {
+  value = provider::datadog::dashboard_json_canonicalize(jsonencode({
+    id          = "abc-def-ghi"
+    title       = "foo"
+    url         = "/dashboard/abc-def-ghi/foo"
+    layout_type = "ordered"
+    widgets = [{
+      id         = 123
+      definition = { type = "note" }
+    }]
+  }))
+}
+
+output "tag_list" {
+  value = join(",", provider::datadog::tag_map_to_list({ team = "foo", env = "prod", unkeyed = "" }))
+}
+
+output "tag_map" {
+  value = join(",", [for k, v in provider::datadog::tag_list_to_map(["team:foo:bar", "env:prod", "unkeyed"]) : "${k}=${v}"])
+}
+`
@@ -55,6 +55,7 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/data_source_datadog_cloud_workload_security_agent_rules_test":     "cloud-workload-security",
 	"tests/data_source_datadog_dashboard_list_test":                          "dashboard-lists",
 	"tests/data_source_datadog_dashboard_test":                               "dashboard",
+	"tests/data_source_datadog_dashboards_test":                              "dashboard",
 	"tests/data_source_datadog_hosts_test":                                   "hosts",
 	"tests/data_source_datadog_integration_aws_logs_services_test":           "integration-aws",
 	"tests/data_source_datadog_integration_aws_namespace_rules_test":         "integration-aws",
@@ -62,6 +63,7 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/data_source_datadog_logs_archives_order_test":                     "logs-archive",
 	"tests/data_source_datadog_logs_indexes_order_test":                      "logs-index",
 	"tests/data_source_datadog_logs_indexes_test":                            "logs-index",
+	"tests/data_source_datadog_logs_pipeline_simulation_test":                "logs-pipelines",
 	"tests/data_source_datadog_logs_pipelines_test":                          "logs-pipelines",
 	"tests/data_source_datadog_monitor_config_policies_test":                 "monitor-config-policies",
 	"tests/data_source_datadog_monitor_config_policy_test":                   "monitor-config-policies",
@@ -79,17 +81,23 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/data_source_datadog_service_account_test":                         "users",
 	"tests/data_source_datadog_service_level_objective_test":                 "service-level-objectives",
 	"tests/data_source_datadog_service_level_objectives_test":                "service-level-objectives",
+	"tests/data_source_datadog_synthetics_browser_recording_test":            "synthetics",
 	"tests/data_source_datadog_synthetics_global_variable_test":              "synthetics",
 	"tests/data_source_datadog_synthetics_locations_test":                    "synthetics",
+	"tests/data_source_datadog_synthetics_openapi_requests_test":             "synthetics",
+	"tests/data_source_datadog_synthetics_private_location_deployment_test":  "synthetics",
 	"tests/data_source_datadog_synthetics_test_test":                         "synthetics",
+	"tests/data_source_datadog_synthetics_tests_test":                        "synthetics",
 	"tests/data_source_datadog_team_memberships_test":                        "team",
 	"tests/data_source_datadog_team_test":                                    "team",
 	"tests/data_source_datadog_user_test":                                    "users",
+	"tests/export_datadog_monitor_test":                                      "monitors",
 	"tests/import_datadog_downtime_test":                                     "downtimes",
 	"tests/import_datadog_integration_pagerduty_test":                        "integration-pagerduty",
 	"tests/import_datadog_logs_pipeline_test":                                "logs-pipelines",
 	"tests/import_datadog_monitor_test":                                      "monitors",
 	"tests/import_datadog_user_test":                                         "users",
+	"tests/provider_functions_test":                                          "terraform",
 	"tests/provider_test":                                                    "terraform",
 	"tests/resource_datadog_api_key_test":                                    "api_keys",
 	"tests/resource_datadog_application_key_test":                            "application_keys",
@@ -132,6 +140,7 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/resource_datadog_dashboard_topology_map_test":                     "dashboards",
 	"tests/resource_datadog_dashboard_trace_service_test":                    "dashboards",
 	"tests/resource_datadog_dashboard_treemap_test":                          "dashboards",
+	"tests/resource_datadog_dashboard_widgets_roundtrip_test":                "dashboards",
 	"tests/resource_datadog_downtime_test":                                   "downtimes",
 	"tests/resource_datadog_downtime_schedule_test":                          "downtimes",
 	"tests/resource_datadog_integration_aws_lambda_arn_test":                 "integration-aws",
@@ -152,15 +161,19 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/resource_datadog_ip_allowlist_test":                               "ip_allowlist",
 	"tests/resource_datadog_logs_archive_order_test":                         "logs-archive-order",
 	"tests/resource_datadog_logs_archive_test":                               "logs-archive",
+	"tests/resource_datadog_logs_custom_pipeline_json_test":                  "logs-pipelines",
 	"tests/resource_datadog_logs_custom_pipeline_test":                       "logs-pipelines",
+	"tests/resource_datadog_logs_pipeline_order_test":                        "logs-pipelines",
 	"tests/resource_datadog_logs_index_test":                                 "logs-index",
 	"tests/resource_datadog_logs_metric_test":                                "logs-metric",
+	"tests/resource_datadog_logs_restriction_query_test":                     "logs-restriction-queries",
 	"tests/resource_datadog_metric_metadata_test":                            "metrics",
 	"tests/resource_datadog_metric_tag_configuration_test":                   "metrics",
 	"tests/resource_datadog_monitor_config_policy_test":                      "monitor-config-policies",
 	"tests/resource_datadog_monitor_json_test":                               "monitors-json",
 	"tests/resource_datadog_monitor_test":                                    "monitors",
 	"tests/resource_datadog_organization_settings_test":                      "organization",
+	"tests/resource_datadog_reference_table_test":                            "reference-tables",
 	"tests/resource_datadog_restriction_policy_test":                         "restriction-policy",
 	"tests/resource_datadog_role_test":                                       "roles",
 	"tests/resource_datadog_rum_application_test":                            "rum-application",
@@ -177,10 +190,13 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/resource_datadog_service_level_objective_test":                    "service-level-objectives",
 	"tests/resource_datadog_slo_correction_test":                             "slo_correction",
 	"tests/resource_datadog_spans_metric_test":                               "spans-metric",
+	"tests/resource_datadog_synthetics_ci_run_test":                          "synthetics",
 	"tests/resource_datadog_synthetics_concurrency_cap_test":                 "synthetics",
 	"tests/resource_datadog_synthetics_global_variable_test":                 "synthetics",
 	"tests/resource_datadog_synthetics_private_location_test":                "synthetics",
 	"tests/resource_datadog_synthetics_test_test":                            "synthetics",
+	"tests/resource_datadog_synthetics_test_validation_test":                 "synthetics",
+	"tests/synthetics_local_run_test":                                        "synthetics",
 	"tests/resource_datadog_team_link_test":                                  "team",
 	"tests/resource_datadog_team_membership_test":                            "team",
 	"tests/resource_datadog_team_permission_setting_test":                    "team",
@@ -0,0 +1,526 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+)
+
+const (
+	// Number of random configurations checked for every widget definition
+	widgetRoundTripSamples = 30
+	// Configurations violating the schema constraints are discarded, give up after this many attempts
+	widgetRoundTripMaxAttempts = 200
+	// Nested blocks deeper than this only get their required attributes
+	widgetRoundTripMaxOptionalDepth = 6
+)
+
+// Candidate values tried, in order, for attributes whose validator rejects random values
+var (
+	widgetRoundTripStringCandidates = []string{"1", "10", "1m", "50%", "host", "#ffffff", "2023-01-01T00:00:00Z", "env:prod", "/api/v1/query", "http://example.com/image.png"}
+	widgetRoundTripIntCandidates    = []int{1, 5, 10, 100, 1000, 60000, 1672531200}
+	widgetRoundTripFloatCandidates  = []float64{1, 0.5, 10, 100}
+)
+
+// Attributes of a block which the API treats as mutually exclusive, although the schema does not enforce it.
+// At most one attribute of each group is generated in a given block.
+var widgetRoundTripExclusiveAttributes = [][]string{
+	// A request holds a single kind of query
+	{"q", "apm_query", "apm_stats_query", "audit_query", "log_query", "network_query", "process_query", "rum_query", "security_query", "query", "fill", "size"},
+	// A formula and function query has a single data source
+	{"apm_dependency_stats_query", "apm_resource_stats_query", "cloud_cost_query", "event_query", "metric_query", "process_query", "slo_query"},
+	// The label of a custom link is either the default one or overridden
+	{"label", "override_label"},
+	// A sunburst widget has a single legend
+	{"legend_inline", "legend_table"},
+}
+
+// Attributes of a block which are only sent to the API along with another attribute,
+// optionally only when that attribute has a given value.
+var widgetRoundTripRequiredWith = map[string]struct{ attribute, value string }{
+	"event_size": {"data_source", "event_stream"},
+	"formula":    {"query", ""},
+	"is_hidden":  {"override_label", ""},
+}
+
+// Blocks documented as holding a single nested block, although the schema does not set MaxItems.
+// Only the first block is sent to the API. Keys are the attribute paths, without the list indexes.
+var widgetRoundTripSingleBlocks = []string{
+	".compute",
+	".fill",
+	".size",
+	".x",
+	".y",
+	".scatterplot_table",
+	"topology_map_definition.request.query",
+}
+
+// Formula and function queries only built for the widgets listed, other widgets drop them
+var widgetRoundTripQueryWidgets = map[string]string{
+	"apm_dependency_stats_query": "query_table_definition",
+	"apm_resource_stats_query":   "query_table_definition",
+}
+
+// Values of attributes only accepting a fixed value, although the schema does not validate it
+var widgetRoundTripFixedValues = map[string]string{
+	"metric_query.data_source": "metrics",
+}
+
+// Values accepted by the schema which the API client can't read back, the widget is then left unparsed
+var widgetRoundTripUnreadableValues = map[string][]string{
+	"scatterplot_definition.request.x.aggregator": {"percentile"},
+	"scatterplot_definition.request.y.aggregator": {"percentile"},
+}
+
+// TestDatadogDashboard_widgetRoundTrip generates random valid configurations for every widget definition of
+// the `datadog_dashboard` resource, then checks that building the API payload and reading it back produces
+// the same configuration. Asymmetric build and read functions otherwise surface as permanent diffs in plans.
+// The API is replaced by a local server echoing the created dashboard, so no cassette nor network is needed.
+func TestDatadogDashboard_widgetRoundTrip(t *testing.T) {
+	t.Parallel()
+	res := datadog.Provider().ResourcesMap["datadog_dashboard"]
+	meta := newWidgetRoundTripProviderConfiguration(t)
+
+	widgetSchema := res.SchemaMap()["widget"].Elem.(*schema.Resource).Schema
+	var definitions []string
+	for k := range widgetSchema {
+		if strings.HasSuffix(k, "_definition") {
+			definitions = append(definitions, k)
+		}
+	}
+	sort.Strings(definitions)
+	// A widget, including the ones nested in groups, holds a single definition
+	exclusiveAttributes := append([][]string{definitions}, widgetRoundTripExclusiveAttributes...)
+
+	for i, definition := range definitions {
+		definition := definition
+		seed := int64(i + 1)
+		t.Run(definition, func(t *testing.T) {
+			t.Parallel()
+			gen := &widgetRoundTripGenerator{rand: rand.New(rand.NewSource(seed)), exclusiveAttributes: exclusiveAttributes}
+			for sample := 0; sample < widgetRoundTripSamples; sample++ {
+				raw := gen.dashboardConfig(t, res, widgetSchema, definition)
+
+				d := schema.TestResourceDataRaw(t, res.SchemaMap(), raw)
+				if diags := res.CreateContext(context.Background(), d, meta); diags.HasError() {
+					t.Fatalf("seed %d, sample %d: error creating dashboard: %v\nconfiguration: %s", seed, sample, diags, widgetRoundTripJSON(raw))
+				}
+
+				want := raw["widget"].([]interface{})[0].(map[string]interface{})
+				got := d.Get("widget").([]interface{})[0].(map[string]interface{})
+				for _, diff := range widgetRoundTripDiff(widgetSchema, want, got, "widget.0") {
+					t.Errorf("seed %d, sample %d: %s\nconfiguration: %s", seed, sample, diff, widgetRoundTripJSON(want))
+				}
+				if t.Failed() {
+					return
+				}
+			}
+		})
+	}
+}
+
+type widgetRoundTripGenerator struct {
+	rand                *rand.Rand
+	exclusiveAttributes [][]string
+}
+
+// dashboardConfig returns the configuration of a dashboard holding a single widget of the given definition,
+// retrying until it satisfies the schema validation.
+func (g *widgetRoundTripGenerator) dashboardConfig(t *testing.T, res *schema.Resource, widgetSchema map[string]*schema.Schema, definition string) map[string]interface{} {
+	var lastDiags interface{}
+	for attempt := 0; attempt < widgetRoundTripMaxAttempts; attempt++ {
+		widget := map[string]interface{}{
+			definition: []interface{}{g.resource(t, widgetSchema[definition].Elem.(*schema.Resource).Schema, 1, definition)},
+		}
+		raw := map[string]interface{}{
+			"title":       "Round trip dashboard",
+			"layout_type": "ordered",
+			"widget":      []interface{}{widget},
+		}
+		diags := res.Validate(terraform.NewResourceConfigRaw(raw))
+		if !diags.HasError() {
+			return raw
+		}
+		lastDiags = diags
+	}
+	t.Fatalf("could not generate a valid %s configuration: %v", definition, lastDiags)
+	return nil
+}
+
+func (g *widgetRoundTripGenerator) resource(t *testing.T, s map[string]*schema.Schema, depth int, path string) map[string]interface{} {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	config := make(map[string]interface{})
+	for _, k := range keys {
+		attr := s[k]
+		// Deprecated attributes are kept for backward compatibility and read into their replacement
+		if (attr.Computed && !attr.Optional) || attr.Deprecated != "" {
+			continue
+		}
+		if !attr.Required && (depth > widgetRoundTripMaxOptionalDepth || g.rand.Intn(2) == 0) {
+			continue
+		}
+		if widget, ok := widgetRoundTripQueryWidgets[k]; ok && !strings.Contains(path, widget) {
+			continue
+		}
+		if v, ok := g.value(t, attr, depth, path+"."+k); ok {
+			config[k] = v
+		}
+	}
+	for _, group := range g.exclusiveAttributes {
+		var present []string
+		for _, k := range group {
+			if _, ok := config[k]; ok {
+				present = append(present, k)
+			}
+		}
+		if len(present) > 1 {
+			keep := present[g.rand.Intn(len(present))]
+			for _, k := range present {
+				if k != keep {
+					delete(config, k)
+				}
+			}
+		}
+	}
+	for k, required := range widgetRoundTripRequiredWith {
+		if v, ok := config[required.attribute]; !ok || (required.value != "" && v != required.value) {
+			delete(config, k)
+		}
+	}
+	// Empty blocks are read as nil by the SDK, make sure every block holds at least one attribute
+	if len(config) == 0 {
+		for _, k := range keys {
+			attr := s[k]
+			if _, constrained := widgetRoundTripRequiredWith[k]; constrained || (attr.Computed && !attr.Optional) || attr.Deprecated != "" {
+				continue
+			}
+			if widget, ok := widgetRoundTripQueryWidgets[k]; ok && !strings.Contains(path, widget) {
+				continue
+			}
+			if v, ok := g.value(t, attr, depth, path+"."+k); ok {
+				config[k] = v
+				break
+			}
+		}
+	}
+	return config
+}
+
+func (g *widgetRoundTripGenerator) value(t *testing.T, attr *schema.Schema, depth int, path string) (interface{}, bool) {
+	switch attr.Type {
+	case schema.TypeList, schema.TypeSet:
+		maxItems := 3
+		if attr.MaxItems > 0 && attr.MaxItems < maxItems {
+			maxItems = attr.MaxItems
+		}
+		for _, suffix := range widgetRoundTripSingleBlocks {
+			if strings.HasSuffix(path, suffix) {
+				maxItems = 1
+			}
+		}
+		minItems := 1
+		if attr.MinItems > minItems {
+			minItems = attr.MinItems
+		}
+		n := minItems + g.rand.Intn(maxItems-minItems+1)
+		items := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			switch elem := attr.Elem.(type) {
+			case *schema.Resource:
+				items = append(items, g.resource(t, elem.Schema, depth+1, path))
+			case *schema.Schema:
+				v, ok := g.value(t, elem, depth+1, path)
+				if !ok {
+					return nil, false
+				}
+				// Duplicates collapse in sets
+				if attr.Type == schema.TypeSet && widgetRoundTripContains(items, v) {
+					continue
+				}
+				items = append(items, v)
+			}
+		}
+		return items, true
+	case schema.TypeMap:
+		return map[string]interface{}{g.word(): g.word()}, true
+	case schema.TypeBool:
+		return g.rand.Intn(2) == 0, true
+	case schema.TypeInt:
+		candidates := []interface{}{1 + g.rand.Intn(1000)}
+		for _, c := range widgetRoundTripIntCandidates {
+			candidates = append(candidates, c)
+		}
+		return g.firstValid(attr, candidates)
+	case schema.TypeFloat:
+		// Quarters are exactly represented by floats, so they survive the JSON encoding
+		candidates := []interface{}{float64(1+g.rand.Intn(4000)) / 4}
+		for _, c := range widgetRoundTripFloatCandidates {
+			candidates = append(candidates, c)
+		}
+		return g.firstValid(attr, candidates)
+	case schema.TypeString:
+		for suffix, v := range widgetRoundTripFixedValues {
+			if strings.HasSuffix(path, suffix) {
+				return v, true
+			}
+		}
+		if allowed := widgetRoundTripAllowedValues(attr, widgetRoundTripUnreadableValues[strings.TrimPrefix(path, "group_definition.widget.")]); len(allowed) > 0 {
+			return allowed[g.rand.Intn(len(allowed))], true
+		}
+		candidates := []interface{}{g.word()}
+		for _, c := range widgetRoundTripStringCandidates {
+			candidates = append(candidates, c)
+		}
+		return g.firstValid(attr, candidates)
+	}
+	t.Fatalf("unsupported attribute type %s", attr.Type)
+	return nil, false
+}
+
+func (g *widgetRoundTripGenerator) word() string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, 3+g.rand.Intn(8))
+	for i := range b {
+		b[i] = letters[g.rand.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+func (g *widgetRoundTripGenerator) firstValid(attr *schema.Schema, candidates []interface{}) (interface{}, bool) {
+	for _, c := range candidates {
+		if attr.ValidateDiagFunc != nil && attr.ValidateDiagFunc(c, cty.Path{}).HasError() {
+			continue
+		}
+		if attr.ValidateFunc != nil {
+			if _, errs := attr.ValidateFunc(c, "value"); len(errs) > 0 {
+				continue
+			}
+		}
+		return c, true
+	}
+	return nil, false
+}
+
+// widgetRoundTripAllowedValues returns the values accepted by enum validators, using the same
+// hook as the documentation generation, except the excluded ones.
+func widgetRoundTripAllowedValues(attr *schema.Schema, excluded []string) []string {
+	if attr.ValidateDiagFunc == nil {
+		return nil
+	}
+	var allowed []string
+	for _, d := range attr.ValidateDiagFunc(validators.EnumChecker{}, cty.Path{}) {
+		if d.Summary != "Allowed values" {
+			continue
+		}
+		for _, v := range strings.Split(d.Detail, ", ") {
+			if v = strings.Trim(v, "`"); !slices.Contains(excluded, v) {
+				allowed = append(allowed, v)
+			}
+		}
+	}
+	return allowed
+}
+
+// widgetRoundTripDiff returns the differences between the configured values and the ones read back.
+// Attributes absent from the configuration must be read back as their zero or default value.
+func widgetRoundTripDiff(s map[string]*schema.Schema, want, got map[string]interface{}, path string) []string {
+	var diffs []string
+	for k, attr := range s {
+		attrPath := path + "." + k
+		w, configured := want[k]
+		g := got[k]
+		if attr.Deprecated != "" || (attr.Computed && !attr.Optional) {
+			continue
+		}
+		if !configured {
+			if !widgetRoundTripIsZero(g) && !reflect.DeepEqual(g, attr.Default) && !attr.Computed {
+				diffs = append(diffs, fmt.Sprintf("%s: not configured but read back as %#v", attrPath, widgetRoundTripJSON(g)))
+			}
+			continue
+		}
+
+		switch attr.Type {
+		case schema.TypeList, schema.TypeSet:
+			wantItems := w.([]interface{})
+			gotItems := widgetRoundTripItems(g)
+			if len(wantItems) != len(gotItems) {
+				diffs = append(diffs, fmt.Sprintf("%s: configured %d items but read back %d: %s", attrPath, len(wantItems), len(gotItems), widgetRoundTripJSON(g)))
+				continue
+			}
+			elem, isResource := attr.Elem.(*schema.Resource)
+			if attr.Type == schema.TypeSet {
+				// Set items are matched regardless of their order
+				if !widgetRoundTripSetEqual(attr, wantItems, gotItems) {
+					diffs = append(diffs, fmt.Sprintf("%s: configured %s but read back %s", attrPath, widgetRoundTripJSON(wantItems), widgetRoundTripJSON(gotItems)))
+				}
+				continue
+			}
+			for i := range wantItems {
+				itemPath := fmt.Sprintf("%s.%d", attrPath, i)
+				if isResource {
+					gotItem, _ := gotItems[i].(map[string]interface{})
+					diffs = append(diffs, widgetRoundTripDiff(elem.Schema, wantItems[i].(map[string]interface{}), gotItem, itemPath)...)
+				} else if !widgetRoundTripLeafEqual(wantItems[i], gotItems[i]) {
+					diffs = append(diffs, fmt.Sprintf("%s: configured %#v but read back %#v", itemPath, wantItems[i], gotItems[i]))
+				}
+			}
+		default:
+			if !widgetRoundTripLeafEqual(w, g) {
+				diffs = append(diffs, fmt.Sprintf("%s: configured %#v but read back %#v", attrPath, w, g))
+			}
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+func widgetRoundTripContains(items []interface{}, v interface{}) bool {
+	for _, item := range items {
+		if reflect.DeepEqual(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func widgetRoundTripItems(v interface{}) []interface{} {
+	switch items := v.(type) {
+	case []interface{}:
+		return items
+	case *schema.Set:
+		return items.List()
+	}
+	return nil
+}
+
+func widgetRoundTripSetEqual(attr *schema.Schema, want, got []interface{}) bool {
+	var hash schema.SchemaSetFunc
+	switch elem := attr.Elem.(type) {
+	case *schema.Resource:
+		hash = schema.HashResource(elem)
+	case *schema.Schema:
+		hash = schema.HashSchema(elem)
+	}
+	wantSet := schema.NewSet(hash, want)
+	gotSet := schema.NewSet(hash, got)
+	return wantSet.Equal(gotSet)
+}
+
+func widgetRoundTripLeafEqual(want, got interface{}) bool {
+	// Unset attributes are read as their zero value
+	if widgetRoundTripIsZero(want) && widgetRoundTripIsZero(got) {
+		return true
+	}
+	if m, ok := want.(map[string]interface{}); ok {
+		return reflect.DeepEqual(m, got)
+	}
+	if f, ok := want.(float64); ok {
+		g, ok := got.(float64)
+		return ok && f == g
+	}
+	return reflect.DeepEqual(want, got)
+}
+
+func widgetRoundTripIsZero(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		// Blocks only holding zero values are equivalent to missing blocks
+		for _, item := range value {
+			if !widgetRoundTripIsZero(item) {
+				return false
+			}
+		}
+		return true
+	case *schema.Set:
+		return value.Len() == 0
+	case map[string]interface{}:
+		for _, item := range value {
+			if !widgetRoundTripIsZero(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+func widgetRoundTripJSON(v interface{}) string {
+	if s, ok := v.(*schema.Set); ok {
+		v = s.List()
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// newWidgetRoundTripProviderConfiguration returns a provider configuration pointing to a local server
+// which stores created dashboards and returns them as-is.
+func newWidgetRoundTripProviderConfiguration(t *testing.T) *datadog.ProviderConfiguration {
+	var mu sync.Mutex
+	dashboards := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/dashboard":
+			var dashboard map[string]interface{}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &dashboard); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			id := fmt.Sprintf("rt-%d", len(dashboards))
+			dashboard["id"] = id
+			body, _ = json.Marshal(dashboard)
+			dashboards[id] = body
+			w.Write(body)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/dashboard/"):
+			body, ok := dashboards[strings.TrimPrefix(r.URL.Path, "/api/v1/dashboard/")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(body)
+		default:
+			http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, err := buildContext(context.Background(), "fake-api-key", "fake-app-key", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &datadog.ProviderConfiguration{
+		Auth:                ctx,
+		DatadogApiInstances: &utils.ApiInstances{HttpClient: buildDatadogClient(ctx, server.Client())},
+		Now:                 time.Now,
+	}
+}
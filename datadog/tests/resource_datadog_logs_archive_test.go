@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog"
@@ -210,6 +211,98 @@ func TestAccDatadogLogsArchiveS3_basic(t *testing.T) {
 	})
 }
 
+// create: OK s3 with encryption
+func archiveS3EncryptionConfigForCreation(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_integration_aws" "account" {
+  account_id = "%s"
+  role_name  = "testacc-datadog-integration-role"
+}
+
+resource "datadog_logs_archive" "my_s3_archive" {
+  depends_on = ["datadog_integration_aws.account"]
+  name       = "my encrypted s3 archive"
+  query      = "service:tutu"
+  s3_archive {
+    bucket          = "my-encrypted-bucket"
+    path            = "/path/foo"
+    account_id      = "%s"
+    role_name       = "testacc-datadog-integration-role"
+    encryption_type = "SSE_KMS"
+    encryption_key  = "arn:aws:kms:us-east-1:%s:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+    storage_class   = "STANDARD_IA"
+  }
+}`, uniq, uniq, uniq)
+}
+
+func TestAccDatadogLogsArchiveS3Encryption_basic(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	accountID := uniqueAWSAccountID(ctx, t)
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testAccCheckArchiveAndIntegrationAWSDestroy(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: archiveS3EncryptionConfigForCreation(accountID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckArchiveExists(accProvider),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_archive.my_s3_archive", "s3_archive.0.bucket", "my-encrypted-bucket"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_archive.my_s3_archive", "s3_archive.0.encryption_type", "SSE_KMS"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_archive.my_s3_archive", "s3_archive.0.encryption_key", fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/1234abcd-12ab-34cd-56ef-1234567890ab", accountID)),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_archive.my_s3_archive", "s3_archive.0.storage_class", "STANDARD_IA"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatadogLogsArchiveS3Encryption_missingKey(t *testing.T) {
+	t.Parallel()
+	_, accProviders := testAccProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "datadog_logs_archive" "my_s3_archive" {
+  name  = "my encrypted s3 archive"
+  query = "service:tutu"
+  s3_archive {
+    bucket          = "my-encrypted-bucket"
+    account_id      = "123456789012"
+    role_name       = "testacc-datadog-integration-role"
+    encryption_type = "SSE_KMS"
+  }
+}`,
+				ExpectError: regexp.MustCompile("encryption_key is required when encryption_type is `SSE_KMS`"),
+			},
+			{
+				Config: `
+resource "datadog_logs_archive" "my_s3_archive" {
+  name  = "my s3 archive"
+  query = "service:tutu"
+  s3_archive {
+    bucket     = "My_Bucket"
+    account_id = "123456789012"
+    role_name  = "testacc-datadog-integration-role"
+  }
+}`,
+				ExpectError: regexp.MustCompile("can only contain lowercase letters, numbers, dots and hyphens"),
+			},
+		},
+	})
+}
+
 // update: OK
 func archiveS3ConfigForUpdate(uniq string) string {
 	return fmt.Sprintf(`
@@ -0,0 +1,107 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogLogsCustomPipelineJSON_Basic(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+	uniqUpdated := uniq + "-updated"
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testAccCheckPipelineDestroy(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogLogsCustomPipelineJSON(uniq, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPipelineExists(accProvider),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_custom_pipeline_json.redis", "pipeline", fmt.Sprintf(`{"filter":{"query":"source:redis"},"is_enabled":true,"name":"%s","processors":[{"grok":{"match_rules":"rule %%{word:level} %%{data:msg}","support_rules":""},"is_enabled":true,"name":"Parse redis","source":"message","type":"grok-parser"},{"sources":["level"],"target":"severity","type":"attribute-remapper"},{"filter":{"query":"service:cache"},"is_enabled":true,"name":"nested","processors":[{"is_enabled":true,"name":"urls","sources":["http.url"],"target":"http.url_details","type":"url-parser"}],"type":"pipeline"}]}`, uniq)),
+				),
+			},
+			{
+				Config: testAccCheckDatadogLogsCustomPipelineJSON(uniqUpdated, `["INFO ready to accept connections"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPipelineExists(accProvider),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_custom_pipeline_json.redis", "pipeline", fmt.Sprintf(`{"filter":{"query":"source:redis"},"is_enabled":true,"name":"%s","processors":[{"grok":{"match_rules":"rule %%{word:level} %%{data:msg}","support_rules":""},"is_enabled":true,"name":"Parse redis","samples":["INFO ready to accept connections"],"source":"message","type":"grok-parser"},{"sources":["level"],"target":"severity","type":"attribute-remapper"},{"filter":{"query":"service:cache"},"is_enabled":true,"name":"nested","processors":[{"is_enabled":true,"name":"urls","sources":["http.url"],"target":"http.url_details","type":"url-parser"}],"type":"pipeline"}]}`, uniqUpdated)),
+				),
+			},
+			{
+				ResourceName:      "datadog_logs_custom_pipeline_json.redis",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// The definition is formatted as exported from the Datadog UI, with computed fields and default values
+func testAccCheckDatadogLogsCustomPipelineJSON(uniq string, samples string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_custom_pipeline_json" "redis" {
+  pipeline = <<-EOF
+{
+    "id": "aBcDeFgHiJkLmNoPqRsTuV",
+    "type": "pipeline",
+    "name": "%s",
+    "is_enabled": true,
+    "is_read_only": false,
+    "filter": {
+        "query": "source:redis"
+    },
+    "processors": [
+        {
+            "type": "grok-parser",
+            "name": "Parse redis",
+            "is_enabled": true,
+            "source": "message",
+            "samples": %s,
+            "grok": {
+                "support_rules": "",
+                "match_rules": "rule %%%%{word:level} %%%%{data:msg}"
+            }
+        },
+        {
+            "type": "attribute-remapper",
+            "name": "",
+            "is_enabled": false,
+            "sources": ["level"],
+            "source_type": "attribute",
+            "target": "severity",
+            "target_type": "attribute",
+            "preserve_source": false,
+            "override_on_conflict": false
+        },
+        {
+            "type": "pipeline",
+            "name": "nested",
+            "is_enabled": true,
+            "filter": {
+                "query": "service:cache"
+            },
+            "processors": [
+                {
+                    "type": "url-parser",
+                    "name": "urls",
+                    "is_enabled": true,
+                    "sources": ["http.url"],
+                    "target": "http.url_details",
+                    "normalize_ending_slashes": false
+                }
+            ]
+        }
+    ]
+}
+EOF
+}`, uniq, samples)
+}
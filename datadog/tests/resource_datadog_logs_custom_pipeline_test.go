@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -411,6 +412,208 @@ func TestAccDatadogLogsPipelineEmptyFilterQuery(t *testing.T) {
 	})
 }
 
+func pipelineConfigWithGrokParser(uniq string, matchRules string, supportRules string, samples string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_custom_pipeline" "grok_pipeline" {
+	name = "%s"
+	is_enabled = true
+	filter {
+		query = "source:nginx"
+	}
+	processor {
+		pipeline {
+			name       = "nested"
+			is_enabled = true
+			filter {
+				query = "service:web"
+			}
+			processor {
+				grok_parser {
+					name       = "access logs"
+					is_enabled = true
+					source     = "message"
+					samples    = %s
+					grok {
+						match_rules   = %q
+						support_rules = %q
+					}
+				}
+			}
+		}
+	}
+}`, uniq, samples, matchRules, supportRules)
+}
+
+func TestAccDatadogLogsPipeline_GrokValidation(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	pipelineName := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: pipelineConfigWithGrokParser(pipelineName,
+					`access %%{_client_ip} %%{word:http.method} %%{notSpace:http.url} %%{integer:http.status_code}`,
+					`_client_ip %%{ipOrHost:network.client.ip}`,
+					`["10.0.0.1 GET /checkout 200"]`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      pipelineConfigWithGrokParser(pipelineName, `%%{word:http.method}`, ``, `[]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("processor.0: grok_parser \"access logs\": match rules: line 1 must be formatted as `name pattern`"),
+			},
+			{
+				Config:      pipelineConfigWithGrokParser(pipelineName, `access %%{_client_ip} %%{word:http.method}`, ``, `[]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`unknown matcher or support rule "_client_ip"`),
+			},
+			{
+				Config:      pipelineConfigWithGrokParser(pipelineName, `access %%{integer:duration:seconds}`, ``, `[]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`unknown filter "seconds"`),
+			},
+			{
+				Config:      pipelineConfigWithGrokParser(pipelineName, `access %%{date("yyyy-MM-dd", "Europe/Nowhere"):date}`, ``, `[]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`unknown timezone "Europe/Nowhere"`),
+			},
+			{
+				Config: pipelineConfigWithGrokParser(pipelineName,
+					`access %%{_client_ip} %%{word:http.method} %%{notSpace:http.url} %%{integer:http.status_code}`,
+					`_client_ip %%{ipOrHost:network.client.ip}`,
+					`["10.0.0.1 GET /checkout 200", "10.0.0.1 GET /checkout OK"]`),
+				// Samples which don't match are only reported with a warning
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func pipelineConfigWithNewProcessors(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_custom_pipeline" "new_processors" {
+	name = "%s"
+	is_enabled = true
+	filter {
+		query = "source:auth"
+	}
+	processor {
+		span_id_remapper {
+			name       = "span id"
+			is_enabled = true
+			sources    = ["dd.span_id"]
+		}
+	}
+	processor {
+		array_processor {
+			name       = "referrer"
+			is_enabled = true
+			operation {
+				select {
+					source           = "http.headers"
+					target           = "http.referrer"
+					filter           = "name:Referrer"
+					value_to_extract = "value"
+				}
+			}
+		}
+	}
+	processor {
+		array_processor {
+			name       = "tags count"
+			is_enabled = true
+			operation {
+				length {
+					source = "tags"
+					target = "tags_count"
+				}
+			}
+		}
+	}
+	processor {
+		array_processor {
+			name = "collect users"
+			operation {
+				append {
+					source          = "usr.id"
+					target          = "user_ids"
+					preserve_source = false
+				}
+			}
+		}
+	}
+	processor {
+		decoder_processor {
+			name                    = "payload"
+			is_enabled              = true
+			source                  = "payload"
+			target                  = "payload_decoded"
+			binary_to_text_encoding = "base64"
+			input_representation    = "utf_8"
+		}
+	}
+	processor {
+		raw_processor {
+			definition = jsonencode({
+				type       = "schema-processor"
+				name       = "ocsf"
+				is_enabled = true
+				schema = {
+					schema_type = "ocsf"
+					version     = "1.5.0"
+					class_uid   = 3002
+					class_name  = "Authentication"
+				}
+				mappers = []
+			})
+		}
+	}
+}`, uniq)
+}
+
+func TestAccDatadogLogsPipeline_NewProcessors(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	pipelineName := uniqueEntityName(ctx, t)
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testAccCheckPipelineDestroy(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: pipelineConfigWithNewProcessors(pipelineName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPipelineExists(accProvider),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_custom_pipeline.new_processors", "processor.0.span_id_remapper.0.sources.0", "dd.span_id"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_custom_pipeline.new_processors", "processor.1.array_processor.0.operation.0.select.0.filter", "name:Referrer"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_custom_pipeline.new_processors", "processor.2.array_processor.0.operation.0.length.0.target", "tags_count"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_custom_pipeline.new_processors", "processor.3.array_processor.0.operation.0.append.0.preserve_source", "false"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_custom_pipeline.new_processors", "processor.4.decoder_processor.0.binary_to_text_encoding", "base64"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_custom_pipeline.new_processors", "processor.5.raw_processor.0.definition", `{"is_enabled":true,"mappers":[],"name":"ocsf","schema":{"class_name":"Authentication","class_uid":3002,"schema_type":"ocsf","version":"1.5.0"},"type":"schema-processor"}`),
+				),
+			},
+			{
+				ResourceName:      "datadog_logs_custom_pipeline.new_processors",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckPipelineExists(accProvider func() (*schema.Provider, error)) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		provider, _ := accProvider()
@@ -427,7 +630,7 @@ func testAccCheckPipelineExists(accProvider func() (*schema.Provider, error)) re
 
 func pipelineExistsChecker(ctx context.Context, s *terraform.State, apiInstances *utils.ApiInstances) error {
 	for _, r := range s.RootModule().Resources {
-		if r.Type == "datadog_logs_custom_pipeline" {
+		if r.Type == "datadog_logs_custom_pipeline" || r.Type == "datadog_logs_custom_pipeline_json" {
 			id := r.Primary.ID
 			if _, _, err := apiInstances.GetLogsPipelinesApiV1().GetLogsPipeline(ctx, id); err != nil {
 				return fmt.Errorf("received an error when retrieving pipeline, (%s)", err)
@@ -453,7 +656,7 @@ func testAccCheckPipelineDestroy(accProvider func() (*schema.Provider, error)) f
 
 func pipelineDestroyHelper(ctx context.Context, s *terraform.State, apiInstances *utils.ApiInstances) error {
 	for _, r := range s.RootModule().Resources {
-		if r.Type == "datadog_logs_custom_pipeline" {
+		if r.Type == "datadog_logs_custom_pipeline" || r.Type == "datadog_logs_custom_pipeline_json" {
 			err := utils.Retry(2, 5, func() error {
 				id := r.Primary.ID
 				_, _, err := apiInstances.GetLogsPipelinesApiV1().
@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/terraform-providers/terraform-provider-datadog/datadog"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -71,6 +76,132 @@ func TestAccDatadogLogsIndex_Basic(t *testing.T) {
 	})
 }
 
+// The indexes aren't listed in a datadog_logs_index_order, which would have to list all the indexes of the organization
+func TestAccDatadogLogsIndex_DeletionPolicy(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	uniq := strings.ToLower(strings.ReplaceAll(uniqueEntityName(ctx, t), "_", "-"))
+	retained := uniq + "-retained"
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDatadogLogsIndexDeleted(accProvider, uniq),
+			testAccCheckDatadogLogsIndexRetained(accProvider, retained),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogLogsIndexDeletionPolicyConfig(uniq, retained),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("datadog_logs_index.deleted_index", "name", uniq),
+					resource.TestCheckResourceAttr("datadog_logs_index.deleted_index", "deletion_policy", "delete"),
+					resource.TestCheckResourceAttr("datadog_logs_index.retained_index", "name", retained),
+					resource.TestCheckResourceAttr("datadog_logs_index.retained_index", "deletion_policy", "retain"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogLogsIndexDeleted(accProvider func() (*schema.Provider, error), name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		provider, _ := accProvider()
+		providerConf := provider.Meta().(*datadog.ProviderConfiguration)
+		apiInstances := providerConf.DatadogApiInstances
+		auth := providerConf.Auth
+
+		_, httpResp, err := apiInstances.GetLogsIndexesApiV1().GetLogsIndex(auth, name)
+		if err == nil {
+			return fmt.Errorf("logs index %s still exists", name)
+		}
+		if httpResp == nil || httpResp.StatusCode != 404 {
+			return fmt.Errorf("received an error when retrieving logs index %s: %s", name, err)
+		}
+		return nil
+	}
+}
+
+// testAccCheckDatadogLogsIndexRetained checks that a retained index still exists, then deletes it
+func testAccCheckDatadogLogsIndexRetained(accProvider func() (*schema.Provider, error), name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		provider, _ := accProvider()
+		providerConf := provider.Meta().(*datadog.ProviderConfiguration)
+		apiInstances := providerConf.DatadogApiInstances
+		auth := providerConf.Auth
+
+		if _, _, err := apiInstances.GetLogsIndexesApiV1().GetLogsIndex(auth, name); err != nil {
+			return fmt.Errorf("received an error when retrieving retained logs index %s: %s", name, err)
+		}
+		if _, _, err := utils.SendRequest(auth, apiInstances.HttpClient, "DELETE", "/api/v1/logs/config/indexes/"+name, nil); err != nil {
+			return fmt.Errorf("received an error when deleting retained logs index %s: %s", name, err)
+		}
+		return nil
+	}
+}
+
+func TestAccDatadogLogsIndex_InvalidQuery(t *testing.T) {
+	t.Parallel()
+	_, accProviders := testAccProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "datadog_logs_index" "sample_index" {
+  name = "invalid-query"
+  filter {
+    query = "service:(payments OR billing"
+  }
+}`,
+				ExpectError: regexp.MustCompile("is not a valid log search query: unclosed `\\(` at position 8"),
+			},
+			{
+				Config: `
+resource "datadog_logs_index" "sample_index" {
+  name = "invalid-query"
+  filter {
+    query = "service:payments"
+  }
+  exclusion_filter {
+    name = "Exclude debug logs"
+    filter {
+      query       = "status:debug AND"
+      sample_rate = 1.0
+    }
+  }
+}`,
+				ExpectError: regexp.MustCompile("is not a valid log search query: missing operand after `AND` at position 13"),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogLogsIndexDeletionPolicyConfig(name string, retained string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_index" "deleted_index" {
+  name            = "%s"
+  retention_days  = 15
+  deletion_policy = "delete"
+  filter {
+    query = "source:test"
+  }
+}
+
+resource "datadog_logs_index" "retained_index" {
+  name            = "%s"
+  retention_days  = 15
+  deletion_policy = "retain"
+  filter {
+    query = "source:retained"
+  }
+}
+`, name, retained)
+}
+
 func sleep() resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if isReplaying() {
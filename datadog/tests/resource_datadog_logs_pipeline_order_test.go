@@ -0,0 +1,102 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// Integration pipelines aren't listed, the relative order of the listed pipelines is enforced around them
+func pipelineOrderRelativeConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_custom_pipeline" "a" {
+	name       = "%[1]s-a"
+	is_enabled = true
+	filter {
+		query = "source:%[1]s-a"
+	}
+}
+
+resource "datadog_logs_custom_pipeline" "b" {
+	name       = "%[1]s-b"
+	is_enabled = true
+	filter {
+		query = "source:%[1]s-b"
+	}
+}
+
+resource "datadog_logs_pipeline_order" "pipelines" {
+	name      = "pipelines"
+	mode      = "relative"
+	pipelines = [
+		datadog_logs_custom_pipeline.b.id,
+		datadog_logs_custom_pipeline.a.id,
+	]
+}`, uniq)
+}
+
+func TestAccDatadogLogsPipelineOrder_Relative(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testAccCheckPipelineDestroy(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: pipelineOrderRelativeConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPipelineExists(accProvider),
+					resource.TestCheckResourceAttr("datadog_logs_pipeline_order.pipelines", "mode", "relative"),
+					resource.TestCheckResourceAttr("datadog_logs_pipeline_order.pipelines", "pipelines.#", "2"),
+					resource.TestCheckResourceAttrPair("datadog_logs_pipeline_order.pipelines", "pipelines.0", "datadog_logs_custom_pipeline.b", "id"),
+					resource.TestCheckResourceAttrPair("datadog_logs_pipeline_order.pipelines", "pipelines.1", "datadog_logs_custom_pipeline.a", "id"),
+					testAccCheckPipelineOrderedBefore(accProvider, "datadog_logs_custom_pipeline.b", "datadog_logs_custom_pipeline.a"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckPipelineOrderedBefore checks that the first pipeline is processed before the second one
+func testAccCheckPipelineOrderedBefore(accProvider func() (*schema.Provider, error), first string, second string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		provider, _ := accProvider()
+		providerConf := provider.Meta().(*datadog.ProviderConfiguration)
+		apiInstances := providerConf.DatadogApiInstances
+		auth := providerConf.Auth
+
+		order, _, err := apiInstances.GetLogsPipelinesApiV1().GetLogsPipelineOrder(auth)
+		if err != nil {
+			return fmt.Errorf("received an error when retrieving the pipeline order, (%s)", err)
+		}
+		positions := make(map[string]int)
+		for i, id := range order.GetPipelineIds() {
+			positions[id] = i
+		}
+		var ids []string
+		for _, name := range []string{first, second} {
+			r, ok := s.RootModule().Resources[name]
+			if !ok {
+				return fmt.Errorf("resource %s not found", name)
+			}
+			if _, ok := positions[r.Primary.ID]; !ok {
+				return fmt.Errorf("pipeline %s isn't in the pipeline order", r.Primary.ID)
+			}
+			ids = append(ids, r.Primary.ID)
+		}
+		if positions[ids[0]] > positions[ids[1]] {
+			return fmt.Errorf("expected pipeline %s to be before %s, got positions %d and %d", ids[0], ids[1], positions[ids[0]], positions[ids[1]])
+		}
+		return nil
+	}
+}
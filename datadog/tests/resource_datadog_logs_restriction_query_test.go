@@ -0,0 +1,119 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDatadogLogsRestrictionQuery_Basic(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testAccCheckDatadogLogsRestrictionQueryDestroy(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogLogsRestrictionQueryConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogLogsRestrictionQueryExists(accProvider),
+					resource.TestCheckResourceAttr("datadog_logs_restriction_query.payments", "restriction_query", "service:payments"),
+					resource.TestCheckResourceAttrPair("datadog_logs_restriction_query_role.payments", "restriction_query_id", "datadog_logs_restriction_query.payments", "id"),
+					resource.TestCheckResourceAttrPair("datadog_logs_restriction_query_role.payments", "role_id", "datadog_role.payments", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatadogLogsRestrictionQuery_InvalidQuery(t *testing.T) {
+	t.Parallel()
+	_, accProviders := testAccProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "datadog_logs_restriction_query" "payments" {
+  restriction_query = "service:(payments OR billing"
+}`,
+				ExpectError: regexp.MustCompile("is not a valid log search query: unclosed `\\(` at position 8"),
+			},
+			{
+				Config: `
+resource "datadog_logs_restriction_query" "payments" {
+  restriction_query = "service:payments error"
+}`,
+				ExpectError: regexp.MustCompile(`got full-text search term "error"`),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogLogsRestrictionQueryConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_role" "payments" {
+  name = "%s"
+}
+
+resource "datadog_logs_restriction_query" "payments" {
+  restriction_query = "service:payments"
+}
+
+resource "datadog_logs_restriction_query_role" "payments" {
+  restriction_query_id = datadog_logs_restriction_query.payments.id
+  role_id              = datadog_role.payments.id
+}`, uniq)
+}
+
+func testAccCheckDatadogLogsRestrictionQueryExists(accProvider func() (*schema.Provider, error)) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		provider, _ := accProvider()
+		providerConf := provider.Meta().(*datadog.ProviderConfiguration)
+		httpClient := providerConf.DatadogApiInstances.HttpClient
+		auth := providerConf.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_logs_restriction_query" {
+				continue
+			}
+			if _, _, err := utils.SendRequest(auth, httpClient, "GET", "/api/v2/logs/config/restriction_queries/"+r.Primary.ID, nil); err != nil {
+				return fmt.Errorf("received an error retrieving logs restriction query %s", err)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogLogsRestrictionQueryDestroy(accProvider func() (*schema.Provider, error)) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		provider, _ := accProvider()
+		providerConf := provider.Meta().(*datadog.ProviderConfiguration)
+		httpClient := providerConf.DatadogApiInstances.HttpClient
+		auth := providerConf.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_logs_restriction_query" {
+				continue
+			}
+			if _, httpResp, err := utils.SendRequest(auth, httpClient, "GET", "/api/v2/logs/config/restriction_queries/"+r.Primary.ID, nil); err == nil || httpResp == nil || httpResp.StatusCode != 404 {
+				return fmt.Errorf("logs restriction query %s still exists", r.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,220 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const referenceTableCSV = `asset_id,owner,priority
+i-0a1b2c,payments,1
+i-3d4e5f,search,2
+`
+
+func TestAccDatadogReferenceTable_LocalFile(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	uniq := strings.ToLower(strings.ReplaceAll(uniqueEntityName(ctx, t), "-", "_"))
+	accProvider := testAccProvider(t, accProviders)
+	path := writeReferenceTableFile(t, referenceTableCSV)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testAccCheckDatadogReferenceTableDestroy(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogReferenceTableLocalFile(uniq, path),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogReferenceTableExists(accProvider),
+					resource.TestCheckResourceAttr("datadog_reference_table.assets", "table_name", uniq),
+					resource.TestCheckResourceAttr("datadog_reference_table.assets", "description", "Owners of the assets"),
+					resource.TestCheckResourceAttr("datadog_reference_table.assets", "schema.0.primary_key", "asset_id"),
+					resource.TestCheckResourceAttr("datadog_reference_table.assets", "schema.0.field.#", "3"),
+					resource.TestCheckResourceAttr("datadog_reference_table.assets", "schema.0.field.2.type", "INT32"),
+					resource.TestCheckResourceAttr("datadog_reference_table.assets", "local_file_sha256", "a7ae94c8425e472336ba057e37312f5ba3e034f564cda6944bdd3ee149bebe37"),
+					resource.TestCheckResourceAttr("datadog_reference_table.assets", "row_count", "2"),
+					resource.TestCheckResourceAttr("datadog_reference_table.assets", "status", "DONE"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatadogReferenceTable_InvalidFile(t *testing.T) {
+	t.Parallel()
+	_, accProviders := testAccProviders(context.Background(), t)
+	missingColumn := writeReferenceTableFile(t, "asset_id,owner\ni-0a1b2c,payments\n")
+	duplicateKey := writeReferenceTableFile(t, "asset_id,owner,priority\ni-0a1b2c,payments,1\ni-0a1b2c,search,2\n")
+	invalidInteger := writeReferenceTableFile(t, "asset_id,owner,priority\ni-0a1b2c,payments,high\n")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckDatadogReferenceTableLocalFile("invalid_assets", missingColumn),
+				ExpectError: regexp.MustCompile("columns priority of the schema are missing from the header"),
+			},
+			{
+				Config:      testAccCheckDatadogReferenceTableLocalFile("invalid_assets", duplicateKey),
+				ExpectError: regexp.MustCompile(`primary key "i-0a1b2c" already used on line 2`),
+			},
+			{
+				Config:      testAccCheckDatadogReferenceTableLocalFile("invalid_assets", invalidInteger),
+				ExpectError: regexp.MustCompile(`column "priority" must be a 32-bit integer, got "high"`),
+			},
+		},
+	})
+}
+
+func TestAccDatadogReferenceTable_PipelineColumns(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	uniq := strings.ToLower(strings.ReplaceAll(uniqueEntityName(ctx, t), "-", "_"))
+	accProvider := testAccProvider(t, accProviders)
+	path := writeReferenceTableFile(t, referenceTableCSV)
+	tableConfig := testAccCheckDatadogReferenceTableLocalFile(uniq, path)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDatadogReferenceTableDestroy(accProvider),
+			testAccCheckPipelineDestroy(accProvider),
+		),
+		Steps: []resource.TestStep{
+			{
+				// The columns of the table are only known once it exists
+				Config: tableConfig,
+				Check:  testAccCheckDatadogReferenceTableExists(accProvider),
+			},
+			{
+				Config:      tableConfig + testAccCheckDatadogReferenceTablePipeline(uniq, "asset.region"),
+				ExpectError: regexp.MustCompile(`"region" isn't a column of reference table "` + uniq + `"`),
+			},
+			{
+				Config: tableConfig + testAccCheckDatadogReferenceTablePipeline(uniq, "asset.owner"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPipelineExists(accProvider),
+					resource.TestCheckResourceAttrPair("datadog_logs_custom_pipeline.assets", "processor.0.reference_table_lookup_processor.0.lookup_enrichment_table", "datadog_reference_table.assets", "table_name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogReferenceTablePipeline(name, attribute string) string {
+	return fmt.Sprintf(`
+
+resource "datadog_logs_custom_pipeline" "assets" {
+  name       = "%s"
+  is_enabled = true
+  filter {
+    query = "source:cloudtrail"
+  }
+  processor {
+    reference_table_lookup_processor {
+      name                    = "Asset lookup"
+      is_enabled              = true
+      source                  = "instance.id"
+      target                  = "asset"
+      lookup_enrichment_table = datadog_reference_table.assets.table_name
+    }
+  }
+  processor {
+    attribute_remapper {
+      name                 = "Asset attribute"
+      is_enabled           = true
+      sources              = ["%s"]
+      source_type          = "attribute"
+      target               = "asset_attribute"
+      target_type          = "tag"
+      preserve_source      = true
+      override_on_conflict = false
+    }
+  }
+}`, name, attribute)
+}
+
+func testAccCheckDatadogReferenceTableLocalFile(name, path string) string {
+	return fmt.Sprintf(`
+resource "datadog_reference_table" "assets" {
+  table_name  = "%s"
+  description = "Owners of the assets"
+  tags        = ["team:security"]
+  schema {
+    primary_key = "asset_id"
+    field {
+      name = "asset_id"
+    }
+    field {
+      name = "owner"
+    }
+    field {
+      name = "priority"
+      type = "INT32"
+    }
+  }
+  local_file {
+    path = "%s"
+  }
+}`, name, path)
+}
+
+func writeReferenceTableFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "assets.csv")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testAccCheckDatadogReferenceTableExists(accProvider func() (*schema.Provider, error)) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		provider, _ := accProvider()
+		providerConf := provider.Meta().(*datadog.ProviderConfiguration)
+		httpClient := providerConf.DatadogApiInstances.HttpClient
+		auth := providerConf.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_reference_table" {
+				continue
+			}
+			if _, _, err := utils.SendRequest(auth, httpClient, "GET", "/api/v2/reference-tables/tables/"+r.Primary.ID, nil); err != nil {
+				return fmt.Errorf("received an error retrieving reference table %s", err)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogReferenceTableDestroy(accProvider func() (*schema.Provider, error)) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		provider, _ := accProvider()
+		providerConf := provider.Meta().(*datadog.ProviderConfiguration)
+		httpClient := providerConf.DatadogApiInstances.HttpClient
+		auth := providerConf.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_reference_table" {
+				continue
+			}
+			if _, httpResp, err := utils.SendRequest(auth, httpClient, "GET", "/api/v2/reference-tables/tables/"+r.Primary.ID, nil); err == nil || httpResp == nil || httpResp.StatusCode != 404 {
+				return fmt.Errorf("reference table %s still exists", r.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
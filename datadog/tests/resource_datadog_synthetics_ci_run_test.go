@@ -0,0 +1,79 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsCIRun_Basic(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	testName := uniqueEntityName(ctx, t)
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testSyntheticsTestIsDestroyed(accProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatadogSyntheticsCIRunConfig(testName, "1", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("datadog_synthetics_ci_run.deploy", "id"),
+					resource.TestCheckResourceAttrPair("datadog_synthetics_ci_run.deploy", "batch_id", "datadog_synthetics_ci_run.deploy", "id"),
+					resource.TestCheckResourceAttr("datadog_synthetics_ci_run.deploy", "status", "passed"),
+					resource.TestCheckResourceAttr("datadog_synthetics_ci_run.deploy", "result.#", "1"),
+					resource.TestCheckResourceAttrPair("datadog_synthetics_ci_run.deploy", "result.0.public_id", "datadog_synthetics_test.deploy", "id"),
+					resource.TestCheckResourceAttrPair("datadog_synthetics_ci_run.deploy", "result.0.test_name", "datadog_synthetics_test.deploy", "name"),
+					resource.TestCheckResourceAttr("datadog_synthetics_ci_run.deploy", "result.0.location", "aws:eu-central-1"),
+					resource.TestCheckResourceAttrSet("datadog_synthetics_ci_run.deploy", "result.0.result_id"),
+					resource.TestCheckResourceAttr("datadog_synthetics_ci_run.deploy", "result.0.status", "passed"),
+					resource.TestCheckResourceAttr("datadog_synthetics_ci_run.deploy", "result.0.execution_rule", "blocking"),
+					resource.TestCheckResourceAttrSet("datadog_synthetics_ci_run.deploy", "result.0.duration"),
+				),
+			},
+			{
+				// Changing the triggers runs the test again, and the failed blocking test fails the apply
+				Config:      testAccDatadogSyntheticsCIRunConfig(testName, "2", `start_url = "https://www.datadoghq.com/does-not-exist"`),
+				ExpectError: regexp.MustCompile(`synthetics tests of batch \S+ failed:\s+\S.* \([a-z0-9-]+\) from aws:eu-central-1, result \S+`),
+			},
+		},
+	})
+}
+
+func testAccDatadogSyntheticsCIRunConfig(uniq string, version string, extra string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test" "deploy" {
+  name      = "%s"
+  type      = "api"
+  subtype   = "http"
+  status    = "live"
+  locations = ["aws:eu-central-1"]
+  tags      = ["foo:bar"]
+  request_definition {
+    method = "GET"
+    url    = "https://www.datadoghq.com"
+  }
+  assertion {
+    type     = "statusCode"
+    operator = "is"
+    target   = "200"
+  }
+  options_list {
+    tick_every = 900
+  }
+}
+
+resource "datadog_synthetics_ci_run" "deploy" {
+  public_ids = [datadog_synthetics_test.deploy.id]
+  locations  = ["aws:eu-central-1"]
+  triggers = {
+    version = "%s"
+  }
+  %s
+}`, uniq, version, extra)
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -133,6 +134,63 @@ func TestAccDatadogSyntheticsGlobalVariableFromTest_LocalVariable(t *testing.T)
 	})
 }
 
+func TestAccDatadogSyntheticsGlobalVariableTOTP_Basic(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testSyntheticsResourceIsDestroyed(accProvider),
+		Steps: []resource.TestStep{
+			createSyntheticsGlobalVariableTOTPStep(ctx, accProvider, t),
+		},
+	})
+}
+
+func TestAccDatadogSyntheticsGlobalVariable_Validation(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	variableName := getUniqueVariableName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      createSyntheticsGlobalVariableValidationConfig(variableName, `value = "not a base32 secret!"`, `is_totp = true`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("TOTP secret must be base32 encoded"),
+			},
+			{
+				Config: createSyntheticsGlobalVariableValidationConfig(variableName, `value = ""`, `parse_test_id = "abc-def-ghi"
+	parse_test_options {
+		type = "local_variable"
+		local_variable_name = "EXTRACTED_TOKEN"
+		parser {
+			type = "raw"
+		}
+	}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("can't be set when type is `local_variable`"),
+			},
+			{
+				Config: createSyntheticsGlobalVariableValidationConfig(variableName, `value = ""`, `parse_test_id = "abc-def-ghi"
+	parse_test_options {
+		type = "http_body"
+		parser {
+			type = "json_path"
+			value = "$.items["
+		}
+	}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("invalid JSON path"),
+			},
+		},
+	})
+}
+
 func createSyntheticsGlobalVariableStep(ctx context.Context, accProvider func() (*schema.Provider, error), t *testing.T) resource.TestStep {
 	variableName := getUniqueVariableName(ctx, t)
 	roleName := uniqueEntityName(ctx, t)
@@ -299,6 +357,56 @@ resource "datadog_synthetics_global_variable" "foo" {
 }`, uniq)
 }
 
+func createSyntheticsGlobalVariableTOTPStep(ctx context.Context, accProvider func() (*schema.Provider, error), t *testing.T) resource.TestStep {
+	variableName := getUniqueVariableName(ctx, t)
+	return resource.TestStep{
+		Config: createSyntheticsGlobalVariableTOTPConfig(variableName),
+		Check: resource.ComposeTestCheckFunc(
+			testSyntheticsResourceExists(accProvider),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_global_variable.foo", "name", variableName),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_global_variable.foo", "is_totp", "true"),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_global_variable.foo", "value", "jbsw y3dp ehpk 3pxp"),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_global_variable.foo", "secure", "true"),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_global_variable.foo", "options.0.totp_parameters.0.digits", "8"),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_global_variable.foo", "options.0.totp_parameters.0.refresh_interval", "60"),
+		),
+	}
+}
+
+func createSyntheticsGlobalVariableTOTPConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_global_variable" "foo" {
+	name = "%s"
+	description = "a TOTP global variable"
+	tags = ["foo:bar", "baz"]
+	value = "jbsw y3dp ehpk 3pxp"
+	secure = true
+	is_totp = true
+	options {
+		totp_parameters {
+			digits = 8
+			refresh_interval = 60
+		}
+	}
+}`, uniq)
+}
+
+func createSyntheticsGlobalVariableValidationConfig(uniq string, value string, source string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_global_variable" "foo" {
+	name = "%s"
+	description = "an invalid global variable"
+	%s
+	%s
+}`, uniq, value, source)
+}
+
 func createSyntheticsGlobalVariableFromTestStep(ctx context.Context, accProvider func() (*schema.Provider, error), t *testing.T) resource.TestStep {
 	variableName := getUniqueVariableName(ctx, t)
 	return resource.TestStep{
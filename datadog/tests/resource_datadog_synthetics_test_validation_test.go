@@ -0,0 +1,115 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsAPITest_Validation(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	testName := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: createSyntheticsAPITestValidationConfig(testName, `
+  assertion {
+    type     = "body"
+    operator = "validatesJSONPath"
+    targetjsonpath {
+      operator    = "is"
+      jsonpath    = "$.items["
+      targetvalue = "1"
+    }
+  }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`assertion.0: invalid JSON path "\$.items\["`),
+			},
+			{
+				Config: createSyntheticsAPITestValidationConfig(testName, `
+  assertion {
+    type     = "statusCode"
+    operator = "is"
+    target   = "OK"
+  }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`assertion.0: target "OK" must be an integer for statusCode assertions`),
+			},
+			{
+				Config:      createSyntheticsMultistepValidationConfig(testName, "$.token", "{{ SESSION }}"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`api_step.1 \(me\): variable SESSION is neither a config_variable nor extracted by a previous step`),
+			},
+			{
+				Config:      createSyntheticsMultistepValidationConfig(testName, "$.token[", "{{ USER }}"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`api_step.0 \(login\) extracted_value.0: invalid JSON path "\$.token\["`),
+			},
+		},
+	})
+}
+
+func createSyntheticsAPITestValidationConfig(uniq string, assertion string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test" "foo" {
+  name      = "%s"
+  type      = "api"
+  status    = "paused"
+  locations = ["aws:eu-central-1"]
+  request_definition {
+    method = "GET"
+    url    = "https://www.example.org"
+  }%s
+}`, uniq, assertion)
+}
+
+func createSyntheticsMultistepValidationConfig(uniq string, tokenPath string, session string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test" "multi" {
+  name      = "%s"
+  type      = "api"
+  subtype   = "multi"
+  status    = "paused"
+  locations = ["aws:eu-central-1"]
+  config_variable {
+    name    = "USER"
+    type    = "text"
+    pattern = "user"
+  }
+  api_step {
+    name    = "login"
+    subtype = "http"
+    request_definition {
+      method = "POST"
+      url    = "https://api.example.com/login?user={{ USER }}"
+    }
+    extracted_value {
+      name = "TOKEN"
+      type = "http_body"
+      parser {
+        type  = "json_path"
+        value = "%s"
+      }
+    }
+  }
+  api_step {
+    name    = "me"
+    subtype = "http"
+    request_definition {
+      method = "GET"
+      url    = "https://api.example.com/me"
+    }
+    request_headers = {
+      Authorization = "Bearer {{ TOKEN }}"
+      "X-Session"   = "%s"
+    }
+  }
+}`, uniq, tokenPath, session)
+}
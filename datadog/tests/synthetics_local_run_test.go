@@ -0,0 +1,469 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog"
+)
+
+func TestSyntheticsLocalRun_http(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status" || r.URL.Query().Get("env") != "prod" || !regexp.MustCompile(`^\d{6}$`).MatchString(r.Header.Get("X-Request-Id")) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if user, password, _ := r.BasicAuth(); user != "admin" || password != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-Version", "2")
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "items": []interface{}{map[string]interface{}{"id": 1}}})
+	}))
+	defer server.Close()
+
+	test := loadSyntheticsLocalTest(t, `
+resource "datadog_synthetics_test" "api" {
+  name      = "local http"
+  type      = "api"
+  subtype   = "http"
+  status    = "paused"
+  locations = ["aws:eu-central-1"]
+  request_definition {
+    method = "GET"
+    url    = "https://api.example.com/status"
+  }
+  request_query = {
+    env = "{{ ENV }}"
+  }
+  request_headers = {
+    X-Request-Id = "{{ REQUEST_ID }}"
+  }
+  request_basicauth {
+    username = "admin"
+    password = "{{ PASSWORD }}"
+  }
+  assertion {
+    type     = "statusCode"
+    operator = "is"
+    target   = "200"
+  }
+  assertion {
+    type     = "header"
+    property = "x-version"
+    operator = "moreThan"
+    target   = "1"
+  }
+  assertion {
+    type     = "body"
+    operator = "validatesJSONPath"
+    targetjsonpath {
+      jsonpath    = "$.items[0].id"
+      operator    = "is"
+      targetvalue = "1"
+    }
+  }
+  assertion {
+    type     = "responseTime"
+    operator = "lessThan"
+    target   = "10000"
+  }
+  config_variable {
+    type    = "text"
+    name    = "ENV"
+    pattern = "prod"
+  }
+  config_variable {
+    type    = "text"
+    name    = "REQUEST_ID"
+    pattern = "{{ numeric(6) }}"
+  }
+  config_variable {
+    type = "global"
+    name = "PASSWORD"
+    id   = "76636cd1-82e2-4aeb-9cfe-51366a8198a2"
+  }
+}`)
+
+	if _, err := datadog.RunSyntheticsAPITest(context.Background(), test, datadog.SyntheticsLocalRunOptions{Target: server.URL}); err == nil || !strings.Contains(err.Error(), "PASSWORD") {
+		t.Fatalf("expected an error for the missing global variable value, got %v", err)
+	}
+
+	result, err := datadog.RunSyntheticsAPITest(context.Background(), test, datadog.SyntheticsLocalRunOptions{
+		Target:    server.URL,
+		Variables: map[string]string{"PASSWORD": "s3cr3t"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSyntheticsLocalRun(t, result, true)
+	if len(result.Steps[0].Assertions) != 4 {
+		t.Fatalf("expected 4 assertions, got %d", len(result.Steps[0].Assertions))
+	}
+
+	result, err = datadog.RunSyntheticsAPITest(context.Background(), test, datadog.SyntheticsLocalRunOptions{
+		Target:    server.URL,
+		Variables: map[string]string{"PASSWORD": "wrong"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSyntheticsLocalRun(t, result, false)
+	if assertion := result.Steps[0].Assertions[0]; assertion.Passed || assertion.Actual != "401" {
+		t.Fatalf("expected the status code assertion to fail with 401, got %+v", assertion)
+	}
+}
+
+func TestSyntheticsLocalRun_retry(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first attempt only
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	test := loadSyntheticsLocalTest(t, `
+resource "datadog_synthetics_test" "api" {
+  name      = "local retry"
+  type      = "api"
+  subtype   = "http"
+  status    = "paused"
+  locations = ["aws:eu-central-1"]
+  request_definition {
+    method = "GET"
+    url    = "https://api.example.com/status"
+  }
+  assertion {
+    type     = "statusCode"
+    operator = "is"
+    target   = "200"
+  }
+  options_list {
+    tick_every = 900
+    retry {
+      count    = 1
+      interval = 10
+    }
+  }
+}`)
+
+	result, err := datadog.RunSyntheticsAPITest(context.Background(), test, datadog.SyntheticsLocalRunOptions{Target: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSyntheticsLocalRun(t, result, true)
+	if attempts := result.Steps[0].Attempts; attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestSyntheticsLocalRun_multistep(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("X-Session", "session-1")
+			fmt.Fprint(w, `{"token": "abc123"}`)
+		case "/me":
+			if r.Header.Get("Authorization") != "Bearer abc123" || r.Header.Get("X-Session") != "session-1" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `<user><name>jane</name></user>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	test := loadSyntheticsLocalTest(t, `
+resource "datadog_synthetics_test" "multi" {
+  name      = "local multistep"
+  type      = "api"
+  subtype   = "multi"
+  status    = "paused"
+  locations = ["aws:eu-central-1"]
+  api_step {
+    name = "login"
+    request_definition {
+      method = "POST"
+      url    = "https://api.example.com/login"
+      body   = jsonencode({ user = "jane" })
+    }
+    assertion {
+      type     = "statusCode"
+      operator = "is"
+      target   = "200"
+    }
+    extracted_value {
+      name = "TOKEN"
+      type = "http_body"
+      parser {
+        type  = "json_path"
+        value = "$.token"
+      }
+    }
+    extracted_value {
+      name   = "SESSION"
+      type   = "http_header"
+      field  = "x-session"
+      secure = true
+      parser {
+        type  = "regex"
+        value = "session-(\\d+)"
+      }
+    }
+  }
+  api_step {
+    name = "optional"
+    request_definition {
+      method = "GET"
+      url    = "https://api.example.com/missing"
+    }
+    assertion {
+      type     = "statusCode"
+      operator = "is"
+      target   = "200"
+    }
+    allow_failure = true
+  }
+  api_step {
+    name = "me"
+    request_definition {
+      method = "GET"
+      url    = "https://api.example.com/me"
+    }
+    request_headers = {
+      Authorization = "Bearer {{ TOKEN }}"
+      X-Session     = "session-{{ SESSION }}"
+    }
+    assertion {
+      type     = "body"
+      operator = "validatesXPath"
+      targetxpath {
+        xpath       = "/user/name"
+        operator    = "is"
+        targetvalue = "jane"
+      }
+    }
+  }
+}`)
+
+	result, err := datadog.RunSyntheticsAPITest(context.Background(), test, datadog.SyntheticsLocalRunOptions{Target: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSyntheticsLocalRun(t, result, true)
+	if len(result.Steps) != 3 || result.Steps[1].Passed {
+		t.Fatalf("expected the optional step to fail without failing the test, got %+v", result.Steps)
+	}
+	extracted := result.Steps[0].ExtractedValues
+	if len(extracted) != 2 || extracted[0].Value != "abc123" || extracted[1].Value != "1" || !extracted[1].Secure {
+		t.Fatalf("unexpected extracted values %+v", extracted)
+	}
+}
+
+func TestSyntheticsLocalRun_tcp(t *testing.T) {
+	t.Parallel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	test := loadSyntheticsLocalTest(t, `
+resource "datadog_synthetics_test" "tcp" {
+  name      = "local tcp"
+  type      = "api"
+  subtype   = "tcp"
+  status    = "paused"
+  locations = ["aws:eu-central-1"]
+  request_definition {
+    host = "db.example.com"
+    port = 5432
+  }
+  assertion {
+    type     = "connection"
+    operator = "is"
+    target   = "established"
+  }
+  assertion {
+    type     = "responseTime"
+    operator = "lessThan"
+    target   = "10000"
+  }
+}`)
+
+	result, err := datadog.RunSyntheticsAPITest(context.Background(), test, datadog.SyntheticsLocalRunOptions{Target: listener.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSyntheticsLocalRun(t, result, true)
+}
+
+func TestSyntheticsLocalRun_ssl(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	test := loadSyntheticsLocalTest(t, `
+resource "datadog_synthetics_test" "ssl" {
+  name      = "local ssl"
+  type      = "api"
+  subtype   = "ssl"
+  status    = "paused"
+  locations = ["aws:eu-central-1"]
+  request_definition {
+    host = "example.com"
+    port = 443
+  }
+  options_list {
+    tick_every         = 900
+    accept_self_signed = true
+  }
+  assertion {
+    type     = "certificate"
+    operator = "isInMoreThan"
+    target   = "10"
+  }
+  assertion {
+    type     = "tlsVersion"
+    operator = "moreThanOrEqual"
+    target   = "1.2"
+  }
+}`)
+
+	result, err := datadog.RunSyntheticsAPITest(context.Background(), test, datadog.SyntheticsLocalRunOptions{Target: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSyntheticsLocalRun(t, result, true)
+}
+
+func TestSyntheticsLocalRun_dns(t *testing.T) {
+	t.Parallel()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	go serveSyntheticsLocalDNS(conn, [4]byte{10, 0, 0, 1})
+
+	test := loadSyntheticsLocalTest(t, `
+resource "datadog_synthetics_test" "dns" {
+  name      = "local dns"
+  type      = "api"
+  subtype   = "dns"
+  status    = "paused"
+  locations = ["aws:eu-central-1"]
+  request_definition {
+    host       = "service.example.test"
+    dns_server = "8.8.8.8"
+  }
+  assertion {
+    type     = "recordSome"
+    property = "A"
+    operator = "is"
+    target   = "10.0.0.1"
+  }
+  assertion {
+    type     = "recordEvery"
+    property = "A"
+    operator = "matches"
+    target   = "^10\\."
+  }
+}`)
+
+	result, err := datadog.RunSyntheticsAPITest(context.Background(), test, datadog.SyntheticsLocalRunOptions{Target: conn.LocalAddr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSyntheticsLocalRun(t, result, true)
+}
+
+// serveSyntheticsLocalDNS answers every A query with the given address.
+func serveSyntheticsLocalDNS(conn net.PacketConn, address [4]byte) {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var parser dnsmessage.Parser
+		header, err := parser.Start(buf[:n])
+		if err != nil {
+			continue
+		}
+		question, err := parser.Question()
+		if err != nil {
+			continue
+		}
+		builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true, RecursionDesired: header.RecursionDesired})
+		builder.StartQuestions()
+		builder.Question(question)
+		builder.StartAnswers()
+		if question.Type == dnsmessage.TypeA {
+			builder.AResource(dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.AResource{A: address})
+		}
+		msg, err := builder.Finish()
+		if err != nil {
+			continue
+		}
+		conn.WriteTo(msg, addr)
+	}
+}
+
+func loadSyntheticsLocalTest(t *testing.T, config string) *datadogV1.SyntheticsAPITest {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "main.tf")
+	if err := os.WriteFile(filename, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	test, err := datadog.LoadSyntheticsAPITest([]string{filename}, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return test
+}
+
+func assertSyntheticsLocalRun(t *testing.T, result *datadog.SyntheticsLocalRunResult, passed bool) {
+	t.Helper()
+	if result.Passed == passed {
+		return
+	}
+	for _, step := range result.Steps {
+		t.Logf("step %q: passed=%t error=%q", step.Name, step.Passed, step.Error)
+		for _, assertion := range step.Assertions {
+			t.Logf("  %+v", assertion)
+		}
+	}
+	t.Fatalf("expected the test to pass: %t", passed)
+}
@@ -0,0 +1,5 @@
+data "datadog_dashboards" "test" {
+  title_filter       = "payments"
+  tags_filter        = ["team:payments"]
+  include_definition = true
+}
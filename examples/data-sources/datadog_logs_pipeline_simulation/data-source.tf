@@ -0,0 +1,42 @@
+# Check the processors of a pipeline against sample logs before applying it
+data "datadog_logs_pipeline_simulation" "nginx" {
+  pipeline {
+    name       = "nginx"
+    is_enabled = true
+    filter {
+      query = "source:nginx"
+    }
+    processor {
+      grok_parser {
+        name       = "access logs"
+        is_enabled = true
+        source     = "message"
+        samples    = []
+        grok {
+          support_rules = ""
+          match_rules   = "access %%{ipOrHost:network.client.ip} %%{word:http.method} %%{notSpace:http.url} %%{integer:http.status_code}"
+        }
+      }
+    }
+    processor {
+      category_processor {
+        is_enabled = true
+        target     = "http.status_category"
+        category {
+          name = "Error"
+          filter {
+            query = "@http.status_code:>=500"
+          }
+        }
+      }
+    }
+  }
+  events = [
+    jsonencode({ source = "nginx", message = "10.0.0.1 GET /checkout 503" }),
+    "a raw line is used as the message of the log",
+  ]
+}
+
+output "nginx_status_category" {
+  value = jsondecode(data.datadog_logs_pipeline_simulation.nginx.results[0].event).http.status_category
+}
@@ -0,0 +1,51 @@
+data "datadog_synthetics_browser_recording" "checkout" {
+  recording = file("${path.module}/recordings/checkout.json")
+}
+
+resource "datadog_synthetics_test" "checkout" {
+  name       = "Checkout"
+  type       = "browser"
+  status     = "live"
+  locations  = ["aws:eu-central-1"]
+  device_ids = ["laptop_large"]
+
+  request_definition {
+    method = "GET"
+    url    = data.datadog_synthetics_browser_recording.checkout.start_url
+  }
+
+  dynamic "browser_step" {
+    for_each = data.datadog_synthetics_browser_recording.checkout.browser_step
+    content {
+      name    = browser_step.value.name
+      type    = browser_step.value.type
+      timeout = browser_step.value.timeout
+
+      params {
+        # Enum attributes must be null rather than empty when unused
+        check      = browser_step.value.params[0].check != "" ? browser_step.value.params[0].check : null
+        click_type = browser_step.value.params[0].click_type != "" ? browser_step.value.params[0].click_type : null
+        code       = browser_step.value.params[0].code
+        element    = browser_step.value.params[0].element
+        modifiers  = browser_step.value.params[0].modifiers
+        value      = browser_step.value.params[0].value
+        x          = browser_step.value.params[0].x
+        y          = browser_step.value.params[0].y
+
+        dynamic "element_user_locator" {
+          for_each = browser_step.value.params[0].element_user_locator
+          content {
+            value {
+              type  = element_user_locator.value.value[0].type
+              value = element_user_locator.value.value[0].value
+            }
+          }
+        }
+      }
+    }
+  }
+
+  options_list {
+    tick_every = 3600
+  }
+}
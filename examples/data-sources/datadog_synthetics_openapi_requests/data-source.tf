@@ -0,0 +1,45 @@
+data "datadog_synthetics_openapi_requests" "payments" {
+  spec = file("${path.module}/openapi.yaml")
+  tags = ["public"]
+}
+
+resource "datadog_synthetics_test" "payments_contract" {
+  for_each = { for request in data.datadog_synthetics_openapi_requests.payments.request : request.operation_id => request }
+
+  name      = "Contract check: ${each.key}"
+  type      = "api"
+  subtype   = "http"
+  status    = "live"
+  locations = ["aws:eu-central-1"]
+
+  request_definition {
+    method    = each.value.method
+    url       = each.value.url
+    body      = each.value.body != "" ? each.value.body : null
+    body_type = each.value.body_type != "" ? each.value.body_type : null
+  }
+  request_headers = each.value.request_headers
+
+  dynamic "assertion" {
+    for_each = each.value.assertion
+    content {
+      type     = assertion.value.type
+      operator = assertion.value.operator
+      property = assertion.value.property != "" ? assertion.value.property : null
+      target   = assertion.value.target != "" ? assertion.value.target : null
+
+      dynamic "targetjsonpath" {
+        for_each = assertion.value.targetjsonpath
+        content {
+          operator    = targetjsonpath.value.operator
+          jsonpath    = targetjsonpath.value.jsonpath
+          targetvalue = targetjsonpath.value.targetvalue
+        }
+      }
+    }
+  }
+
+  options_list {
+    tick_every = 900
+  }
+}
@@ -0,0 +1,14 @@
+resource "datadog_synthetics_private_location" "worker" {
+  name = "Kubernetes worker"
+}
+
+data "datadog_synthetics_private_location_deployment" "worker" {
+  config    = datadog_synthetics_private_location.worker.config
+  namespace = "synthetics"
+}
+
+# Install the Helm chart with these values
+resource "local_sensitive_file" "helm_values" {
+  filename = "${path.module}/private-location-values.yaml"
+  content  = data.datadog_synthetics_private_location_deployment.worker.helm_values
+}
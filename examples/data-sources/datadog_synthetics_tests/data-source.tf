@@ -0,0 +1,24 @@
+# All the paused browser tests of the checkout team
+data "datadog_synthetics_tests" "checkout_paused_browser" {
+  type_filter   = "browser"
+  status_filter = "paused"
+  tags_filter   = ["team:checkout"]
+}
+
+# Mute the monitors of all the tests running from Frankfurt
+data "datadog_synthetics_tests" "frankfurt" {
+  locations_filter = ["aws:eu-central-1"]
+}
+
+resource "datadog_downtime_schedule" "frankfurt_maintenance" {
+  for_each = { for test in data.datadog_synthetics_tests.frankfurt.tests : test.public_id => test.monitor_id }
+
+  scope = "*"
+  monitor_identifier {
+    monitor_id = each.value
+  }
+  one_time_schedule {
+    start = "2050-01-02T03:04:05Z"
+    end   = "2050-01-02T05:04:05Z"
+  }
+}
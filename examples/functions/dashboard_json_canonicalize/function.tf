@@ -0,0 +1,4 @@
+# Detect drift between an exported dashboard and the one managed by Terraform
+output "dashboard_drift" {
+  value = provider::datadog::dashboard_json_canonicalize(file("exported_dashboard.json")) != provider::datadog::dashboard_json_canonicalize(datadog_dashboard_json.foo.dashboard)
+}
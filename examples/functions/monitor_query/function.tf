@@ -0,0 +1,11 @@
+resource "datadog_monitor" "cpu" {
+  name    = "High CPU usage"
+  type    = "metric alert"
+  message = "CPU usage is high on {{host.name}}"
+  # avg(last_5m):avg:system.cpu.user{env:prod,!host:my\ host} by {host} > 90
+  query = provider::datadog::monitor_query("avg", "last_5m", "avg", "system.cpu.user", ["env:prod", "!host:my host"], ["host"], ">", 90)
+
+  monitor_thresholds {
+    critical = 90
+  }
+}
@@ -0,0 +1,8 @@
+# Tag the monitor with the same value Datadog stores for the service tag
+resource "datadog_monitor" "foo" {
+  name    = "High latency on ${var.service}"
+  type    = "metric alert"
+  message = "Latency is high"
+  query   = "avg(last_5m):avg:trace.http.request.duration{service:${provider::datadog::normalize_tag(var.service)}} > 1"
+  tags    = ["service:${provider::datadog::normalize_tag(var.service)}"]
+}
@@ -0,0 +1,10 @@
+resource "datadog_service_level_objective" "foo" {
+  name        = "Example Monitor SLO"
+  type        = "monitor"
+  monitor_ids = [provider::datadog::parse_monitor_id_from_url("https://app.datadoghq.com/monitors/12345")]
+
+  thresholds {
+    timeframe = "7d"
+    target    = 99.9
+  }
+}
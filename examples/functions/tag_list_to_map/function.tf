@@ -0,0 +1,4 @@
+# "payments"
+output "monitor_team" {
+  value = provider::datadog::tag_list_to_map(datadog_monitor.foo.tags)["team"]
+}
@@ -0,0 +1,15 @@
+locals {
+  tags = {
+    env  = "prod"
+    team = "payments"
+  }
+}
+
+resource "datadog_monitor" "foo" {
+  name    = "Payments errors"
+  type    = "metric alert"
+  message = "Too many errors"
+  query   = "sum(last_5m):sum:trace.http.request.errors{service:payments}.as_count() > 10"
+  # ["env:prod", "team:payments"]
+  tags = provider::datadog::tag_map_to_list(local.tags)
+}
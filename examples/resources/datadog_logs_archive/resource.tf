@@ -2,9 +2,12 @@ resource "datadog_logs_archive" "my_s3_archive" {
   name  = "my s3 archive"
   query = "service:myservice"
   s3_archive {
-    bucket     = "my-bucket"
-    path       = "/path/foo"
-    account_id = "001234567888"
-    role_name  = "my-role-name"
+    bucket          = "my-bucket"
+    path            = "/path/foo"
+    account_id      = "001234567888"
+    role_name       = "my-role-name"
+    encryption_type = "SSE_KMS"
+    encryption_key  = "arn:aws:kms:us-east-1:001234567888:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+    storage_class   = "STANDARD_IA"
   }
 }
@@ -145,4 +145,51 @@ resource "datadog_logs_custom_pipeline" "sample_pipeline" {
       is_enabled = true
     }
   }
+  processor {
+    span_id_remapper {
+      sources    = ["dd.span_id"]
+      name       = "sample span id remapper"
+      is_enabled = true
+    }
+  }
+  processor {
+    array_processor {
+      name       = "sample array processor"
+      is_enabled = true
+      operation {
+        select {
+          source           = "http.headers"
+          target           = "http.referrer"
+          filter           = "name:Referrer"
+          value_to_extract = "value"
+        }
+      }
+    }
+  }
+  processor {
+    decoder_processor {
+      source                  = "encoded_payload"
+      target                  = "payload"
+      binary_to_text_encoding = "base64"
+      input_representation    = "utf_8"
+      name                    = "sample decoder processor"
+      is_enabled              = true
+    }
+  }
+  processor {
+    raw_processor {
+      definition = jsonencode({
+        type       = "schema-processor"
+        name       = "sample OCSF schema processor"
+        is_enabled = true
+        schema = {
+          schema_type = "ocsf"
+          version     = "1.5.0"
+          class_uid   = 3002
+          class_name  = "Authentication"
+        }
+        mappers = []
+      })
+    }
+  }
 }
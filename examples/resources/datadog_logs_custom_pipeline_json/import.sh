@@ -0,0 +1,3 @@
+# To find the pipeline ID, click the "edit" button in the UI to open the pipeline details.
+# The pipeline ID is the last part of the URL.
+terraform import datadog_logs_custom_pipeline_json.redis <pipelineID>
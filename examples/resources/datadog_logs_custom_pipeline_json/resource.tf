@@ -0,0 +1,34 @@
+# Pipeline exported from the Datadog UI. The computed fields like `id` are ignored.
+resource "datadog_logs_custom_pipeline_json" "redis" {
+  pipeline = <<-EOF
+{
+    "id": "aBcDeFgHiJkLmNoPqRsTuV",
+    "type": "pipeline",
+    "name": "Redis",
+    "is_enabled": true,
+    "is_read_only": false,
+    "filter": {
+        "query": "source:redis"
+    },
+    "processors": [
+        {
+            "type": "grok-parser",
+            "name": "Parse redis",
+            "is_enabled": true,
+            "source": "message",
+            "samples": ["INFO ready to accept connections"],
+            "grok": {
+                "support_rules": "",
+                "match_rules": "rule %%{word:level} %%{data:msg}"
+            }
+        },
+        {
+            "type": "status-remapper",
+            "name": "Define level as the status",
+            "is_enabled": true,
+            "sources": ["level"]
+        }
+    ]
+}
+EOF
+}
@@ -1,9 +1,10 @@
 # A sample Datadog logs index resource definition.
 
 resource "datadog_logs_index" "sample_index" {
-  name           = "your index"
-  daily_limit    = 200000
-  retention_days = 7
+  name            = "your index"
+  daily_limit     = 200000
+  retention_days  = 7
+  deletion_policy = "delete"
   filter {
     query = "*"
   }
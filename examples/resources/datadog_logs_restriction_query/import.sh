@@ -0,0 +1 @@
+terraform import datadog_logs_restriction_query.payments <restriction_query_id>
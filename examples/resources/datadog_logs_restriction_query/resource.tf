@@ -0,0 +1,4 @@
+# Only allow the payments team to see the logs of the payments service
+resource "datadog_logs_restriction_query" "payments" {
+  restriction_query = "service:payments"
+}
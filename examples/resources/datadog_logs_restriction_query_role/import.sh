@@ -0,0 +1,2 @@
+# The ID is made of the restriction query ID and the role ID, separated by a colon.
+terraform import datadog_logs_restriction_query_role.payments "<restriction_query_id>:<role_id>"
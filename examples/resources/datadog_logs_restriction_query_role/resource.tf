@@ -0,0 +1,12 @@
+resource "datadog_role" "payments" {
+  name = "Payments"
+}
+
+resource "datadog_logs_restriction_query" "payments" {
+  restriction_query = "service:payments"
+}
+
+resource "datadog_logs_restriction_query_role" "payments" {
+  restriction_query_id = datadog_logs_restriction_query.payments.id
+  role_id              = datadog_role.payments.id
+}
@@ -0,0 +1,2 @@
+# The local_file block isn't imported, as the path of uploaded files isn't returned by the API.
+terraform import datadog_reference_table.asset_owners <table_id>
@@ -0,0 +1,46 @@
+# A reference table uploaded from a CSV file stored next to the configuration
+resource "datadog_reference_table" "asset_owners" {
+  table_name  = "asset_owners"
+  description = "Owners of the cloud assets"
+  tags        = ["team:security"]
+
+  schema {
+    primary_key = "asset_id"
+    field {
+      name = "asset_id"
+    }
+    field {
+      name = "owner"
+    }
+    field {
+      name = "priority"
+      type = "INT32"
+    }
+  }
+
+  local_file {
+    path = "${path.module}/asset_owners.csv"
+  }
+}
+
+# A reference table synced from a CSV file stored in S3
+resource "datadog_reference_table" "business_units" {
+  table_name = "business_units"
+
+  schema {
+    primary_key = "account_id"
+    field {
+      name = "account_id"
+    }
+    field {
+      name = "business_unit"
+    }
+  }
+
+  s3_source {
+    bucket     = "my-reference-tables"
+    path       = "business_units.csv"
+    account_id = "001234567888"
+  }
+  sync_enabled = true
+}
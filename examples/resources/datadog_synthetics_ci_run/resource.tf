@@ -0,0 +1,12 @@
+# Run the synthetics tests of the service each time a new version is deployed
+resource "datadog_synthetics_ci_run" "deploy" {
+  public_ids = [datadog_synthetics_test.checkout.id, "abc-def-ghi"]
+
+  triggers = {
+    version = var.service_version
+  }
+
+  variables = {
+    ENV = "staging"
+  }
+}
@@ -4,3 +4,32 @@ resource "datadog_synthetics_global_variable" "test_variable" {
   tags        = ["foo:bar", "env:test"]
   value       = "variable-value"
 }
+
+# TOTP variable generating the one-time passwords of an MFA protected login flow
+resource "datadog_synthetics_global_variable" "mfa_token" {
+  name        = "EXAMPLE_MFA_TOKEN"
+  description = "MFA token of the test user"
+  tags        = ["foo:bar", "env:test"]
+  value       = "JBSWY3DPEHPK3PXP"
+  secure      = true
+  is_totp     = true
+  options {
+    totp_parameters {
+      digits           = 6
+      refresh_interval = 30
+    }
+  }
+}
+
+# Variable extracted by a step of a multistep API test
+resource "datadog_synthetics_global_variable" "session_token" {
+  name          = "EXAMPLE_SESSION_TOKEN"
+  description   = "Session token of the test user"
+  tags          = ["foo:bar", "env:test"]
+  value         = ""
+  parse_test_id = "abc-def-ghi"
+  parse_test_options {
+    type                = "local_variable"
+    local_variable_name = "SESSION_TOKEN"
+  }
+}
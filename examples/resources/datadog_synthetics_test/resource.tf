@@ -272,6 +272,43 @@ resource "datadog_synthetics_test" "test_browser" {
   }
 }
 
+# Example Usage (Synthetics Browser test playing a subtest)
+# Play the browser test above as a subtest before checking the dashboards page
+resource "datadog_synthetics_test" "test_browser_with_subtest" {
+  name       = "A Browser test playing a subtest"
+  type       = "browser"
+  status     = "paused"
+  device_ids = ["laptop_large"]
+  locations  = ["aws:eu-central-1"]
+
+  request_definition {
+    method = "GET"
+    url    = "https://app.datadoghq.com"
+  }
+
+  browser_step {
+    name = "Play the shared steps"
+    type = "playSubTest"
+    params {
+      playing_tab_id    = 0
+      subtest_public_id = datadog_synthetics_test.test_browser.id
+    }
+  }
+
+  browser_step {
+    name = "Check dashboards page"
+    type = "assertCurrentUrl"
+    params {
+      check = "contains"
+      value = "dashboard"
+    }
+  }
+
+  options_list {
+    tick_every = 3600
+  }
+}
+
 # Example Usage (GRPC API test)
 # Create a new Datadog GRPC API test starting on google.org:50050
 resource "datadog_synthetics_test" "grpc" {
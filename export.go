@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog"
+)
+
+// runExport implements the `export` subcommand, which generates Terraform configuration and
+// `import` blocks for existing Datadog objects. Credentials are read from the same environment
+// variables as the provider configuration (DD_API_KEY, DD_APP_KEY, DD_HOST).
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	resourceTypes := fs.String("resource-types", strings.Join(datadog.ExportResourceTypes(), ","), "comma separated list of resource types to export")
+	ids := fs.String("ids", "", "comma separated list of IDs to export, only valid with a single resource type")
+	nameFilter := fs.String("name", "", "only export objects whose name contains this value")
+	tagsFilter := fs.String("tags", "", "comma separated list of tags, only export objects having all of them")
+	output := fs.String("output", "", "file to write the configuration to, defaults to stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	types := splitExportFlag(*resourceTypes)
+	idList := splitExportFlag(*ids)
+	if len(idList) > 0 && len(types) != 1 {
+		return fmt.Errorf("-ids requires exactly one resource type in -resource-types")
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	meta, err := datadog.ConfigureExportProvider(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range types {
+		err := datadog.Export(ctx, meta, datadog.ExportOptions{
+			ResourceType: t,
+			IDs:          idList,
+			NameFilter:   *nameFilter,
+			TagsFilter:   splitExportFlag(*tagsFilter),
+		}, w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitExportFlag(v string) []string {
+	var values []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// runConvertDashboard implements the `convert-dashboard` subcommand, which converts a dashboard JSON
+// definition, as used by `datadog_dashboard_json`, into a `datadog_dashboard` resource. Parts of the
+// definition that can't be expressed with the typed resource are reported on stderr.
+func runConvertDashboard(args []string) error {
+	fs := flag.NewFlagSet("convert-dashboard", flag.ContinueOnError)
+	input := fs.String("input", "", "file to read the dashboard JSON from, defaults to stdin")
+	output := fs.String("output", "", "file to write the configuration to, defaults to stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *input != "" {
+		f, err := os.Open(*input)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	dashboardJSON, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	config, warnings, err := datadog.ConvertDashboardJSON(string(dashboardJSON))
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+
+	if *output != "" {
+		return os.WriteFile(*output, config, 0644)
+	}
+	_, err = os.Stdout.Write(config)
+	return err
+}
+
+// keyValueFlags collects repeated NAME=VALUE flags.
+type keyValueFlags map[string]string
+
+func (f keyValueFlags) String() string {
+	return ""
+}
+
+func (f keyValueFlags) Set(v string) error {
+	name, value, ok := strings.Cut(v, "=")
+	if !ok {
+		return fmt.Errorf("%q must be in the NAME=VALUE format", v)
+	}
+	f[name] = value
+	return nil
+}
+
+// runRunSyntheticsTest implements the `run-synthetics-test` subcommand, which runs a
+// `datadog_synthetics_test` API test from the local machine, for instance against a local service,
+// and prints the outcome of every assertion. It fails when the test fails.
+func runRunSyntheticsTest(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("run-synthetics-test", flag.ContinueOnError)
+	config := fs.String("config", ".", "Terraform configuration file or directory holding the test")
+	resourceName := fs.String("resource", "", "name of the datadog_synthetics_test resource, required when the configuration holds several tests")
+	target := fs.String("target", "", "URL, or host:port, to send the requests to instead of the ones of the test")
+	inputVariables := keyValueFlags{}
+	fs.Var(inputVariables, "var", "value of a Terraform input variable, as NAME=VALUE, can be repeated")
+	testVariables := keyValueFlags{}
+	fs.Var(testVariables, "variable", "value of a config variable of the test, as NAME=VALUE, can be repeated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filenames := []string{*config}
+	if info, err := os.Stat(*config); err != nil {
+		return err
+	} else if info.IsDir() {
+		filenames, _ = filepath.Glob(filepath.Join(*config, "*.tf"))
+		jsonFilenames, _ := filepath.Glob(filepath.Join(*config, "*.tf.json"))
+		filenames = append(filenames, jsonFilenames...)
+	}
+
+	test, err := datadog.LoadSyntheticsAPITest(filenames, strings.TrimPrefix(*resourceName, "datadog_synthetics_test."), inputVariables)
+	if err != nil {
+		return err
+	}
+	result, err := datadog.RunSyntheticsAPITest(ctx, test, datadog.SyntheticsLocalRunOptions{
+		Target:    *target,
+		Variables: testVariables,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, step := range result.Steps {
+		fmt.Printf("%s %s (%s)\n", syntheticsRunStatus(step.Passed, false), step.Name, step.Duration.Round(time.Millisecond))
+		if step.Error != "" {
+			fmt.Printf("    error: %s\n", step.Error)
+		}
+		for _, assertion := range step.Assertions {
+			fmt.Printf("    %s %s", syntheticsRunStatus(assertion.Passed, assertion.Skipped), assertion.Description)
+			if !assertion.Passed && assertion.Actual != "" {
+				fmt.Printf(", got %q", assertion.Actual)
+			}
+			if assertion.Message != "" {
+				fmt.Printf(": %s", assertion.Message)
+			}
+			fmt.Println()
+		}
+		for _, v := range step.ExtractedValues {
+			value := v.Value
+			if v.Secure {
+				value = "********"
+			}
+			fmt.Printf("    extracted %s = %s\n", v.Name, value)
+		}
+	}
+	if !result.Passed {
+		return fmt.Errorf("test %q failed", test.GetName())
+	}
+	fmt.Printf("test %q passed\n", test.GetName())
+	return nil
+}
+
+func syntheticsRunStatus(passed, skipped bool) string {
+	switch {
+	case skipped:
+		return "[SKIP]"
+	case passed:
+		return "[PASS]"
+	}
+	return "[FAIL]"
+}
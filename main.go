@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
@@ -17,6 +18,28 @@ import (
 func main() {
 	ctx := context.Background()
 
+	var command string
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+	switch command {
+	case "export":
+		if err := runExport(ctx, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	case "convert-dashboard":
+		if err := runConvertDashboard(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	case "run-synthetics-test":
+		if err := runRunSyntheticsTest(ctx, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	var debugMode bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")